@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationleadership provides access to the
+// ApplicationLeadership API facade, which lets model administrators
+// take control of application leadership.
+package applicationleadership
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ApplicationLeadership API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the
+// ApplicationLeadership API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ApplicationLeadership")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// TransferLeadership makes the named unit the leader of its
+// application once the current leader's lease runs out.
+func (c *Client) TransferLeadership(unitName string) error {
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	return c.call("TransferLeadership", names.NewUnitTag(unitName))
+}
+
+// PinLeadership stops the named application's leadership from
+// changing hands until it is unpinned.
+func (c *Client) PinLeadership(application string) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	return c.call("PinLeadership", names.NewApplicationTag(application))
+}
+
+// UnpinLeadership removes the current user's pin from the named
+// application's leadership.
+func (c *Client) UnpinLeadership(application string) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	return c.call("UnpinLeadership", names.NewApplicationTag(application))
+}
+
+func (c *Client) call(method string, tag names.Tag) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationleadership_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationleadership"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) newClient(c *gc.C, method, tag string, err *params.Error) (*applicationleadership.Client, *bool) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationLeadership")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, method)
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: tag}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{Error: err}}
+			return nil
+		})
+	return applicationleadership.NewClient(apiCaller), &called
+}
+
+func (s *clientSuite) TestTransferLeadership(c *gc.C) {
+	client, called := s.newClient(c, "TransferLeadership", "unit-redis-1", nil)
+	err := client.TransferLeadership("redis/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestTransferLeadershipError(c *gc.C) {
+	client, _ := s.newClient(c, "TransferLeadership", "unit-redis-1", &params.Error{
+		Message: `cannot transfer leadership of "redis": leadership pinned`,
+	})
+	err := client.TransferLeadership("redis/1")
+	c.Assert(err, gc.ErrorMatches, `cannot transfer leadership of "redis": leadership pinned`)
+}
+
+func (s *clientSuite) TestTransferLeadershipInvalidUnit(c *gc.C) {
+	client, called := s.newClient(c, "TransferLeadership", "", nil)
+	err := client.TransferLeadership("redis")
+	c.Assert(err, gc.ErrorMatches, `unit name "redis" not valid`)
+	c.Assert(*called, jc.IsFalse)
+}
+
+func (s *clientSuite) TestPinLeadership(c *gc.C) {
+	client, called := s.newClient(c, "PinLeadership", "application-redis", nil)
+	err := client.PinLeadership("redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestUnpinLeadership(c *gc.C) {
+	client, called := s.newClient(c, "UnpinLeadership", "application-redis", nil)
+	err := client.UnpinLeadership("redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestPinLeadershipInvalidApplication(c *gc.C) {
+	client, called := s.newClient(c, "PinLeadership", "", nil)
+	err := client.PinLeadership("redis/0")
+	c.Assert(err, gc.ErrorMatches, `application name "redis/0" not valid`)
+	c.Assert(*called, jc.IsFalse)
+}
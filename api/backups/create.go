@@ -13,11 +13,39 @@ import (
 // returns the metadata associated with the resulting backup and a
 // filename for download.
 func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+	return c.CreateOnStorage(notes, "", keepCopy, noDownload)
+}
+
+// CreateOnStorage sends a request to create a backup of juju's state,
+// storing the archive in the given backup storage target. An empty
+// target means the one given by the backup-storage controller config.
+// It returns the metadata associated with the resulting backup and a
+// filename for download.
+func (c *Client) CreateOnStorage(notes, storage string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+	return c.CreateEncrypted(notes, storage, keepCopy, noDownload, "", "")
+}
+
+// CreateEncrypted sends a request to create a backup of juju's state,
+// like CreateOnStorage, with the archive encrypted either with the
+// given passphrase or for the given armored OpenPGP public key. At
+// most one of them may be set.
+func (c *Client) CreateEncrypted(
+	notes, storage string, keepCopy, noDownload bool, passphrase, publicKey string,
+) (*params.BackupsMetadataResult, error) {
+	if storage != "" && c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("backup storage targets on this controller")
+	}
+	if (passphrase != "" || publicKey != "") && c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("encrypted backups on this controller")
+	}
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
 		Notes:      notes,
 		KeepCopy:   keepCopy,
 		NoDownload: noDownload,
+		Storage:    storage,
+		Passphrase: passphrase,
+		PublicKey:  publicKey,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -47,3 +48,80 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateOnStorage(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Storage, gc.Equals, "s3://bucket")
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+				result.Storage = p.Storage
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateOnStorage("important", "s3://bucket", false, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Storage, gc.Equals, "s3://bucket")
+}
+
+func (s *createSuite) TestCreateOnStorageNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 2,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.CreateOnStorage("important", "s3://bucket", false, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 4,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Passphrase, gc.Equals, "sekrit")
+			c.Check(p.PublicKey, gc.Equals, "")
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+				result.Encrypted = true
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateEncrypted("important", "", false, false, "sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsTrue)
+}
+
+func (s *createSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.CreateEncrypted("important", "", false, false, "sekrit", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
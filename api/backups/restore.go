@@ -67,8 +67,14 @@ func prepareRestore(newClient ClientConnection) error {
 	return errors.Annotatef(err, "could not start restore process: %v", remoteError)
 }
 
-// RestoreReader restores the contents of backupFile as backup.
-func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient ClientConnection) error {
+// RestoreReader restores the contents of backupFile as backup. If the
+// backup is encrypted, key must hold what is needed to decrypt it.
+func (c *Client) RestoreReader(
+	r io.ReadSeeker, meta *params.BackupsMetadataResult, key *params.BackupDecryptionKey, newClient ClientConnection,
+) error {
+	if err := c.checkDecryptionKey(key); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
@@ -83,7 +89,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 	list := results.List
 	for _, b := range list {
 		if b.Checksum == meta.Checksum {
-			return c.restore(b.ID, newClient)
+			return c.restore(b.ID, key, newClient)
 		}
 	}
 
@@ -95,16 +101,29 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
 
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, key, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// If the backup is encrypted, key must hold what is needed to decrypt it.
+func (c *Client) Restore(backupId string, key *params.BackupDecryptionKey, newClient ClientConnection) error {
+	if err := c.checkDecryptionKey(key); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, key, newClient)
+}
+
+// checkDecryptionKey returns an error if a decryption key is given
+// to a controller that cannot restore encrypted backups.
+func (c *Client) checkDecryptionKey(key *params.BackupDecryptionKey) error {
+	if key != nil && c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("encrypted backups on this controller")
+	}
+	return nil
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -120,12 +139,13 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // It takes backupId as the identifier for the remote backup file and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId string, key *params.BackupDecryptionKey, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
+		BackupId:      backupId,
+		DecryptionKey: key,
 	}
 
 	cleanExit := false
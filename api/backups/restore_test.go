@@ -50,5 +50,5 @@ func (s *restoreSuite) TestRestoreFromFileBackupExistsOnController(c *gc.C) {
 		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
 	}
 	mockBackupsClient, _ := connFunc()
-	mockBackupsClient.RestoreReader(nil, &testBackupResults, connFunc)
+	mockBackupsClient.RestoreReader(nil, &testBackupResults, nil, connFunc)
 }
@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RestoreModel restores a single model from the identified backup,
+// without restoring the rest of the controller. If newName is set the
+// model is restored alongside any existing model, with that name and
+// a new UUID; otherwise, if replace is set, the model replaces the
+// existing model with the same UUID. key must be set to restore from
+// an encrypted backup.
+func (c *Client) RestoreModel(backupId, modelUUID, newName string, replace bool, key *params.BackupDecryptionKey) (params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	if c.facade.BestAPIVersion() < 5 {
+		return result, errors.NotSupportedf("restoring a single model on this controller")
+	}
+	args := params.RestoreModelArgs{
+		BackupId:      backupId,
+		ModelUUID:     modelUUID,
+		NewName:       newName,
+		Replace:       replace,
+		DecryptionKey: key,
+	}
+	err := c.facade.FacadeCall("RestoreModel", args, &result)
+	return result, errors.Trace(err)
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type restoreModelSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreModelSuite{})
+
+func (s *restoreModelSuite) TestRestoreModel(c *gc.C) {
+	key := &params.BackupDecryptionKey{Passphrase: "sekrit"}
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 5,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "RestoreModel")
+			c.Check(paramsIn, jc.DeepEquals, params.RestoreModelArgs{
+				BackupId:      "some-id",
+				ModelUUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				NewName:       "restored",
+				DecryptionKey: key,
+			})
+			result, ok := resp.(*params.RestoreModelResult)
+			c.Assert(ok, jc.IsTrue)
+			*result = params.RestoreModelResult{
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+				Name:      "restored",
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.RestoreModel("some-id", "deadbeef-0bad-400d-8000-4b1d0d06f00d", "restored", false, key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.RestoreModelResult{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+		Name:      "restored",
+	})
+}
+
+func (s *restoreModelSuite) TestRestoreModelNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 4,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.RestoreModel("some-id", "deadbeef-0bad-400d-8000-4b1d0d06f00d", "", true, nil)
+	c.Assert(err, gc.ErrorMatches, "restoring a single model on this controller not supported")
+}
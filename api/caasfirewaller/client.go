@@ -130,6 +130,28 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// RelatedApplications returns the names of the applications
+// the specified CAAS application is related to.
+func (c *Client) RelatedApplications(appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("RelatedApplications", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
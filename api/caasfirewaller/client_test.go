@@ -66,6 +66,49 @@ func (s *FirewallerSuite) TestIsExposedError(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *FirewallerSuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RelatedApplications")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"mysql"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *FirewallerSuite) TestRelatedApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "bletch",
+			}}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.RelatedApplications("gitlab")
+	c.Assert(err, gc.ErrorMatches, "bletch")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *FirewallerSuite) TestIsExposedInvalidEntityame(c *gc.C) {
 	client := caasfirewaller.NewClient(basetesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
 		return errors.New("should not be called")
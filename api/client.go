@@ -43,9 +43,19 @@ type Client struct {
 
 // Status returns the status of the juju model.
 func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
+	return c.FilteredStatus(params.StatusParams{Patterns: patterns})
+}
+
+// FilteredStatus returns the status of the juju model, restricted to
+// the entities matching the patterns and the units matching the
+// workload status, agent status and message filters in args.
+func (c *Client) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	statusFiltered := len(args.WorkloadStatuses) > 0 || len(args.AgentStatuses) > 0 || args.Message != ""
+	if c.facade.BestAPIVersion() < 3 && statusFiltered {
+		return nil, errors.New("filtering status by workload status, agent status or message not supported by the controller")
+	}
 	var result params.FullStatus
-	p := params.StatusParams{Patterns: patterns}
-	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+	if err := c.facade.FacadeCall("FullStatus", args, &result); err != nil {
 		return nil, err
 	}
 	// Older servers don't fill out model type, but
@@ -56,6 +66,19 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusTimeline returns the status history of the model's
+// applications, machines and units, merged and ordered by time.
+func (c *Client) StatusTimeline(args params.StatusTimelineRequest) ([]params.StatusTimelineEntry, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("status timeline on this version of Juju")
+	}
+	var result params.StatusTimeline
+	if err := c.facade.FacadeCall("StatusTimeline", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
@@ -758,3 +758,45 @@ func (s *IsolatedClientSuite) TestFindAllErrorsOnOlderController(c *gc.C) {
 	_, err := client.FindTools(0, 0, "", "", "proposed")
 	c.Assert(err, gc.ErrorMatches, "passing agent-stream not supported by the controller")
 }
+
+func (s *IsolatedClientSuite) TestStatusTimeline(c *gc.C) {
+	from := time.Unix(1000, 0)
+	var called bool
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "StatusTimeline")
+			c.Check(arg, jc.DeepEquals, params.StatusTimelineRequest{
+				From:  &from,
+				Kinds: []string{"workload"},
+			})
+			*(result.(*params.StatusTimeline)) = params.StatusTimeline{
+				Entries: []params.StatusTimelineEntry{{
+					Tag:    "unit-mysql-0",
+					Status: params.DetailedStatus{Status: "active", Kind: "workload"},
+				}},
+			}
+			return nil
+		}),
+	}
+	client := api.APIClient(apiCaller)
+	entries, err := client.StatusTimeline(params.StatusTimelineRequest{
+		From:  &from,
+		Kinds: []string{"workload"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entries, jc.DeepEquals, []params.StatusTimelineEntry{{
+		Tag:    "unit-mysql-0",
+		Status: params.DetailedStatus{Status: "active", Kind: "workload"},
+	}})
+}
+
+func (s *IsolatedClientSuite) TestStatusTimelineNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 3}
+	client := api.APIClient(apiCaller)
+	_, err := client.StatusTimeline(params.StatusTimelineRequest{Size: 10})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
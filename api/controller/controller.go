@@ -257,6 +257,17 @@ func (c *Client) ConfigSet(values map[string]interface{}) error {
 	)
 }
 
+// BackupStatus reports when the most recent successful backup of the
+// controller finished, and its ID.
+func (c *Client) BackupStatus() (params.ControllerBackupStatus, error) {
+	var result params.ControllerBackupStatus
+	if c.BestAPIVersion() < 6 {
+		return result, errors.NotSupportedf("backup status on this controller version")
+	}
+	err := c.facade.FacadeCall("BackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
@@ -297,16 +308,133 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRun checks whether the migration described by spec
+// would succeed, without starting it. It returns every problem found
+// with the model, the source controller and the target controller; no
+// problems means the migration is expected to succeed.
+func (c *Client) MigrationDryRun(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("migration dry run on this controller version")
+	}
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Problems, nil
+}
+
+// MigrationProgress reports the progress of the identified model
+// migrations, in the same order as the IDs. The progress of each
+// migration may be reported with an error, such as when the ID is not
+// known to the controller.
+func (c *Client) MigrationProgress(ids []string) ([]params.MigrationProgressResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("migration progress on this controller version")
+	}
+	args := params.MigrationProgressArgs{MigrationIds: ids}
+	response := params.MigrationProgressResults{}
+	if err := c.facade.FacadeCall("MigrationProgress", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(response.Results))
+	}
+	return response.Results, nil
+}
+
+// RebuildModel starts rebuilding the model identified by the spec on
+// the target controller's cloud, in the given region and using the
+// model owner's named credential there. An empty region means the
+// target controller's own region. The model is rebuilt in the
+// background, under a new model UUID, and the source model is left in
+// place. The returned ID is passed to RebuildProgress to follow the
+// rebuild, which is aborted if this connection is closed first.
+func (c *Client) RebuildModel(spec MigrationSpec, region, credential string) (string, error) {
+	if c.BestAPIVersion() < 9 {
+		return "", errors.NotSupportedf("rebuilding models on this controller version")
+	}
+	migrationArgs, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	args := params.RebuildModelArgs{
+		Specs: []params.RebuildModelSpec{{
+			ModelTag:        migrationArgs.Specs[0].ModelTag,
+			TargetInfo:      migrationArgs.Specs[0].TargetInfo,
+			CloudRegion:     region,
+			CloudCredential: credential,
+		}},
+	}
+	response := params.RebuildModelResults{}
+	if err := c.facade.FacadeCall("RebuildModel", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(response.Results))
+	}
+	if err := response.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return response.Results[0].RebuildId, nil
+}
+
+// RebuildProgress reports the progress of the identified model
+// rebuilds, started with RebuildModel on this connection.
+func (c *Client) RebuildProgress(ids []string) ([]params.RebuildProgressResult, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("rebuilding models on this controller version")
+	}
+	args := params.RebuildProgressArgs{RebuildIds: ids}
+	response := params.RebuildProgressResults{}
+	if err := c.facade.FacadeCall("RebuildProgress", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(response.Results))
+	}
+	return response.Results, nil
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -318,19 +446,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
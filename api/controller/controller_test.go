@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -380,3 +381,215 @@ func (s *Suite) TestConfigSetAgainstOlderAPIVersion(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support updating controller config")
 }
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	finished := time.Date(2018, time.June, 13, 2, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Assert(objType, gc.Equals, "Controller")
+			c.Assert(version, gc.Equals, 6)
+			c.Assert(request, gc.Equals, "BackupStatus")
+			c.Assert(args, gc.IsNil)
+			*result.(*params.ControllerBackupStatus) = params.ControllerBackupStatus{
+				LastSuccessful:   &finished,
+				LastSuccessfulID: "20180613-020000.deadbeef",
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.ControllerBackupStatus{
+		LastSuccessful:   &finished,
+		LastSuccessfulID: "20180613-020000.deadbeef",
+	})
+}
+
+func (s *Suite) TestBackupStatusAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	spec := makeSpec()
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "MigrationDryRun")
+			c.Check(args, jc.DeepEquals, specToArgs(spec))
+			*result.(*params.MigrationDryRunResults) = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Problems: []string{"source: model is dying", "target: model with same UUID already exists"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	problems, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, jc.DeepEquals, []string{"source: model is dying", "target: model with same UUID already exists"})
+}
+
+func (s *Suite) TestMigrationDryRunError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			*result.(*params.MigrationDryRunResults) = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	problems, err := client.MigrationDryRun(makeSpec())
+	c.Check(problems, gc.HasLen, 0)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationDryRunAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 6}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestMigrationProgress(c *gc.C) {
+	changed := time.Date(2018, time.June, 13, 2, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "MigrationProgress")
+			c.Check(args, jc.DeepEquals, params.MigrationProgressArgs{MigrationIds: []string{"uuid:0"}})
+			*result.(*params.MigrationProgressResults) = params.MigrationProgressResults{
+				Results: []params.MigrationProgressResult{{
+					MigrationId:      "uuid:0",
+					Phase:            "IMPORT",
+					PhaseChangedTime: changed,
+					StatusMessage:    "uploading model binaries into target controller",
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	progress, err := client.MigrationProgress([]string{"uuid:0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress, jc.DeepEquals, []params.MigrationProgressResult{{
+		MigrationId:      "uuid:0",
+		Phase:            "IMPORT",
+		PhaseChangedTime: changed,
+		StatusMessage:    "uploading model binaries into target controller",
+	}})
+}
+
+func (s *Suite) TestMigrationProgressResultMismatch(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationProgress([]string{"uuid:0"})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *Suite) TestMigrationProgressAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 7}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationProgress([]string{"uuid:0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestRebuildModel(c *gc.C) {
+	spec := makeSpec()
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "RebuildModel")
+			migrationArgs := specToArgs(spec)
+			c.Check(args, jc.DeepEquals, params.RebuildModelArgs{
+				Specs: []params.RebuildModelSpec{{
+					ModelTag:        migrationArgs.Specs[0].ModelTag,
+					TargetInfo:      migrationArgs.Specs[0].TargetInfo,
+					CloudRegion:     "west",
+					CloudCredential: "cred",
+				}},
+			})
+			*result.(*params.RebuildModelResults) = params.RebuildModelResults{
+				Results: []params.RebuildModelResult{{RebuildId: "42"}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	id, err := client.RebuildModel(spec, "west", "cred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "42")
+}
+
+func (s *Suite) TestRebuildModelError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			*result.(*params.RebuildModelResults) = params.RebuildModelResults{
+				Results: []params.RebuildModelResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.RebuildModel(makeSpec(), "", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestRebuildModelAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 8}
+	client := controller.NewClient(apiCaller)
+	_, err := client.RebuildModel(makeSpec(), "", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestRebuildProgress(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "RebuildProgress")
+			c.Check(args, jc.DeepEquals, params.RebuildProgressArgs{RebuildIds: []string{"42"}})
+			*result.(*params.RebuildProgressResults) = params.RebuildProgressResults{
+				Results: []params.RebuildProgressResult{{
+					RebuildId:     "42",
+					StatusMessage: "activating model on target controller",
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	progress, err := client.RebuildProgress([]string{"42"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress, jc.DeepEquals, []params.RebuildProgressResult{{
+		RebuildId:     "42",
+		StatusMessage: "activating model on target controller",
+	}})
+}
+
+func (s *Suite) TestRebuildProgressAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 8}
+	client := controller.NewClient(apiCaller)
+	_, err := client.RebuildProgress([]string{"42"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -19,22 +19,23 @@ var facadeVersions = map[string]int{
 	"AllWatcher":                   1,
 	"Annotations":                  2,
 	"Application":                  8,
+	"ApplicationLeadership":        1,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"Backups":                      5,
 	"Block":                        2,
 	"Bundle":                       2,
 	"CAASAgent":                    1,
-	"CAASFirewaller":               1,
+	"CAASFirewaller":               2,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
 	"CAASUnitProvisioner":          1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       2,
+	"Client":                       4,
 	"Cloud":                        2,
-	"Controller":                   5,
+	"Controller":                   9,
 	"CredentialManager":            1,
 	"CredentialValidator":          1,
 	"CrossController":              1,
@@ -47,7 +48,7 @@ var facadeVersions = map[string]int{
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   5,
 	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"HighAvailability":             4,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
@@ -71,9 +72,9 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              3,
 	"ModelConfig":                  2,
-	"ModelManager":                 4,
+	"ModelManager":                 5,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
@@ -4,9 +4,12 @@
 package highavailability
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -55,6 +58,35 @@ func (c *Client) EnableHA(
 	return result.Result, nil
 }
 
+// RemoveController decommissions the controller machine with the
+// given ID: its vote moves to another controller machine, unless
+// replace is false, and it is removed from the mongo replica set and
+// raft cluster before the machine itself is removed.
+func (c *Client) RemoveController(machineId string, replace bool) (params.ControllersChanges, error) {
+	if c.BestAPIVersion() < 4 {
+		return params.ControllersChanges{}, errors.NotSupportedf("removing controller machines")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.ControllersChanges{}, errors.NotValidf("machine ID %q", machineId)
+	}
+	arg := params.RemoveControllersArgs{
+		Entities:      []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+		NoReplacement: !replace,
+	}
+	var results params.ControllersChangeResults
+	if err := c.facade.FacadeCall("RemoveControllers", arg, &results); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ControllersChanges{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ControllersChanges{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // MongoUpgradeMode will make all Slave members of the HA
 // to shut down their mongo server.
 func (c *Client) MongoUpgradeMode(v mongo.Version) (params.MongoUpgradeResults, error) {
@@ -83,3 +115,23 @@ func (c *Client) ResumeHAReplicationAfterUpgrade(members []replicaset.Member) er
 	}
 	return nil
 }
+
+// RehearseFailover steps down the controller's mongo primary and/or
+// raft leader and waits, for up to the given timeout, for the
+// controllers to elect new ones. It returns how each service
+// recovered.
+func (c *Client) RehearseFailover(stepDownMongo, stepDownRaft bool, timeout time.Duration) ([]params.FailoverResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("rehearsing failover")
+	}
+	arg := params.RehearseFailoverArgs{
+		Mongo:   stepDownMongo,
+		Raft:    stepDownRaft,
+		Timeout: timeout,
+	}
+	var results params.RehearseFailoverResults
+	if err := c.facade.FacadeCall("RehearseFailover", arg, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type failoverSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&failoverSuite{})
+
+func (s *failoverSuite) TestRehearseFailover(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "HighAvailability")
+			c.Check(request, gc.Equals, "RehearseFailover")
+			c.Check(arg, jc.DeepEquals, params.RehearseFailoverArgs{
+				Mongo:   true,
+				Raft:    true,
+				Timeout: time.Minute,
+			})
+			*(result.(*params.RehearseFailoverResults)) = params.RehearseFailoverResults{
+				Results: []params.FailoverResult{{
+					Service:      "mongo",
+					OldLeader:    "0",
+					NewLeader:    "1",
+					RecoveryTime: 12 * time.Second,
+				}, {
+					Service: "raft",
+					Error:   &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := highavailability.NewClient(apiCaller)
+	results, err := client.RehearseFailover(true, true, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.FailoverResult{{
+		Service:      "mongo",
+		OldLeader:    "0",
+		NewLeader:    "1",
+		RecoveryTime: 12 * time.Second,
+	}, {
+		Service: "raft",
+		Error:   &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *failoverSuite) TestRehearseFailoverNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.RehearseFailover(true, false, time.Minute)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type removeControllerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&removeControllerSuite{})
+
+func (s *removeControllerSuite) TestRemoveController(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "HighAvailability")
+			c.Check(request, gc.Equals, "RemoveControllers")
+			c.Check(arg, jc.DeepEquals, params.RemoveControllersArgs{
+				Entities: []params.Entity{{Tag: "machine-1"}},
+			})
+			*(result.(*params.ControllersChangeResults)) = params.ControllersChangeResults{
+				Results: []params.ControllersChangeResult{{
+					Result: params.ControllersChanges{
+						Removed:  []string{"machine-1"},
+						Promoted: []string{"machine-3"},
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := highavailability.NewClient(apiCaller)
+	changes, err := client.RemoveController("1", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, params.ControllersChanges{
+		Removed:  []string{"machine-1"},
+		Promoted: []string{"machine-3"},
+	})
+}
+
+func (s *removeControllerSuite) TestRemoveControllerNoReplacement(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(arg, jc.DeepEquals, params.RemoveControllersArgs{
+				Entities:      []params.Entity{{Tag: "machine-1"}},
+				NoReplacement: true,
+			})
+			*(result.(*params.ControllersChangeResults)) = params.ControllersChangeResults{
+				Results: []params.ControllersChangeResult{{
+					Result: params.ControllersChanges{Removed: []string{"machine-1"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := highavailability.NewClient(apiCaller)
+	changes, err := client.RemoveController("1", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, params.ControllersChanges{Removed: []string{"machine-1"}})
+}
+
+func (s *removeControllerSuite) TestRemoveControllerError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.ControllersChangeResults)) = params.ControllersChangeResults{
+				Results: []params.ControllersChangeResult{{
+					Error: &params.Error{Message: "machine 0 is the only controller machine"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.RemoveController("0", true)
+	c.Assert(err, gc.ErrorMatches, "machine 0 is the only controller machine")
+}
+
+func (s *removeControllerSuite) TestRemoveControllerNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.RemoveController("1", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -43,14 +43,42 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := migrationModelInfo(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// DryRun asks the target controller for every reason it would not
+// accept the migration of the model described, without importing
+// anything. No problems means the target would accept the model.
+func (c *Client) DryRun(model coremigration.ModelInfo, bytes []byte) ([]error, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("migration dry run on target controller")
+	}
+	args := params.MigrationDryRunArgs{
+		ModelInfo: migrationModelInfo(model),
+		Bytes:     bytes,
+	}
+	var results params.ErrorResults
+	if err := c.caller.FacadeCall("DryRun", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []error
+	for _, result := range results.Results {
+		if result.Error != nil {
+			problems = append(problems, result.Error)
+		}
+	}
+	return problems, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
@@ -60,6 +88,22 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ImportRebuild sends a serialized model description to the target
+// controller, to be rebuilt on the controller's cloud in the given
+// region using the model owner's named credential. The model's
+// machines are provisioned afresh on the target cloud.
+func (c *Client) ImportRebuild(bytes []byte, region, credential string) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("rebuilding models on target controller")
+	}
+	args := params.RebuildImportArgs{
+		Bytes:           bytes,
+		CloudRegion:     region,
+		CloudCredential: credential,
+	}
+	return c.caller.FacadeCall("ImportRebuild", args, nil)
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
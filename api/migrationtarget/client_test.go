@@ -89,6 +89,91 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{
+				{Error: &params.Error{Message: "model with same UUID already exists (uuid)"}},
+				{Error: &params.Error{Message: "can't import models with remote applications"}},
+			},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{apiCaller, 2})
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	problems, err := client.DryRun(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 2)
+	c.Check(problems[0], gc.ErrorMatches, `model with same UUID already exists \(uuid\)`)
+	c.Check(problems[1], gc.ErrorMatches, "can't import models with remote applications")
+
+	expectedArg := params.MigrationDryRunArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   "uuid",
+			Name:                   "name",
+			OwnerTag:               ownerTag.String(),
+			AgentVersion:           vers,
+			ControllerAgentVersion: vers,
+		},
+		Bytes: []byte("foo"),
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.DryRun", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestDryRunNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	_, err := client.DryRun(coremigration.ModelInfo{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportRebuild(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return errors.New("boom")
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{apiCaller, 3})
+
+	err := client.ImportRebuild([]byte("foo"), "region", "cred")
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.RebuildImportArgs{
+		Bytes:           []byte("foo"),
+		CloudRegion:     "region",
+		CloudCredential: "cred",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportRebuild", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestImportRebuildNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{apiCaller, 2})
+
+	err := client.ImportRebuild(nil, "", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
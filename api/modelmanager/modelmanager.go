@@ -277,6 +277,25 @@ func (c *Client) ListModelSummaries(user string, all bool) ([]base.UserModelSumm
 	return summaries, nil
 }
 
+// ListUnhealthyEntities returns the statuses of the machines and units
+// that need attention, across the models that the given user has access
+// to. If all is true, controller admins get the statuses from all of
+// the models in the controller.
+func (c *Client) ListUnhealthyEntities(user string, all bool) ([]params.UnhealthyEntity, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("listing unhealthy entities on this controller")
+	}
+	if !names.IsValidUser(user) {
+		return nil, errors.Errorf("invalid user name %q", user)
+	}
+	in := params.ModelSummariesRequest{UserTag: names.NewUserTag(user).String(), All: all}
+	var out params.UnhealthyEntities
+	if err := c.facade.FacadeCall("ListUnhealthyEntities", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Entities, nil
+}
+
 func (c *Client) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
 	entities := params.Entities{
 		Entities: make([]params.Entity, len(tags)),
@@ -536,6 +536,42 @@ func (s *modelmanagerSuite) TestListModelSummariesServerError(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *modelmanagerSuite) TestListUnhealthyEntities(c *gc.C) {
+	entities := []params.UnhealthyEntity{{
+		ModelUUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:     "default",
+		ModelOwnerTag: "user-admin",
+		Tag:           "unit-mysql-0",
+		Kind:          "workload",
+		Status:        params.EntityStatus{Status: status.Blocked, Info: "missing relation"},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListUnhealthyEntities")
+			c.Check(arg, gc.Equals, params.ModelSummariesRequest{
+				UserTag: "user-commander",
+				All:     true,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.UnhealthyEntities{})
+			result.(*params.UnhealthyEntities).Entities = entities
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	results, err := client.ListUnhealthyEntities("commander", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, entities)
+}
+
+func (s *modelmanagerSuite) TestListUnhealthyEntitiesNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.ListUnhealthyEntities("commander", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type dumpModelSuite struct {
 	coretesting.BaseSuite
 }
@@ -43,6 +43,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/action"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/applicationleadership"
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
@@ -144,11 +145,15 @@ func AllFacades() *facade.Registry {
 	reg("Application", 7, application.NewFacadeV7)
 	reg("Application", 8, application.NewFacadeV8)
 
+	reg("ApplicationLeadership", 1, applicationleadership.NewFacade)
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
+	reg("Backups", 3, backups.NewFacadeV3) // adds storage targets
+	reg("Backups", 4, backups.NewFacadeV4) // adds encryption
+	reg("Backups", 5, backups.NewFacadeV5) // adds single model restore
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
@@ -156,13 +161,16 @@ func AllFacades() *facade.Registry {
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacadeV3) // adds status filters to FullStatus
+	reg("Client", 4, client.NewFacade)   // adds StatusTimeline
 	reg("Cloud", 1, cloud.NewFacade)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds CredentialContents, RemoveCloud
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacade)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacade) // adds RelatedApplications
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
 	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
@@ -171,6 +179,10 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
 	reg("Controller", 5, controller.NewControllerAPIv5)
+	reg("Controller", 6, controller.NewControllerAPIv6) // adds BackupStatus
+	reg("Controller", 7, controller.NewControllerAPIv7) // adds MigrationDryRun
+	reg("Controller", 8, controller.NewControllerAPIv8) // adds MigrationProgress
+	reg("Controller", 9, controller.NewControllerAPIv9) // adds RebuildModel
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
@@ -184,7 +196,9 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
-	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPIV2)
+	reg("HighAvailability", 3, highavailability.NewHighAvailabilityAPIV3) // adds RehearseFailover
+	reg("HighAvailability", 4, highavailability.NewHighAvailabilityAPIV4) // adds RemoveControllers
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
@@ -218,13 +232,16 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacadeV2) // adds DryRun
+	reg("MigrationTarget", 3, migrationtarget.NewFacade)   // adds ImportRebuild
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
+	reg("ModelManager", 5, modelmanager.NewFacadeV5) // adds ListUnhealthyEntities
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
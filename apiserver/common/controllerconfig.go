@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -29,14 +30,29 @@ func NewControllerConfig(st state.ControllerAccessor) *ControllerConfigAPI {
 	}
 }
 
-// ControllerConfig returns the controller's configuration.
+// secretControllerConfigKeys are the controller config attributes that
+// are only used by the controller itself, and are never sent to clients
+// or agents.
+var secretControllerConfigKeys = []string{
+	controller.BackupS3AccessKey,
+	controller.BackupS3SecretKey,
+}
+
+// ControllerConfig returns the controller's configuration,
+// without the attributes that hold the controller's secrets.
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = make(params.ControllerConfig)
+	for k, v := range config {
+		result.Config[k] = v
+	}
+	for _, key := range secretControllerConfigKeys {
+		delete(result.Config, key)
+	}
 	return result, nil
 }
 
@@ -33,18 +33,23 @@ var _ = gc.Suite(&controllerConfigSuite{})
 
 type fakeControllerAccessor struct {
 	controllerConfigError error
+	extraConfig           map[string]interface{}
 }
 
 func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 	if f.controllerConfigError != nil {
 		return nil, f.controllerConfigError
 	}
-	return map[string]interface{}{
+	cfg := map[string]interface{}{
 		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
-	}, nil
+	}
+	for k, v := range f.extraConfig {
+		cfg[k] = v
+	}
+	return cfg, nil
 }
 
 func (f *fakeControllerAccessor) ControllerInfo(modelUUID string) ([]string, string, error) {
@@ -73,6 +78,27 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (*controllerConfigSuite) TestControllerConfigOmitsSecrets(c *gc.C) {
+	cc := common.NewControllerConfig(
+		&fakeControllerAccessor{
+			extraConfig: map[string]interface{}{
+				controller.BackupStorage:     "s3://backups/juju",
+				controller.BackupS3AccessKey: "access",
+				controller.BackupS3SecretKey: "secret",
+			},
+		},
+	)
+	result, err := cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(map[string]interface{}(result.Config), jc.DeepEquals, map[string]interface{}{
+		"ca-cert":         testing.CACert,
+		"controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		"state-port":      1234,
+		"api-port":        4321,
+		"backup-storage":  "s3://backups/juju",
+	})
+}
+
 func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	cc := common.NewControllerConfig(
 		&fakeControllerAccessor{
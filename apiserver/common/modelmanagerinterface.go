@@ -32,6 +32,7 @@ type ModelManagerBackend interface {
 	ModelUUIDsForUser(names.UserTag) ([]string, error)
 	ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error)
 	ModelSummariesForUser(user names.UserTag, all bool) ([]state.ModelSummary, error)
+	UnhealthyStatuses(modelUUIDs []string) ([]state.UnhealthyStatus, error)
 	IsControllerAdmin(user names.UserTag) (bool, error)
 	NewModel(state.ModelArgs) (Model, ModelManagerBackend, error)
 	Model() (Model, error)
@@ -56,7 +56,7 @@ func (s *modelStatusSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -75,7 +75,7 @@ func (s *modelStatusSuite) TestModelStatusNonAuth(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -100,7 +100,7 @@ func (s *modelStatusSuite) TestModelStatusOwnerAllowed(c *gc.C) {
 	}
 	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: owner.Tag()})
 	defer st.Close()
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
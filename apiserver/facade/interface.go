@@ -69,7 +69,8 @@ type Context interface {
 	Presence() Presence
 
 	// Hub returns the central hub that the API server holds.
-	// At least at this stage, facades only need to publish events.
+	// Facades mostly publish events, but may also subscribe to
+	// collect the responses to requests they publish.
 	Hub() Hub
 
 	// ID returns a string that should almost always be "", unless
@@ -85,6 +86,12 @@ type Context interface {
 	// LeadershipChecker returns a leadership.Checker for this context's model.
 	LeadershipChecker() (leadership.Checker, error)
 
+	// LeadershipPinner returns a leadership.Pinner for this context's model.
+	LeadershipPinner() (leadership.Pinner, error)
+
+	// LeadershipTransferrer returns a leadership.Transferrer for this context's model.
+	LeadershipTransferrer() (leadership.Transferrer, error)
+
 	// SingularClaimer returns a lease.Claimer for singular leases for this context's model.
 	SingularClaimer() (lease.Claimer, error)
 }
@@ -168,4 +175,5 @@ type ModelPresence interface {
 // Hub represents the central hub that the API server has.
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
 }
@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationleadership implements the API endpoint that lets
+// model administrators take control of application leadership.
+package applicationleadership
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.applicationleadership")
+
+// transferDuration is how long a unit is made leader for by
+// TransferLeadership. The unit's agent extends the lease as usual
+// once it has noticed it's the leader.
+const transferDuration = time.Minute
+
+// BlockChecker checks whether changes to the model are allowed.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API implements the ApplicationLeadership facade.
+type API struct {
+	backend     Backend
+	authorizer  facade.Authorizer
+	check       BlockChecker
+	pinner      leadership.Pinner
+	transferrer leadership.Transferrer
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	pinner, err := ctx.LeadershipPinner()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	transferrer, err := ctx.LeadershipTransferrer()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st := ctx.State()
+	return NewAPI(
+		stateShim{st},
+		common.NewBlockChecker(st),
+		pinner,
+		transferrer,
+		ctx.Auth(),
+	)
+}
+
+// NewAPI returns a new ApplicationLeadership API facade.
+func NewAPI(
+	backend Backend,
+	check BlockChecker,
+	pinner leadership.Pinner,
+	transferrer leadership.Transferrer,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:     backend,
+		authorizer:  authorizer,
+		check:       check,
+		pinner:      pinner,
+		transferrer: transferrer,
+	}, nil
+}
+
+func (api *API) checkCanChange() error {
+	isModelAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	// Controllers that haven't been upgraded yet can't apply the lease
+	// operations used to pin and transfer leadership.
+	upgrading, err := api.backend.IsUpgrading()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if upgrading {
+		return errors.New("cannot change leadership while the controller is being upgraded")
+	}
+	return nil
+}
+
+// TransferLeadership makes each of the supplied units the leader of
+// its application once the current leader's lease runs out.
+func (api *API) TransferLeadership(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanChange(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		results[i].Error = common.ServerError(api.transferLeadership(entity.Tag))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) transferLeadership(tagString string) error {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %q is not alive", tag.Id())
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.transferrer.TransferLeadership(appName, tag.Id(), transferDuration); err != nil {
+		return errors.Annotatef(err, "cannot transfer leadership of %q", appName)
+	}
+	logger.Infof("leadership of %q transferring to %q by %s", appName, tag.Id(), api.authorizer.GetAuthTag())
+	return api.record(app, fmt.Sprintf("leadership transferring to %s", tag.Id()))
+}
+
+// PinLeadership stops the leadership of each of the supplied
+// applications from changing hands until it is unpinned.
+func (api *API) PinLeadership(args params.Entities) (params.ErrorResults, error) {
+	return api.pinOps(args, "pinned", api.pinner.PinLeadership)
+}
+
+// UnpinLeadership removes the authenticated user's pin from the
+// leadership of each of the supplied applications.
+func (api *API) UnpinLeadership(args params.Entities) (params.ErrorResults, error) {
+	return api.pinOps(args, "unpinned", api.pinner.UnpinLeadership)
+}
+
+func (api *API) pinOps(args params.Entities, verb string, op func(string, string) error) (params.ErrorResults, error) {
+	if err := api.checkCanChange(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	entity := api.authorizer.GetAuthTag().String()
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		results[i].Error = common.ServerError(api.pinOp(arg.Tag, entity, verb, op))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) pinOp(tagString, entity, verb string, op func(string, string) error) error {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := op(tag.Id(), entity); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("leadership of %q %s by %s", tag.Id(), verb, entity)
+	return api.record(app, fmt.Sprintf("leadership %s", verb))
+}
+
+// record adds the message, along with the user responsible, to the
+// application's status history.
+func (api *API) record(app Application, message string) error {
+	message = fmt.Sprintf("%s by %s", message, api.authorizer.GetAuthTag().Id())
+	return errors.Trace(app.RecordLeadershipChange(message))
+}
@@ -0,0 +1,251 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationleadership_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/applicationleadership"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	coretesting.BaseSuite
+
+	stub       jujutesting.Stub
+	backend    *mockBackend
+	check      *mockBlockChecker
+	leadership *mockLeadership
+	authorizer apiservertesting.FakeAuthorizer
+	api        *applicationleadership.API
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stub = jujutesting.Stub{}
+	s.backend = &mockBackend{
+		stub: &s.stub,
+		apps: map[string]*mockApplication{
+			"redis": {stub: &s.stub},
+		},
+		units: map[string]*mockUnit{
+			"redis/0": {life: state.Alive},
+			"redis/1": {life: state.Dying},
+		},
+	}
+	s.check = &mockBlockChecker{stub: &s.stub}
+	s.leadership = &mockLeadership{stub: &s.stub}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := applicationleadership.NewAPI(s.backend, s.check, s.leadership, s.leadership, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *facadeSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("redis/0")
+	_, err := applicationleadership.NewAPI(s.backend, s.check, s.leadership, s.leadership, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestTransferLeadership(c *gc.C) {
+	results, err := s.api.TransferLeadership(params.Entities{
+		Entities: []params.Entity{
+			{"unit-redis-0"},
+			{"unit-redis-1"},
+			{"unit-redis-2"},
+			{"application-redis"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `unit "redis/1" is not alive`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `unit "redis/2" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"application-redis" is not a valid unit tag`)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ChangeAllowed", nil},
+		{"IsUpgrading", nil},
+		{"Application", []interface{}{"redis"}},
+		{"TransferLeadership", []interface{}{"redis", "redis/0", time.Minute}},
+		{"RecordLeadershipChange", []interface{}{"leadership transferring to redis/0 by admin"}},
+	})
+}
+
+func (s *facadeSuite) TestTransferLeadershipPinned(c *gc.C) {
+	s.stub.SetErrors(nil, nil, nil, leadership.ErrPinned)
+	results, err := s.api.TransferLeadership(params.Entities{
+		Entities: []params.Entity{{"unit-redis-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot transfer leadership of "redis": leadership pinned`)
+	s.stub.CheckCallNames(c, "ChangeAllowed", "IsUpgrading", "Application", "TransferLeadership")
+}
+
+func (s *facadeSuite) TestPinLeadership(c *gc.C) {
+	results, err := s.api.PinLeadership(params.Entities{
+		Entities: []params.Entity{{"application-redis"}, {"application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ChangeAllowed", nil},
+		{"IsUpgrading", nil},
+		{"Application", []interface{}{"redis"}},
+		{"PinLeadership", []interface{}{"redis", "user-admin"}},
+		{"RecordLeadershipChange", []interface{}{"leadership pinned by admin"}},
+		{"Application", []interface{}{"mysql"}},
+	})
+}
+
+func (s *facadeSuite) TestUnpinLeadership(c *gc.C) {
+	results, err := s.api.UnpinLeadership(params.Entities{
+		Entities: []params.Entity{{"application-redis"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ChangeAllowed", nil},
+		{"IsUpgrading", nil},
+		{"Application", []interface{}{"redis"}},
+		{"UnpinLeadership", []interface{}{"redis", "user-admin"}},
+		{"RecordLeadershipChange", []interface{}{"leadership unpinned by admin"}},
+	})
+}
+
+func (s *facadeSuite) TestRequiresModelAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	api, err := applicationleadership.NewAPI(s.backend, s.check, s.leadership, s.leadership, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{"application-redis"}}}
+	_, err = api.PinLeadership(args)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	_, err = api.UnpinLeadership(args)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	_, err = api.TransferLeadership(params.Entities{Entities: []params.Entity{{"unit-redis-0"}}})
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestChangeBlocked(c *gc.C) {
+	s.stub.SetErrors(common.OperationBlockedError("frozen"))
+	_, err := s.api.PinLeadership(params.Entities{
+		Entities: []params.Entity{{"application-redis"}},
+	})
+	c.Check(err, gc.ErrorMatches, "frozen")
+	s.stub.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *facadeSuite) TestChangeWhileUpgrading(c *gc.C) {
+	s.backend.upgrading = true
+	_, err := s.api.TransferLeadership(params.Entities{
+		Entities: []params.Entity{{"unit-redis-0"}},
+	})
+	c.Check(err, gc.ErrorMatches, "cannot change leadership while the controller is being upgraded")
+	s.stub.CheckCallNames(c, "ChangeAllowed", "IsUpgrading")
+}
+
+type mockBackend struct {
+	stub      *jujutesting.Stub
+	apps      map[string]*mockApplication
+	units     map[string]*mockUnit
+	upgrading bool
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Application(name string) (applicationleadership.Application, error) {
+	b.stub.AddCall("Application", name)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := b.apps[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (b *mockBackend) Unit(name string) (applicationleadership.Unit, error) {
+	unit, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+func (b *mockBackend) IsUpgrading() (bool, error) {
+	b.stub.AddCall("IsUpgrading")
+	return b.upgrading, b.stub.NextErr()
+}
+
+type mockApplication struct {
+	stub *jujutesting.Stub
+}
+
+func (a *mockApplication) RecordLeadershipChange(message string) error {
+	a.stub.AddCall("RecordLeadershipChange", message)
+	return a.stub.NextErr()
+}
+
+type mockUnit struct {
+	life state.Life
+}
+
+func (u *mockUnit) Life() state.Life {
+	return u.life
+}
+
+type mockBlockChecker struct {
+	stub *jujutesting.Stub
+}
+
+func (m *mockBlockChecker) ChangeAllowed() error {
+	m.stub.AddCall("ChangeAllowed")
+	return m.stub.NextErr()
+}
+
+type mockLeadership struct {
+	stub *jujutesting.Stub
+}
+
+func (m *mockLeadership) PinLeadership(applicationId, entity string) error {
+	m.stub.AddCall("PinLeadership", applicationId, entity)
+	return m.stub.NextErr()
+}
+
+func (m *mockLeadership) UnpinLeadership(applicationId, entity string) error {
+	m.stub.AddCall("UnpinLeadership", applicationId, entity)
+	return m.stub.NextErr()
+}
+
+func (m *mockLeadership) TransferLeadership(applicationId, unitId string, duration time.Duration) error {
+	m.stub.AddCall("TransferLeadership", applicationId, unitId, duration)
+	return m.stub.NextErr()
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationleadership
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the ApplicationLeadership
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+	Unit(name string) (Unit, error)
+	IsUpgrading() (bool, error)
+}
+
+// Application defines the application methods used by the
+// ApplicationLeadership facade.
+type Application interface {
+	RecordLeadershipChange(message string) error
+}
+
+// Unit defines the unit methods used by the ApplicationLeadership
+// facade.
+type Unit interface {
+	Life() state.Life
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
@@ -52,6 +52,52 @@ type APIv2 struct {
 	*API
 }
 
+// APIv5 serves backup-specific API methods for version 5.
+type APIv5 struct {
+	*APIv4
+	importer ModelImporter
+}
+
+// NewAPIv5 creates a new instance of the Backups API facade
+// for version 5.
+func NewAPIv5(backend Backend, importer ModelImporter, resources facade.Resources, authorizer facade.Authorizer) (*APIv5, error) {
+	api, err := NewAPIv4(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api, importer}, nil
+}
+
+// APIv4 serves backup-specific API methods for version 4.
+type APIv4 struct {
+	*APIv3
+}
+
+// NewAPIv4 creates a new instance of the Backups API facade
+// for version 4.
+func NewAPIv4(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPIv3(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
+// APIv3 serves backup-specific API methods for version 3.
+type APIv3 struct {
+	*APIv2
+}
+
+// NewAPIv3 creates a new instance of the Backups API facade
+// for version 3.
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -148,6 +194,7 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Storage = meta.Storage
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -164,6 +211,8 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.CACert = meta.CACert
 	result.CAPrivateKey = meta.CAPrivateKey
 	result.Filename = filename
+	result.Encrypted = meta.Encrypted
+	result.Scheduled = meta.Scheduled
 
 	return result
 }
@@ -183,6 +232,9 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Storage = result.Storage
+	meta.Encrypted = result.Encrypted
+	meta.Scheduled = result.Scheduled
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -26,7 +26,7 @@ type backupsSuite struct {
 	testing.JujuConnSuite
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *backupsAPI.APIv2
+	api        *backupsAPI.APIv4
 	meta       *backups.Metadata
 	machineTag names.MachineTag
 }
@@ -57,7 +57,7 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 
 	tag := names.NewLocalUserTag("admin")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
-	s.api, err = backupsAPI.NewAPIv2(&stateShim{s.State, s.Model}, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPIv4(&stateShim{s.State, s.Model}, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 )
@@ -30,7 +31,65 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	return result, nil
 }
 
+// Create is the API method that requests juju to create a new backup
+// of its state. The backup archive is stored in the storage target
+// given by the backup-storage controller config.
+//
+// NOTE this provides backwards compatibility for facade version 2.
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	args.Storage = ""
+	apiv3 := APIv3{a}
+	result, err := apiv3.Create(args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state. The backup archive is stored in the given storage
+// target, or the one given by the backup-storage controller config
+// if none is given. It returns the metadata for that backup.
+//
+// NOTE this provides backwards compatibility for facade version 3.
+func (a *APIv3) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	args.Passphrase = ""
+	args.PublicKey = ""
+	apiv4 := APIv4{a}
+	result, err := apiv4.Create(args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state. The backup archive is stored in the given storage
+// target, or the one given by the backup-storage controller config
+// if none is given, and is encrypted with the given passphrase or
+// public key, if any. It returns the metadata for that backup.
+func (a *APIv4) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	if err := controller.ValidateBackupStorage(args.Storage); err != nil {
+		return params.BackupsMetadataResult{}, errors.Trace(err)
+	}
+	var encryption *backups.EncryptionKey
+	if args.Passphrase != "" || args.PublicKey != "" {
+		encryption = &backups.EncryptionKey{
+			Passphrase: args.Passphrase,
+			PublicKey:  args.PublicKey,
+		}
+		if err := encryption.Validate(); err != nil {
+			return params.BackupsMetadataResult{}, errors.Trace(err)
+		}
+	}
+	result, err := a.create(args, encryption)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func (a *API) create(args params.BackupsCreateArgs, encryption *backups.EncryptionKey) (params.BackupsMetadataResult, error) {
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
 
@@ -70,8 +129,15 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 		return result, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	switch args.Storage {
+	case "":
+	case controller.BackupStorageController:
+		meta.Storage = ""
+	default:
+		meta.Storage = args.Storage
+	}
 
-	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
+	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload, encryption)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
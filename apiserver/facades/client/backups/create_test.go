@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -54,3 +55,82 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateStorage(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		Storage: "file:///srv/backups",
+	}
+
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.MetaArg.Storage, gc.Equals, "file:///srv/backups")
+	c.Check(result.Storage, gc.Equals, "file:///srv/backups")
+}
+
+func (s *backupsSuite) TestCreateStorageInvalid(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		Storage: "ftp://host/backups",
+	}
+
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, `invalid backup storage "ftp://host/backups": expected "controller", "file" or "s3" storage`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateV2IgnoresStorage(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		Storage: "file:///srv/backups",
+	}
+
+	_, err := s.api.APIv2.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.MetaArg.Storage, gc.Equals, "")
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		Passphrase: "sekrit",
+	}
+
+	_, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.EncryptionArg, jc.DeepEquals, &statebackups.EncryptionKey{Passphrase: "sekrit"})
+}
+
+func (s *backupsSuite) TestCreateEncryptionInvalid(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		PublicKey: "junk",
+	}
+
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, "invalid public key: .*")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateV3IgnoresEncryption(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{
+		Passphrase: "sekrit",
+	}
+
+	_, err := s.api.APIv3.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.EncryptionArg, gc.IsNil)
+}
@@ -67,6 +67,12 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
 	}
+	if p.DecryptionKey != nil {
+		restoreArgs.Decryption = &backups.DecryptionKey{
+			Passphrase: p.DecryptionKey.Passphrase,
+			PrivateKey: p.DecryptionKey.PrivateKey,
+		}
+	}
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
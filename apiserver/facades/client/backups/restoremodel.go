@@ -0,0 +1,331 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// ModelImporter imports models restored from a backup into the
+// controller.
+type ModelImporter interface {
+	// ModelExists reports whether the identified model is on the
+	// controller.
+	ModelExists(uuid string) (bool, error)
+
+	// CheckImport returns an error if the described model
+	// would not be accepted by ImportModel or RebuildModel.
+	CheckImport(model description.Model) error
+
+	// TryImport imports the described model under a temporary name
+	// and UUID, and removes it again. It returns an error if the
+	// model cannot be imported.
+	TryImport(model description.Model) error
+
+	// RemoveModel removes all of the identified model's documents,
+	// so that it can be replaced.
+	RemoveModel(uuid string) error
+
+	// ImportModel imports the described model, stores its binaries
+	// and makes it available. A model that cannot be imported is
+	// removed again.
+	ImportModel(model description.Model, binaries backups.ModelBinaries) error
+
+	// RebuildModel imports the described model without its machines'
+	// cloud instances, which are provisioned afresh, stores its
+	// binaries and makes it available. A model that cannot be
+	// imported is removed again.
+	RebuildModel(model description.Model, args state.RebuildArgs, binaries backups.ModelBinaries) error
+}
+
+// NewModelImporter returns a ModelImporter that imports models into the
+// controller of the given state pool.
+func NewModelImporter(pool *state.StatePool) ModelImporter {
+	return &stateModelImporter{pool: pool}
+}
+
+type stateModelImporter struct {
+	pool *state.StatePool
+}
+
+// ModelExists is part of the ModelImporter interface.
+func (i *stateModelImporter) ModelExists(uuid string) (bool, error) {
+	return i.pool.SystemState().ModelExists(uuid)
+}
+
+// CheckImport is part of the ModelImporter interface.
+func (i *stateModelImporter) CheckImport(desc description.Model) error {
+	bytes, err := description.Serialize(desc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	problems, err := migration.CheckImport(i.pool.SystemState(), bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Error()
+	}
+	return errors.Errorf("model cannot be imported: %s", strings.Join(messages, "; "))
+}
+
+// TryImport is part of the ModelImporter interface. The model's
+// binaries are not stored.
+func (i *stateModelImporter) TryImport(desc description.Model) error {
+	bytes, err := description.Serialize(desc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trial, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuid := utils.MustNewUUID().String()
+	trial.UpdateConfig(map[string]interface{}{
+		"name": "restore-" + uuid[:8],
+		"uuid": uuid,
+	})
+	_, st, err := i.pool.SystemState().Import(trial)
+	if err == nil {
+		st.Close()
+	}
+	if removeErr := i.removeImportingModel(uuid); removeErr != nil {
+		if err == nil {
+			return errors.Annotate(removeErr, "cannot remove trial import")
+		}
+		logger.Errorf("cannot remove trial import of model %q: %v", desc.Tag().Id(), removeErr)
+	}
+	return errors.Trace(err)
+}
+
+// RemoveModel is part of the ModelImporter interface. The model is
+// removed as it would be once migrated away from the controller.
+func (i *stateModelImporter) RemoveModel(uuid string) error {
+	st, err := i.pool.Get(uuid)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := model.SetMigrationMode(state.MigrationModeExporting); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.RemoveExportingModelDocs(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = i.pool.Remove(uuid)
+	return errors.Trace(err)
+}
+
+// ImportModel is part of the ModelImporter interface. The model is
+// activated as it would be at the end of a migration.
+func (i *stateModelImporter) ImportModel(desc description.Model, binaries backups.ModelBinaries) error {
+	return i.importModel(desc, binaries, func() (*state.Model, *state.State, error) {
+		return i.pool.SystemState().Import(desc)
+	})
+}
+
+// RebuildModel is part of the ModelImporter interface. The model is
+// activated as it would be at the end of a migration.
+func (i *stateModelImporter) RebuildModel(desc description.Model, args state.RebuildArgs, binaries backups.ModelBinaries) error {
+	return i.importModel(desc, binaries, func() (*state.Model, *state.State, error) {
+		return i.pool.SystemState().ImportRebuild(desc, args)
+	})
+}
+
+// importModel imports a model with importFn, stores its binaries and
+// activates it. Whatever was imported is removed again if any of
+// these fail, as when a migration is aborted.
+func (i *stateModelImporter) importModel(
+	desc description.Model,
+	binaries backups.ModelBinaries,
+	importFn func() (*state.Model, *state.State, error),
+) (err error) {
+	resources, err := migration.UsedResources(desc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuid := desc.Tag().Id()
+	defer func() {
+		if err == nil {
+			return
+		}
+		if removeErr := i.removeImportingModel(uuid); removeErr != nil {
+			logger.Errorf("cannot remove partly imported model %q: %v", uuid, removeErr)
+		}
+	}()
+	model, st, err := importFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	logger.Infof("storing binaries of model %q", uuid)
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             migration.UsedCharms(desc),
+		CharmDownloader:    binaries,
+		CharmUploader:      &importUploader{st: st},
+		Tools:              migration.UsedTools(desc),
+		ToolsDownloader:    binaries,
+		ToolsUploader:      &importUploader{st: st},
+		Resources:          resources,
+		ResourceDownloader: binaries,
+		ResourceUploader:   &importUploader{st: st},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot store model binaries")
+	}
+	return errors.Trace(activateImportedModel(model))
+}
+
+// removeImportingModel removes the identified model if it is still
+// being imported. A model that is not on the controller is ignored.
+func (i *stateModelImporter) removeImportingModel(uuid string) error {
+	exists, err := i.pool.SystemState().ModelExists(uuid)
+	if err != nil || !exists {
+		return errors.Trace(err)
+	}
+	st, err := i.pool.Get(uuid)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	if err := st.RemoveImportingModelDocs(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = i.pool.Remove(uuid)
+	return errors.Trace(err)
+}
+
+func activateImportedModel(model *state.Model) error {
+	if err := model.SetStatus(status.StatusInfo{Status: status.Available}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetMigrationMode(state.MigrationModeNone))
+}
+
+// RestoreModel restores a single model from a controller backup,
+// leaving the rest of the controller as it is. The model is either
+// restored as a new model with a new name and UUID, or replaces the
+// existing model with the same UUID; a model that is no longer on the
+// controller is restored as it was. The charms, agent binaries and
+// resources the model uses are restored from the backup along with it.
+//
+// A model restored with a new name does not take over the cloud
+// instances of the backed up model's machines, which may still be in
+// use by the original model: its machines are provisioned afresh.
+//
+// The restored model is checked, and imported under a temporary name
+// and UUID, before an existing model is removed to make way for it, so
+// that a model the controller cannot import does not cause the
+// existing model to be lost.
+func (a *APIv5) RestoreModel(args params.RestoreModelArgs) (params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	if args.ModelUUID == a.backend.ModelTag().Id() {
+		return result, errors.New("cannot restore the controller model on its own: use restore-backup")
+	}
+	if args.Replace && args.NewName != "" {
+		return result, errors.New("cannot both replace a model and restore it with a new name")
+	}
+
+	backup, closer := newBackups(a.backend)
+	defer closer.Close()
+	exportArgs := backups.ExportModelArgs{ModelUUID: args.ModelUUID}
+	if args.DecryptionKey != nil {
+		exportArgs.Decryption = &backups.DecryptionKey{
+			Passphrase: args.DecryptionKey.Passphrase,
+			PrivateKey: args.DecryptionKey.PrivateKey,
+		}
+	}
+	logger.Infof("exporting model %q from backup %q", args.ModelUUID, args.BackupId)
+	// The model's binaries can only be read from the backup while
+	// the model is being restored.
+	var restoreErr error
+	err := backup.ExportModel(args.BackupId, exportArgs, func(model description.Model, binaries backups.ModelBinaries) error {
+		result, restoreErr = a.restoreModel(args, model, binaries)
+		return restoreErr
+	})
+	if restoreErr != nil {
+		return result, errors.Trace(restoreErr)
+	}
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot read model %q from backup %q", args.ModelUUID, args.BackupId)
+	}
+	return result, nil
+}
+
+func (a *APIv5) restoreModel(
+	args params.RestoreModelArgs,
+	model description.Model,
+	binaries backups.ModelBinaries,
+) (params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	exists, err := a.importer.ModelExists(args.ModelUUID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if exists && !args.Replace && args.NewName == "" {
+		return result, errors.NewAlreadyExists(nil, fmt.Sprintf(
+			"model %q already exists on this controller: replace it, or restore it with a new name", args.ModelUUID))
+	}
+	if args.NewName != "" {
+		model.UpdateConfig(map[string]interface{}{
+			"name": args.NewName,
+			"uuid": utils.MustNewUUID().String(),
+		})
+	}
+	if err := a.importer.CheckImport(model); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	switch {
+	case args.NewName != "":
+		rebuild := state.RebuildArgs{
+			Cloud:       model.Cloud(),
+			CloudRegion: model.CloudRegion(),
+		}
+		if creds := model.CloudCredential(); creds != nil {
+			rebuild.CloudCredential = names.NewCloudCredentialTag(
+				fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name()))
+		}
+		if err := a.importer.RebuildModel(model, rebuild, binaries); err != nil {
+			return result, errors.Annotate(err, "cannot import model")
+		}
+	default:
+		if exists {
+			if err := a.importer.TryImport(model); err != nil {
+				return result, errors.Annotate(err, "cannot import model")
+			}
+			logger.Infof("removing model %q to replace it", args.ModelUUID)
+			if err := a.importer.RemoveModel(args.ModelUUID); err != nil {
+				return result, errors.Annotatef(err, "cannot remove model %q", args.ModelUUID)
+			}
+		}
+		if err := a.importer.ImportModel(model, binaries); err != nil {
+			return result, errors.Annotate(err, "cannot import model")
+		}
+	}
+	result.ModelUUID = model.Tag().Id()
+	result.Name, _ = model.Config()["name"].(string)
+	return result, nil
+}
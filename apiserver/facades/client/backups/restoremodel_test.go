@@ -0,0 +1,271 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *backupsSuite) newAPIv5(c *gc.C) *backupsAPI.APIv5 {
+	api, err := backupsAPI.NewAPIv5(
+		&stateShim{s.State, s.Model},
+		backupsAPI.NewModelImporter(s.StatePool),
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+// exportModel makes a new hosted model, and sets the backup to hold
+// it as exported.
+func (s *backupsSuite) exportModel(c *gc.C) (string, *backupstesting.FakeBackups) {
+	st := factory.NewFactory(s.State).MakeModel(c, &factory.ModelParams{Name: "prod"})
+	defer st.Close()
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = model
+	fake.Binaries = &fakeBinaries{}
+	return st.ModelUUID(), fake
+}
+
+// modelUUIDs returns the UUIDs of the models on the controller.
+func (s *backupsSuite) modelUUIDs(c *gc.C) []string {
+	uuids, err := s.State.AllModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	return uuids
+}
+
+func (s *backupsSuite) TestRestoreModelNewName(c *gc.C) {
+	uuid, fake := s.exportModel(c)
+
+	result, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:      "some-id",
+		ModelUUID:     uuid,
+		NewName:       "prod-restored",
+		DecryptionKey: &params.BackupDecryptionKey{Passphrase: "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"ExportModel"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.ModelUUIDArg, gc.Equals, uuid)
+	c.Check(fake.DecryptionArg, jc.DeepEquals, &backups.DecryptionKey{Passphrase: "sekrit"})
+	c.Check(result.Name, gc.Equals, "prod-restored")
+	c.Check(result.ModelUUID, gc.Not(gc.Equals), uuid)
+
+	st, err := s.StatePool.Get(result.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Name(), gc.Equals, "prod-restored")
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+	modelStatus, err := model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelStatus.Status, gc.Equals, status.Available)
+}
+
+func (s *backupsSuite) TestRestoreModelNewNameDoesNotTakeInstances(c *gc.C) {
+	st := factory.NewFactory(s.State).MakeModel(c, &factory.ModelParams{Name: "prod"})
+	defer st.Close()
+	machine := factory.NewFactory(st).MakeMachine(c, nil)
+	_, err := machine.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = model
+	fake.Binaries = &fakeBinaries{}
+
+	result, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: st.ModelUUID(),
+		NewName:   "prod-restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored, err := s.StatePool.Get(result.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer restored.Release()
+	restoredMachine, err := restored.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = restoredMachine.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *backupsSuite) TestRestoreModelStoresCharms(c *gc.C) {
+	st := factory.NewFactory(s.State).MakeModel(c, &factory.ModelParams{Name: "prod"})
+	defer st.Close()
+	app := factory.NewFactory(st).MakeApplication(c, nil)
+	curl, _ := app.CharmURL()
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = model
+	binaries := &fakeBinaries{
+		charmPath: testcharms.RepoForSeries("quantal").CharmArchivePath(c.MkDir(), "mysql"),
+	}
+	fake.Binaries = binaries
+
+	result, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: st.ModelUUID(),
+		NewName:   "prod-restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(binaries.opened, jc.DeepEquals, []string{curl.String()})
+
+	restored, err := s.StatePool.Get(result.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer restored.Release()
+	ch, err := restored.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.IsUploaded(), jc.IsTrue)
+	stor := storage.NewStorage(restored.ModelUUID(), restored.MongoSession())
+	r, _, err := stor.Get(ch.StoragePath())
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+}
+
+func (s *backupsSuite) TestRestoreModelBinariesFail(c *gc.C) {
+	st := factory.NewFactory(s.State).MakeModel(c, &factory.ModelParams{Name: "prod"})
+	defer st.Close()
+	factory.NewFactory(st).MakeApplication(c, nil)
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = model
+	fake.Binaries = &fakeBinaries{charmErr: errors.New("boom")}
+	before := s.modelUUIDs(c)
+
+	_, err = s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: st.ModelUUID(),
+		NewName:   "prod-restored",
+	})
+	c.Check(err, gc.ErrorMatches, "cannot import model: cannot store model binaries: cannot open charm: boom")
+	c.Check(s.modelUUIDs(c), jc.SameContents, before)
+}
+
+func (s *backupsSuite) TestRestoreModelReplace(c *gc.C) {
+	uuid, _ := s.exportModel(c)
+	before := s.modelUUIDs(c)
+
+	result, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: uuid,
+		Replace:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.RestoreModelResult{ModelUUID: uuid, Name: "prod"})
+	// The trial import has been removed.
+	c.Check(s.modelUUIDs(c), jc.SameContents, before)
+
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+}
+
+func (s *backupsSuite) TestRestoreModelReplaceChecksBeforeRemoving(c *gc.C) {
+	uuid, fake := s.exportModel(c)
+	fake.Model.AddRemoteApplication(description.RemoteApplicationArgs{
+		SourceModel: names.NewModelTag(utils.MustNewUUID().String()),
+		OfferUUID:   utils.MustNewUUID().String(),
+		Tag:         names.NewApplicationTag("remote"),
+	})
+
+	_, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: uuid,
+		Replace:   true,
+	})
+	c.Check(err, gc.ErrorMatches, "model cannot be imported: can't import models with remote applications")
+
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+}
+
+func (s *backupsSuite) TestRestoreModelExists(c *gc.C) {
+	uuid, _ := s.exportModel(c)
+
+	_, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: uuid,
+	})
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Check(err, gc.ErrorMatches, `model ".*" already exists on this controller: replace it, or restore it with a new name`)
+}
+
+func (s *backupsSuite) TestRestoreModelControllerModel(c *gc.C) {
+	_, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: s.State.ModelUUID(),
+		Replace:   true,
+	})
+	c.Check(err, gc.ErrorMatches, "cannot restore the controller model on its own: use restore-backup")
+}
+
+func (s *backupsSuite) TestRestoreModelExportFails(c *gc.C) {
+	s.setBackups(c, s.meta, "model not found")
+
+	_, err := s.newAPIv5(c).RestoreModel(params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		NewName:   "restored",
+	})
+	c.Check(err, gc.ErrorMatches, `cannot read model "deadbeef-0bad-400d-8000-4b1d0d06f00d" from backup "some-id": model not found`)
+}
+
+// fakeBinaries serves the same charm archive for every charm, and
+// fixed content for agent binaries and resources.
+type fakeBinaries struct {
+	charmPath string
+	charmErr  error
+	opened    []string
+}
+
+func (b *fakeBinaries) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	b.opened = append(b.opened, curl.String())
+	if b.charmErr != nil {
+		return nil, b.charmErr
+	}
+	return os.Open(b.charmPath)
+}
+
+func (b *fakeBinaries) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("agent binaries")), nil
+}
+
+func (b *fakeBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("resource")), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/tools"
+)
+
+// importUploader stores the binaries of a model restored from a
+// backup in the model's state, as the migration upload endpoints do
+// for a model being imported by a migration.
+type importUploader struct {
+	st *state.State
+}
+
+// UploadCharm is part of migration.CharmUploader.
+func (u *importUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive, err := charm.ReadCharmArchiveBytes(data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid charm archive for %q", curl)
+	}
+	switch curl.Schema {
+	case "local":
+		curl, err = u.st.PrepareLocalCharmUpload(curl)
+	case "cs":
+		_, err = u.st.PrepareStoreCharmUpload(curl)
+	default:
+		err = errors.Errorf("unsupported schema %q", curl.Schema)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = application.StoreCharmArchive(u.st, application.CharmArchive{
+		ID:     curl,
+		Charm:  archive,
+		Data:   bytes.NewReader(data),
+		Size:   int64(len(data)),
+		SHA256: sha256Hex(data),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
+// UploadTools is part of migration.ToolsUploader. The agent binaries
+// are only stored for the given version.
+func (u *importUploader) UploadTools(r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage, err := u.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer storage.Close()
+
+	metadata := binarystorage.Metadata{
+		Version: vers.String(),
+		Size:    int64(len(data)),
+		SHA256:  sha256Hex(data),
+	}
+	if err := storage.Add(bytes.NewReader(data), metadata); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tools.List{{
+		Version: vers,
+		Size:    metadata.Size,
+		SHA256:  metadata.SHA256,
+	}}, nil
+}
+
+// UploadResource is part of migration.ResourceUploader.
+func (u *importUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return errors.Trace(u.setResource(res, content))
+}
+
+// SetPlaceholderResource is part of migration.ResourceUploader.
+func (u *importUploader) SetPlaceholderResource(res resource.Resource) error {
+	return errors.Trace(u.setResource(res, nil))
+}
+
+// SetUnitResource is part of migration.ResourceUploader.
+func (u *importUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	resources, err := u.st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = resources.SetUnitResource(unitName, res.Username, res.Resource)
+	return errors.Trace(err)
+}
+
+func (u *importUploader) setResource(res resource.Resource, r io.Reader) error {
+	resources, err := u.st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = resources.SetResource(res.ApplicationID, res.Username, res.Resource, r)
+	return errors.Trace(err)
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
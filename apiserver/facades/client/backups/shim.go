@@ -32,6 +32,34 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
+// NewFacadeV5 provides the required signature for version 5 facade registration.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv5(&stateShim{st, model}, NewModelImporter(ctx.StatePool()), ctx.Resources(), ctx.Auth())
+}
+
+// NewFacadeV4 provides the required signature for version 4 facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv4(&stateShim{st, model}, resources, authorizer)
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv3(&stateShim{st, model}, resources, authorizer)
+}
+
 // NewFacadeV2 provides the required signature for version 2 facade registration.
 func NewFacadeV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	model, err := st.Model()
@@ -67,6 +67,7 @@ type Backend interface {
 	SetAnnotations(state.GlobalEntity, map[string]string) error
 	SetModelAgentVersion(version.Number, bool) error
 	SetModelConstraints(constraints.Value) error
+	StatusTimeline(state.StatusTimelineFilter) ([]state.StatusTimelineEntry, error)
 	Unit(string) (Unit, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	Watch(params state.WatchParams) *state.Multiwatcher
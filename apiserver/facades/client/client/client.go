@@ -65,9 +65,19 @@ type Client struct {
 	callContext context.ProviderCallContext
 }
 
+// ClientV3 serves the (v3) client-specific API methods.
+type ClientV3 struct {
+	*Client
+}
+
+// ClientV2 serves the (v2) client-specific API methods.
+type ClientV2 struct {
+	*ClientV3
+}
+
 // ClientV1 serves the (v1) client-specific API methods.
 type ClientV1 struct {
-	*Client
+	*ClientV2
 }
 
 func (c *Client) checkCanRead() error {
@@ -118,14 +128,32 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
-// NewFacade creates a version 1 Client facade to handle API requests.
+// NewFacade creates a version 4 Client facade to handle API requests.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	return newFacade(ctx)
 }
 
+// NewFacadeV3 creates a version 3 Client facade to handle API requests.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV2 creates a version 2 Client facade to handle API requests.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
 // NewFacadeV1 creates a version 1 Client facade to handle API requests.
 func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
-	client, err := newFacade(ctx)
+	client, err := NewFacadeV2(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -172,6 +172,11 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	filter, err := newStatusFilter(args)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+
 	var context statusContext
 
 	m, err := c.api.stateAccessor.Model()
@@ -361,7 +366,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
-	return params.FullStatus{
+	fullStatus := params.FullStatus{
 		Model:               modelStatus,
 		Machines:            context.processMachines(),
 		Applications:        context.processApplications(),
@@ -369,7 +374,19 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Offers:              context.processOffers(),
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
-	}, nil
+	}
+	// The status filter is applied to the processed status so that
+	// it sees the unit statuses as reported, including lost agents.
+	if filter != nil {
+		filter.apply(&fullStatus)
+	}
+	return fullStatus, nil
+}
+
+// FullStatus gives the information needed for juju status over the api.
+// Version 2 of the facade does not support filtering by status.
+func (c *ClientV2) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	return c.Client.FullStatus(params.StatusParams{Patterns: args.Patterns})
 }
 
 // newToolsVersionAvailable will return a string representing a tools
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -550,6 +551,69 @@ func (s *statusUnitTestSuite) TestFilterOutRelationsForRelatedApplicationsThatDo
 	c.Assert(status.Relations, gc.HasLen, 0)
 }
 
+func (s *statusUnitTestSuite) TestFilterByStatus(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	u0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	u1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	err := u1.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "Missing database relation",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	for i, test := range []params.StatusParams{{
+		WorkloadStatuses: []string{"blocked", "error"},
+	}, {
+		Message: "database",
+	}, {
+		Patterns:         []string{app.Name()},
+		WorkloadStatuses: []string{"blocked"},
+		Message:          "relation",
+	}} {
+		c.Logf("test %d", i)
+		fullStatus, err := client.FilteredStatus(test)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(fullStatus.Applications, gc.HasLen, 1)
+		units := fullStatus.Applications[app.Name()].Units
+		c.Assert(units, gc.HasLen, 1)
+		c.Assert(units[u1.Name()].WorkloadStatus.Info, gc.Equals, "Missing database relation")
+		c.Assert(fullStatus.Machines, gc.HasLen, 1)
+	}
+
+	fullStatus, err := client.FilteredStatus(params.StatusParams{
+		AgentStatuses: []string{"allocating"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Applications, gc.HasLen, 2)
+	c.Assert(fullStatus.Applications[app.Name()].Units, gc.HasLen, 2)
+	c.Assert(fullStatus.Applications[app.Name()].Units[u0.Name()], gc.NotNil)
+
+	fullStatus, err = client.FilteredStatus(params.StatusParams{
+		AgentStatuses: []string{"executing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Applications, gc.HasLen, 0)
+	c.Assert(fullStatus.Machines, gc.HasLen, 0)
+}
+
+func (s *statusUnitTestSuite) TestFilterByStatusInvalid(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.FilteredStatus(params.StatusParams{
+		WorkloadStatuses: []string{"happy"},
+	})
+	c.Assert(err, gc.ErrorMatches, `workload status "happy" not valid`)
+	_, err = client.FilteredStatus(params.StatusParams{
+		AgentStatuses: []string{"active"},
+	})
+	c.Assert(err, gc.ErrorMatches, `agent status "active" not valid`)
+}
+
 func assertApplicationRelations(c *gc.C, appName string, expectedNumber int, relations []params.RelationStatus) {
 	c.Assert(relations, gc.HasLen, expectedNumber)
 	for _, relation := range relations {
@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// statusFilter restricts the units reported by FullStatus to those
+// with matching workload status, agent status and status message.
+// Each criterion that is set must be satisfied by a unit for it to
+// match.
+type statusFilter struct {
+	workloadStatuses set.Strings
+	agentStatuses    set.Strings
+	message          string
+}
+
+// newStatusFilter returns a filter for the status values in the
+// given arguments, or nil if there are none.
+func newStatusFilter(args params.StatusParams) (*statusFilter, error) {
+	if len(args.WorkloadStatuses) == 0 && len(args.AgentStatuses) == 0 && args.Message == "" {
+		return nil, nil
+	}
+	f := &statusFilter{
+		workloadStatuses: set.NewStrings(),
+		agentStatuses:    set.NewStrings(),
+		message:          strings.ToLower(args.Message),
+	}
+	for _, s := range args.WorkloadStatuses {
+		if !status.Status(s).KnownWorkloadStatus() {
+			return nil, errors.NotValidf("workload status %q", s)
+		}
+		f.workloadStatuses.Add(s)
+	}
+	for _, s := range args.AgentStatuses {
+		// Lost is not a status an agent ever sets, but it is
+		// reported for units whose agent is not present.
+		if !status.Status(s).KnownAgentStatus() && status.Status(s) != status.Lost {
+			return nil, errors.NotValidf("agent status %q", s)
+		}
+		f.agentStatuses.Add(s)
+	}
+	return f, nil
+}
+
+// matchUnit reports whether the unit itself matches the filter.
+func (f *statusFilter) matchUnit(unit params.UnitStatus) bool {
+	if !f.workloadStatuses.IsEmpty() && !f.workloadStatuses.Contains(unit.WorkloadStatus.Status) {
+		return false
+	}
+	if !f.agentStatuses.IsEmpty() && !f.agentStatuses.Contains(unit.AgentStatus.Status) {
+		return false
+	}
+	if f.message != "" &&
+		!strings.Contains(strings.ToLower(unit.WorkloadStatus.Info), f.message) &&
+		!strings.Contains(strings.ToLower(unit.AgentStatus.Info), f.message) {
+		return false
+	}
+	return true
+}
+
+// filterUnits removes the units that do not match the filter. A
+// principal unit is kept if it or any of its subordinates match,
+// but only the matching subordinates of a principal that does not
+// match itself are kept.
+func (f *statusFilter) filterUnits(units map[string]params.UnitStatus) {
+	for name, unit := range units {
+		if f.matchUnit(unit) {
+			continue
+		}
+		f.filterUnits(unit.Subordinates)
+		if len(unit.Subordinates) == 0 {
+			delete(units, name)
+		}
+	}
+}
+
+// apply removes from the status the units that do not match the
+// filter, along with the applications, machines, offers and
+// relations that no longer have any matching units.
+func (f *statusFilter) apply(fullStatus *params.FullStatus) {
+	applications := set.NewStrings()
+	machines := set.NewStrings()
+	var addUnits func(map[string]params.UnitStatus)
+	addUnits = func(units map[string]params.UnitStatus) {
+		for name, unit := range units {
+			if appName, err := names.UnitApplication(name); err == nil {
+				applications.Add(appName)
+			}
+			if unit.Machine != "" {
+				machines.Add(unit.Machine)
+			}
+			addUnits(unit.Subordinates)
+		}
+	}
+	for _, app := range fullStatus.Applications {
+		f.filterUnits(app.Units)
+		addUnits(app.Units)
+	}
+
+	for name := range fullStatus.Applications {
+		if !applications.Contains(name) {
+			delete(fullStatus.Applications, name)
+		}
+	}
+	for name, offer := range fullStatus.Offers {
+		if !applications.Contains(offer.ApplicationName) {
+			delete(fullStatus.Offers, name)
+		}
+	}
+	filterMachines(fullStatus.Machines, machines)
+
+	relations := fullStatus.Relations[:0]
+	for _, relation := range fullStatus.Relations {
+		keep := true
+		for _, ep := range relation.Endpoints {
+			_, remote := fullStatus.RemoteApplications[ep.ApplicationName]
+			if !remote && !applications.Contains(ep.ApplicationName) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			relations = append(relations, relation)
+		}
+	}
+	fullStatus.Relations = relations
+}
+
+// filterMachines removes the machines that do not host any of the
+// given machines, either directly or as containers.
+func filterMachines(machines map[string]params.MachineStatus, keep set.Strings) {
+	for id, machine := range machines {
+		filterMachines(machine.Containers, keep)
+		if len(machine.Containers) == 0 && !keep.Contains(id) {
+			delete(machines, id)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package client_test
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
@@ -222,10 +224,75 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusTimeline(c *gc.C) {
+	since := time.Unix(1000, 0)
+	s.st.timeline = []state.StatusTimelineEntry{{
+		Entity: names.NewApplicationTag("mysql"),
+		Kind:   status.KindApplication,
+		StatusInfo: status.StatusInfo{
+			Status:  status.Blocked,
+			Message: "missing relation",
+			Since:   &since,
+		},
+	}, {
+		Entity: names.NewUnitTag("mysql/0"),
+		Kind:   status.KindUnitAgent,
+		StatusInfo: status.StatusInfo{
+			Status: status.Idle,
+			Since:  &since,
+		},
+	}}
+	from := time.Unix(500, 0)
+	result, err := s.api.StatusTimeline(params.StatusTimelineRequest{
+		From:    &from,
+		Kinds:   []string{"application", "unit"},
+		Exclude: []string{"running update-status hook"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StatusTimeline{
+		Entries: []params.StatusTimelineEntry{{
+			Tag: "application-mysql",
+			Status: params.DetailedStatus{
+				Status: "blocked",
+				Info:   "missing relation",
+				Since:  &since,
+				Kind:   "application",
+			},
+		}, {
+			Tag: "unit-mysql-0",
+			Status: params.DetailedStatus{
+				Status: "idle",
+				Since:  &since,
+				Kind:   "juju-unit",
+			},
+		}},
+	})
+	c.Assert(s.st.timelineFilter, jc.DeepEquals, state.StatusTimelineFilter{
+		From:    &from,
+		Kinds:   []status.HistoryKind{status.KindApplication, status.KindUnit},
+		Exclude: set.NewStrings("running update-status hook"),
+	})
+}
+
+func (s *statusHistoryTestSuite) TestStatusTimelineError(c *gc.C) {
+	s.st.timelineErr = errors.NotValidf("status timeline without a start time or size")
+	_, err := s.api.StatusTimeline(params.StatusTimelineRequest{})
+	c.Assert(err, gc.ErrorMatches, "status timeline without a start time or size not valid")
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+
+	timeline       []state.StatusTimelineEntry
+	timelineFilter state.StatusTimelineFilter
+	timelineErr    error
+}
+
+func (m *mockState) StatusTimeline(filter state.StatusTimelineFilter) ([]state.StatusTimelineEntry, error) {
+	m.timelineFilter = filter
+	return m.timeline, m.timelineErr
 }
 
 func (m *mockState) ModelUUID() string {
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// StatusTimeline returns the status history of the model's
+// applications, machines and units, merged and ordered by time.
+func (c *Client) StatusTimeline(args params.StatusTimelineRequest) (params.StatusTimeline, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.StatusTimeline{}, err
+	}
+	filter := state.StatusTimelineFilter{
+		From:    args.From,
+		To:      args.To,
+		Exclude: set.NewStrings(args.Exclude...),
+		Size:    args.Size,
+	}
+	for _, kind := range args.Kinds {
+		filter.Kinds = append(filter.Kinds, status.HistoryKind(kind))
+	}
+	entries, err := c.api.stateAccessor.StatusTimeline(filter)
+	if err != nil {
+		return params.StatusTimeline{}, errors.Trace(err)
+	}
+	result := params.StatusTimeline{
+		Entries: make([]params.StatusTimelineEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.StatusTimelineEntry{
+			Tag: entry.Entity.String(),
+			Status: params.DetailedStatus{
+				Status: entry.Status.String(),
+				Info:   entry.Message,
+				Data:   entry.Data,
+				Since:  entry.Since,
+				Kind:   entry.Kind.String(),
+			},
+		}
+	}
+	return result, nil
+}
+
+// Mask the new methods from the V3 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// StatusTimeline isn't on the V3 API.
+func (c *ClientV3) StatusTimeline(_, _ struct{}) {}
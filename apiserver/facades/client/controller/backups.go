@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// BackupStatus reports the most recent successful backup of the
+// controller, whether it was created on a schedule or by a user.
+func (c *ControllerAPI) BackupStatus() (params.ControllerBackupStatus, error) {
+	var result params.ControllerBackupStatus
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	controllerState := c.statePool.SystemState()
+	controllerModel, err := controllerState.Model()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	stor := backups.NewStorage(&backupsDB{controllerState, controllerModel})
+	defer stor.Close()
+
+	all, err := backups.NewBackups(stor).List()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if last := backups.LastSuccessful(all); last != nil {
+		result.LastSuccessful = last.Finished
+		result.LastSuccessfulID = last.ID()
+	}
+	return result, nil
+}
+
+// backupsDB implements backups.DB, which needs
+// both state and model methods.
+type backupsDB struct {
+	*state.State
+	*state.Model
+}
+
+// ModelTag disambiguates the ModelTag method.
+func (db *backupsDB) ModelTag() names.ModelTag {
+	return db.Model.ModelTag()
+}
@@ -9,9 +9,11 @@ import (
 	"encoding/json"
 	"sort"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -45,11 +47,38 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8 doesn't have the RebuildModel and
+// RebuildProgress methods.
+type ControllerAPIv8 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the MigrationProgress
+// method.
+type ControllerAPIv7 struct {
+	*ControllerAPIv8
+}
+
+// ControllerAPIv6 provides the v6 Controller API. The only difference
+// between this and v7 is that v6 doesn't have the MigrationDryRun
+// method.
+type ControllerAPIv6 struct {
+	*ControllerAPIv7
+}
+
+// ControllerAPIv5 provides the v5 Controller API. The only difference
+// between this and v6 is that v5 doesn't have the BackupStatus method.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
 // ControllerAPIv4 provides the v4 Controller API. The only difference
 // between this and v5 is that v4 doesn't have the
 // UpdateControllerConfig method.
 type ControllerAPIv4 struct {
-	*ControllerAPI
+	*ControllerAPIv5
 }
 
 // ControllerAPIv3 provides the v3 Controller API.
@@ -57,8 +86,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv5 creates a new ControllerAPIv5.
-func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -76,6 +105,42 @@ func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v5, err := NewControllerAPIv5(ctx)
@@ -446,29 +511,9 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	}
 	defer hostedState.Release()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
-	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
-	}
-	authTag, err := names.ParseUserTag(specTarget.AuthTag)
-	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
-	}
-	var macs []macaroon.Slice
-	if specTarget.Macaroons != "" {
-		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
-		}
-	}
-	targetInfo := coremigration.TargetInfo{
-		ControllerTag: controllerTag,
-		Addrs:         specTarget.Addrs,
-		CACert:        specTarget.CACert,
-		AuthTag:       authTag,
-		Password:      specTarget.Password,
-		Macaroons:     macs,
+		return "", errors.Trace(err)
 	}
 
 	// Check if the migration is likely to succeed.
@@ -487,6 +532,171 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	return mig.Id(), nil
 }
 
+// MigrationDryRun checks whether the migration of each of the given
+// models would succeed, without starting any migration. Every problem
+// found by the source and target prechecks, and every reason the
+// target controller would refuse to import the exported model, is
+// reported.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		problems, err := c.migrationDryRunOne(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		for _, problem := range problems {
+			result.Problems = append(result.Problems, problem.Error())
+		}
+	}
+	return out, nil
+}
+
+// MigrationProgress reports the progress of the identified model
+// migrations. The migrations can be reported on after they have
+// finished, even once a successfully migrated model has been removed
+// from the controller.
+func (c *ControllerAPI) MigrationProgress(args params.MigrationProgressArgs) (params.MigrationProgressResults, error) {
+	out := params.MigrationProgressResults{
+		Results: make([]params.MigrationProgressResult, len(args.MigrationIds)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+	for i, id := range args.MigrationIds {
+		result := &out.Results[i]
+		result.MigrationId = id
+		mig, err := c.state.Migration(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		phase, err := mig.Phase()
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		result.ModelTag = names.NewModelTag(mig.ModelUUID()).String()
+		result.Phase = phase.String()
+		result.PhaseChangedTime = mig.PhaseChangedTime()
+		result.StatusMessage = mig.StatusMessage()
+	}
+	return out, nil
+}
+
+// RebuildModel starts rebuilding each of the given models on the cloud
+// of another controller. Rather than handing the model's machines over
+// to the target controller, as a migration does, the model is
+// imported with new machines to be provisioned by the target
+// controller, and its charms, agent binaries and resources are sent
+// along with it. The model's config, applications, relations,
+// leadership settings and resources are kept. The source model is
+// left as it is, to be destroyed once the rebuilt model is running.
+//
+// Each rebuild runs in the background, and its progress is reported
+// by RebuildProgress. A rebuild belongs to the API connection that
+// started it, and is aborted if the connection closes before the
+// rebuild has finished.
+func (c *ControllerAPI) RebuildModel(args params.RebuildModelArgs) (params.RebuildModelResults, error) {
+	out := params.RebuildModelResults{
+		Results: make([]params.RebuildModelResult, len(args.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+	for i, spec := range args.Specs {
+		id, err := c.rebuildModelOne(spec)
+		out.Results[i].RebuildId = id
+		out.Results[i].Error = common.ServerError(err)
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) rebuildModelOne(spec params.RebuildModelSpec) (string, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return "", errors.Annotate(err, "model tag")
+	}
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return "", errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return "", errors.NotFoundf("model")
+	}
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	pool := c.statePool
+	rebuild := startModelRebuild(func(rebuild *modelRebuild) error {
+		hostedState, err := pool.Get(modelTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer hostedState.Release()
+		return runModelRebuild(hostedState.State, pool.SystemState(), &targetInfo, spec.CloudRegion, spec.CloudCredential, rebuild)
+	})
+	return c.resources.Register(rebuild), nil
+}
+
+// RebuildProgress reports the progress of the identified model
+// rebuilds, which must have been started on the same API connection.
+func (c *ControllerAPI) RebuildProgress(args params.RebuildProgressArgs) (params.RebuildProgressResults, error) {
+	out := params.RebuildProgressResults{
+		Results: make([]params.RebuildProgressResult, len(args.RebuildIds)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+	for i, id := range args.RebuildIds {
+		rebuild, ok := c.resources.Get(id).(*modelRebuild)
+		if !ok {
+			out.Results[i] = params.RebuildProgressResult{
+				RebuildId: id,
+				Error:     common.ServerError(errors.NotFoundf("rebuild %q", id)),
+			}
+			continue
+		}
+		out.Results[i] = rebuild.progress()
+		out.Results[i].RebuildId = id
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) migrationDryRunOne(spec params.MigrationSpec) ([]error, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, errors.NotFoundf("model")
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -557,6 +767,38 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// Mask the BackupStatus method from the v5 API. The API reflection code
+// in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the method as far as the RPC machinery is concerned.
+
+// BackupStatus isn't on the v5 API.
+func (c *ControllerAPIv5) BackupStatus(_, _ struct{}) {}
+
+// Mask the MigrationDryRun method from the v6 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// MigrationDryRun isn't on the v6 API.
+func (c *ControllerAPIv6) MigrationDryRun(_, _ struct{}) {}
+
+// Mask the MigrationProgress method from the v7 API. The API
+// reflection code in rpc/rpcreflect/type.go:newMethod skips 2-argument
+// methods, so this removes the method as far as the RPC machinery is
+// concerned.
+
+// MigrationProgress isn't on the v7 API.
+func (c *ControllerAPIv7) MigrationProgress(_, _ struct{}) {}
+
+// Mask the RebuildModel method from the v8 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// RebuildModel isn't on the v8 API.
+func (c *ControllerAPIv8) RebuildModel(_, _ struct{}) {}
+
+// RebuildProgress isn't on the v8 API.
+func (c *ControllerAPIv8) RebuildProgress(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
@@ -598,6 +840,151 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun runs every check that starting a migration would
+// run, and exports the model for the target controller to check,
+// collecting all of the problems found rather than stopping at the
+// first. If the model can't be exported, the export error is reported
+// along with the source problems, and the target controller isn't
+// asked to check it. An error is returned only if the checks could not
+// be made, such as when the target controller can't be reached.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]error, error) {
+	var problems []error
+	addProblems := func(prefix string, errs []error) {
+		for _, err := range errs {
+			problems = append(problems, errors.Annotate(err, prefix))
+		}
+	}
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	sourceProblems, err := migration.SourcePrecheckAll(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return nil, errors.Annotate(err, "source prechecks")
+	}
+	addProblems("source", sourceProblems)
+
+	// Export the model as the migration would. The target
+	// controller can't check a model that can't be exported.
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		addProblems("export", []error{err})
+		return problems, nil
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return nil, errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			return nil, errors.New("controller API version is too old")
+		}
+	}
+	targetProblems, err := client.DryRun(modelInfo, bytes)
+	if errors.IsNotSupported(err) {
+		return nil, errors.New("target controller does not support a migration dry run")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "target dry run")
+	}
+	addProblems("target", targetProblems)
+	return problems, nil
+}
+
+// runModelRebuild rebuilds the model on the target controller's cloud,
+// after checking that the target controller would accept it, reporting
+// its progress to rebuild. The source prechecks aren't run: the
+// model's machines needn't be healthy, as they are replaced. The
+// rebuilt model is given a new UUID, so that it never claims the
+// source model's instances.
+var runModelRebuild = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, region, credential string, rebuild *modelRebuild) error {
+	rebuild.setStatus("checking target controller")
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelInfo.UUID = utils.MustNewUUID().String()
+	rebuild.setModelUUID(modelInfo.UUID)
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			return errors.New("controller API version is too old")
+		}
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+
+	model, err := st.Export()
+	if err != nil {
+		return errors.Annotate(err, "model export failed")
+	}
+	downloader := &stateDownloader{st: st}
+	return errors.Trace(migration.RebuildModel(migration.RebuildConfig{
+		Model:              model,
+		ModelUUID:          modelInfo.UUID,
+		CloudRegion:        region,
+		CloudCredential:    credential,
+		Target:             client,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+		Clock:              clock.WallClock,
+		Timeout:            migration.DefaultRebuildTimeout,
+		Abort:              rebuild.dying(),
+		SetStatus:          rebuild.setStatus,
+	}))
+}
+
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
+	if err != nil {
+		return coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
+	}
+	authTag, err := names.ParseUserTag(specTarget.AuthTag)
+	if err != nil {
+		return coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
+	}
+	var macs []macaroon.Slice
+	if specTarget.Macaroons != "" {
+		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
+			return coremigration.TargetInfo{}, errors.Annotate(err, "invalid macaroons")
+		}
+	}
+	return coremigration.TargetInfo{
+		ControllerTag: controllerTag,
+		Addrs:         specTarget.Addrs,
+		CACert:        specTarget.CACert,
+		AuthTag:       authTag,
+		Password:      specTarget.Password,
+		Macaroons:     macs,
+	}, nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -4,6 +4,7 @@
 package controller_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"regexp"
 	"time"
@@ -30,6 +31,8 @@ import (
 	"github.com/juju/juju/permission"
 	pscontroller "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -64,7 +67,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -570,6 +573,237 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetMigrationDryRunResult(s, []error{
+		errors.New("source: machine 0 not running (stopped)"),
+		errors.New("target: model with same UUID already exists"),
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationDryRunResult{
+		ModelTag: m.ModelTag().String(),
+		Problems: []string{
+			"source: machine 0 not running (stopped)",
+			"target: model with same UUID already exists",
+		},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetMigrationDryRunResult(s, nil, errors.New("connect to target controller: boom"))
+
+	out, err := s.controller.MigrationDryRun(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin").String(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Problems, gc.HasLen, 0)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "connect to target controller: boom")
+}
+
+func (s *controllerSuite) TestRebuildModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	rebuilt := controller.SetModelRebuildResult(s, nil)
+
+	args := params.RebuildModelArgs{
+		Specs: []params.RebuildModelSpec{
+			{
+				ModelTag: names.NewModelTag(st.ModelUUID()).String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+				CloudRegion:     "west",
+				CloudCredential: "cred",
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.RebuildModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+	c.Check(out.Results[1].RebuildId, gc.Equals, "")
+
+	progress := s.waitForRebuild(c, out.Results[0].RebuildId)
+	c.Check(progress, jc.DeepEquals, params.RebuildProgressResult{
+		RebuildId:     out.Results[0].RebuildId,
+		ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		StatusMessage: "activating model on target controller",
+		Done:          true,
+	})
+	c.Check(*rebuilt, jc.DeepEquals, []string{st.ModelUUID() + " west cred"})
+
+	// The source model is left alone.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestRebuildModelError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetModelRebuildResult(s, errors.New("target prechecks failed: boom"))
+
+	out, err := s.controller.RebuildModel(params.RebuildModelArgs{
+		Specs: []params.RebuildModelSpec{{
+			ModelTag: names.NewModelTag(st.ModelUUID()).String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin").String(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	progress := s.waitForRebuild(c, out.Results[0].RebuildId)
+	c.Check(progress.Done, jc.IsTrue)
+	c.Check(progress.Failure, gc.Equals, "target prechecks failed: boom")
+}
+
+func (s *controllerSuite) TestRebuildProgressNotFound(c *gc.C) {
+	out, err := s.controller.RebuildProgress(params.RebuildProgressArgs{
+		RebuildIds: []string{"42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].RebuildId, gc.Equals, "42")
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `rebuild "42" not found`)
+}
+
+// waitForRebuild waits for the identified rebuild to finish, and
+// returns its final progress.
+func (s *controllerSuite) waitForRebuild(c *gc.C, id string) params.RebuildProgressResult {
+	timeout := time.After(testing.LongWait)
+	for {
+		out, err := s.controller.RebuildProgress(params.RebuildProgressArgs{
+			RebuildIds: []string{id},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(out.Results, gc.HasLen, 1)
+		c.Assert(out.Results[0].Error, gc.IsNil)
+		if out.Results[0].Done {
+			return out.Results[0]
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for rebuild %q", id)
+		case <-time.After(testing.ShortWait):
+		}
+	}
+}
+
+func (s *controllerSuite) TestRebuildModelRequiresAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.UserTag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.RebuildModel(params.RebuildModelArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestMigrationProgress(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetPrecheckResult(s, nil)
+	started, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started.Results, gc.HasLen, 1)
+	c.Assert(started.Results[0].Error, gc.IsNil)
+	id := started.Results[0].MigrationId
+
+	mig, err := st.Migration(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("exporting model"), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationProgress(params.MigrationProgressArgs{
+		MigrationIds: []string{id, "nope:0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationProgressResult{
+		MigrationId:      id,
+		ModelTag:         m.ModelTag().String(),
+		Phase:            "QUIESCE",
+		PhaseChangedTime: mig.PhaseChangedTime(),
+		StatusMessage:    "exporting model",
+	})
+	c.Check(out.Results[1].MigrationId, gc.Equals, "nope:0")
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "migration not found")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -931,7 +1165,7 @@ func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -968,3 +1202,52 @@ func (s *controllerSuite) TestConfigSetPublishesEvent(c *gc.C) {
 
 	c.Assert(config.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
 }
+
+func (s *controllerSuite) TestBackupStatusNoBackups(c *gc.C) {
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerBackupStatus{})
+}
+
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	stor := backups.NewStorage(&backupsDBShim{s.State, s.Model})
+	defer stor.Close()
+	meta := backupstesting.NewMetadata()
+	_, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.LastSuccessfulID, gc.Equals, meta.ID())
+	c.Assert(result.LastSuccessful, gc.NotNil)
+	c.Assert(result.LastSuccessful.Equal(*meta.Finished), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type backupsDBShim struct {
+	*state.State
+	*state.Model
+}
+
+func (s *backupsDBShim) ModelTag() names.ModelTag {
+	return s.Model.ModelTag()
+}
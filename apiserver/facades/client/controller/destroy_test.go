@@ -54,7 +54,7 @@ func (s *destroyControllerSuite) SetUpTest(c *gc.C) {
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag: s.AdminUserTag(c),
 	}
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
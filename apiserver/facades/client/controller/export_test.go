@@ -18,3 +18,20 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetMigrationDryRunResult(p patcher, problems []error, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]error, error) {
+		return problems, err
+	})
+}
+
+func SetModelRebuildResult(p patcher, err error) *[]string {
+	var rebuilt []string
+	p.PatchValue(&runModelRebuild, func(st, _ *state.State, _ *migration.TargetInfo, region, credential string, rebuild *modelRebuild) error {
+		rebuilt = append(rebuilt, st.ModelUUID()+" "+region+" "+credential)
+		rebuild.setModelUUID("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+		rebuild.setStatus("activating model on target controller")
+		return err
+	})
+	return &rebuilt
+}
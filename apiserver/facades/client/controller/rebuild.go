@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"net/url"
+	"path"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+// modelRebuild is a model rebuild running in the background. It is
+// registered as a resource of the API connection that started it, so
+// that its progress can be read through that connection, and so that
+// it is aborted, removing the partly rebuilt model from the target
+// controller, if the connection closes before it has finished.
+type modelRebuild struct {
+	tomb tomb.Tomb
+
+	mu        sync.Mutex
+	modelUUID string
+	status    string
+	err       error
+}
+
+// startModelRebuild runs the rebuild in the background.
+func startModelRebuild(run func(*modelRebuild) error) *modelRebuild {
+	r := &modelRebuild{}
+	r.tomb.Go(func() error {
+		err := run(r)
+		if err != nil {
+			logger.Errorf("rebuilding model failed: %v", err)
+		}
+		r.mu.Lock()
+		r.err = err
+		r.mu.Unlock()
+		return nil
+	})
+	return r
+}
+
+// Stop is part of facade.Resource. A rebuild that is still running is
+// aborted.
+func (r *modelRebuild) Stop() error {
+	r.tomb.Kill(nil)
+	return r.tomb.Wait()
+}
+
+// dying returns a channel that is closed when the rebuild is aborted.
+func (r *modelRebuild) dying() <-chan struct{} {
+	return r.tomb.Dying()
+}
+
+func (r *modelRebuild) setModelUUID(modelUUID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.modelUUID = modelUUID
+}
+
+func (r *modelRebuild) setStatus(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = message
+}
+
+// progress reports how far the rebuild has got.
+func (r *modelRebuild) progress() params.RebuildProgressResult {
+	var done bool
+	select {
+	case <-r.tomb.Dead():
+		done = true
+	default:
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := params.RebuildProgressResult{
+		StatusMessage: r.status,
+		Done:          done,
+	}
+	if r.modelUUID != "" {
+		result.ModelTag = names.NewModelTag(r.modelUUID).String()
+	}
+	if r.err != nil {
+		result.Failure = r.err.Error()
+	}
+	return result
+}
+
+// stateDownloader reads a model's binaries straight from the source
+// controller's storage, for rebuilding the model on another
+// controller.
+type stateDownloader struct {
+	st *state.State
+}
+
+// OpenCharm is part of migration.CharmDownloader.
+func (d *stateDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := d.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store := storage.NewStorage(d.st.ModelUUID(), d.st.MongoSession())
+	reader, _, err := store.Get(ch.StoragePath())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %q from storage", curl)
+	}
+	return reader, nil
+}
+
+// OpenURI is part of migration.ToolsDownloader. The URI is that of
+// the agent binaries on the API server, ending in their version.
+func (d *stateDownloader) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	vers, err := version.ParseBinary(path.Base(uri))
+	if err != nil {
+		return nil, errors.Annotate(err, "error parsing version")
+	}
+	toolsStorage, err := d.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Annotate(err, "error getting storage for agent binaries")
+	}
+	_, reader, err := toolsStorage.Open(vers.String())
+	if err != nil {
+		toolsStorage.Close()
+		return nil, errors.Annotatef(err, "cannot read agent binaries %s from storage", vers)
+	}
+	return &toolsReader{ReadCloser: reader, storage: toolsStorage}, nil
+}
+
+// OpenResource is part of migration.ResourceDownloader.
+func (d *stateDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	resources, err := d.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := resources.OpenResource(application, name)
+	return reader, errors.Trace(err)
+}
+
+// toolsReader closes the tools storage along with the binaries read
+// from it.
+type toolsReader struct {
+	io.ReadCloser
+	storage io.Closer
+}
+
+// Close is part of io.Closer.
+func (r *toolsReader) Close() error {
+	err := r.ReadCloser.Close()
+	if closeErr := r.storage.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewHighAvailabilityAPIForTest returns a high availability API that
+// rehearses failover using the given hub, clock and replica set.
+func NewHighAvailabilityAPIForTest(
+	st *state.State,
+	authorizer facade.Authorizer,
+	hub facade.Hub,
+	clock clock.Clock,
+	rs ReplicaSet,
+) *HighAvailabilityAPI {
+	return &HighAvailabilityAPI{
+		state:          st,
+		authorizer:     authorizer,
+		hub:            hub,
+		clock:          clock,
+		openReplicaSet: func() ReplicaSet { return rs },
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/controller"
+)
+
+const (
+	// minFailoverVoters is the number of healthy voting members a
+	// service needs for failover to be rehearsed safely: demoting
+	// the leader of a smaller cluster would lose quorum.
+	minFailoverVoters = 3
+
+	// defaultFailoverTimeout is how long to wait for a service to
+	// elect a new leader, if the caller doesn't say.
+	defaultFailoverTimeout = 5 * time.Minute
+
+	// failoverPollInterval is how often the services are checked
+	// while waiting for them to recover.
+	failoverPollInterval = time.Second
+
+	// machineIdTag is the replica set member tag holding the
+	// controller machine ID, as set by the peergrouper.
+	machineIdTag = "juju-machine-id"
+)
+
+// ReplicaSet provides the mongo replica set operations needed to
+// rehearse failover.
+type ReplicaSet interface {
+	CurrentStatus() (*replicaset.Status, error)
+	CurrentMembers() ([]replicaset.Member, error)
+	StepDownPrimary() error
+
+	// Refresh reconnects after the primary has stepped down and
+	// closed all its connections.
+	Refresh()
+	Close()
+}
+
+type mongoReplicaSet struct {
+	session *mgo.Session
+}
+
+func (s mongoReplicaSet) CurrentStatus() (*replicaset.Status, error) {
+	return replicaset.CurrentStatus(s.session)
+}
+
+func (s mongoReplicaSet) CurrentMembers() ([]replicaset.Member, error) {
+	return replicaset.CurrentMembers(s.session)
+}
+
+func (s mongoReplicaSet) StepDownPrimary() error {
+	return replicaset.StepDownPrimary(s.session)
+}
+
+func (s mongoReplicaSet) Refresh() {
+	s.session.Refresh()
+}
+
+func (s mongoReplicaSet) Close() {
+	s.session.Close()
+}
+
+// RehearseFailover demotes the current mongo primary and/or raft
+// leader, waits for the controllers to elect new ones, and reports
+// how long they took to recover. The services are checked to have
+// enough healthy voting members first, so that the rehearsal can't
+// cost the controller its quorum.
+func (api *HighAvailabilityAPI) RehearseFailover(args params.RehearseFailoverArgs) (params.RehearseFailoverResults, error) {
+	results := params.RehearseFailoverResults{}
+
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return results, errors.Trace(err)
+	}
+	if !admin {
+		return results, common.ServerError(common.ErrPerm)
+	}
+
+	timeout := args.Timeout
+	if timeout <= 0 {
+		timeout = defaultFailoverTimeout
+	}
+	if args.Mongo {
+		result, err := api.rehearseMongoFailover(timeout)
+		result.Error = common.ServerError(err)
+		results.Results = append(results.Results, result)
+	}
+	if args.Raft {
+		result, err := api.rehearseRaftFailover(timeout)
+		result.Error = common.ServerError(err)
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+func (api *HighAvailabilityAPI) rehearseMongoFailover(timeout time.Duration) (params.FailoverResult, error) {
+	result := params.FailoverResult{Service: "mongo"}
+	if api.openReplicaSet == nil {
+		return result, errors.NotSupportedf("mongo failover rehearsal")
+	}
+	rs := api.openReplicaSet()
+	defer rs.Close()
+
+	members, err := rs.CurrentMembers()
+	if err != nil {
+		return result, errors.Annotate(err, "cannot get replica set members")
+	}
+	status, err := rs.CurrentStatus()
+	if err != nil {
+		return result, errors.Annotate(err, "cannot get replica set status")
+	}
+	primary, err := checkReplicaSet(members, status)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.OldLeader = memberName(members, primary)
+
+	start := api.clock.Now()
+	logger.Infof("rehearsing failover: stepping down mongo primary %s", result.OldLeader)
+	if err := rs.StepDownPrimary(); err != nil {
+		return result, errors.Annotate(err, "cannot step down mongo primary")
+	}
+	deadline := api.clock.After(timeout)
+	for {
+		rs.Refresh()
+		status, err := rs.CurrentStatus()
+		if err != nil {
+			// Expected while the members hold an election.
+			logger.Debugf("waiting for new mongo primary: %v", err)
+		} else if newPrimary, err := checkReplicaSet(members, status); err == nil && newPrimary.Id != primary.Id {
+			result.NewLeader = memberName(members, newPrimary)
+			result.RecoveryTime = api.clock.Now().Sub(start)
+			return result, nil
+		}
+		select {
+		case <-api.clock.After(failoverPollInterval):
+		case <-deadline:
+			return result, errors.Errorf("timed out after %v waiting for new mongo primary", timeout)
+		}
+	}
+}
+
+// checkReplicaSet returns the status of the replica set's primary if
+// the replica set has enough voting members and they are all healthy.
+func checkReplicaSet(members []replicaset.Member, status *replicaset.Status) (replicaset.MemberStatus, error) {
+	statuses := make(map[int]replicaset.MemberStatus)
+	var primary *replicaset.MemberStatus
+	for i, m := range status.Members {
+		statuses[m.Id] = m
+		if m.State == replicaset.PrimaryState {
+			primary = &status.Members[i]
+		}
+	}
+	voters := 0
+	for _, m := range members {
+		if m.Votes != nil && *m.Votes == 0 {
+			continue
+		}
+		voters++
+		if s, ok := statuses[m.Id]; !ok || !s.Healthy {
+			return replicaset.MemberStatus{}, errors.Errorf("voting mongo member %s is not healthy", machineName(m))
+		}
+	}
+	if voters < minFailoverVoters {
+		return replicaset.MemberStatus{}, errors.Errorf(
+			"mongo replica set has %d voting members, need at least %d", voters, minFailoverVoters)
+	}
+	if primary == nil {
+		return replicaset.MemberStatus{}, errors.New("mongo replica set has no primary")
+	}
+	return *primary, nil
+}
+
+// memberName returns the name of the replica set member with the
+// given status.
+func memberName(members []replicaset.Member, status replicaset.MemberStatus) string {
+	for _, m := range members {
+		if m.Id == status.Id {
+			return machineName(m)
+		}
+	}
+	return status.Address
+}
+
+// machineName returns the controller machine ID of the replica set
+// member, or its address if it isn't tagged with one.
+func machineName(m replicaset.Member) string {
+	if id := m.Tags[machineIdTag]; id != "" {
+		return id
+	}
+	return m.Address
+}
+
+func (api *HighAvailabilityAPI) rehearseRaftFailover(timeout time.Duration) (params.FailoverResult, error) {
+	result := params.FailoverResult{Service: "raft"}
+	if api.hub == nil {
+		return result, errors.NotSupportedf("raft failover rehearsal")
+	}
+	statuses := make(chan controller.RaftStatus, 10)
+	unsubscribe, err := api.hub.Subscribe(controller.RaftStatusTopic,
+		func(_ string, status controller.RaftStatus, err error) {
+			if err != nil {
+				logger.Errorf("raft status: %v", err)
+				return
+			}
+			select {
+			case statuses <- status:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer unsubscribe()
+
+	deadline := api.clock.After(timeout)
+	old, err := api.checkRaftCluster(statuses, deadline)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.OldLeader = old.ID
+
+	start := api.clock.Now()
+	logger.Infof("rehearsing failover: stepping down raft leader %s", old.ID)
+	if _, err := api.hub.Publish(controller.RaftStepDownTopic, controller.RaftStepDown{Leader: old.ID}); err != nil {
+		return result, errors.Annotate(err, "cannot step down raft leader")
+	}
+	leader, err := api.waitRaftLeader(statuses, deadline, func(status controller.RaftStatus) bool {
+		return status.Term > old.Term
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.NewLeader = leader.ID
+	result.RecoveryTime = api.clock.Now().Sub(start)
+	return result, nil
+}
+
+// checkRaftCluster asks the controllers for their raft status, and
+// returns the leader's status once every other voting member reports
+// following it. Only the leader reports how many voters there are, so
+// a round of status requests that leaves any voter not following the
+// leader means the cluster is not healthy enough to rehearse failover.
+func (api *HighAvailabilityAPI) checkRaftCluster(
+	statuses <-chan controller.RaftStatus,
+	deadline <-chan time.Time,
+) (controller.RaftStatus, error) {
+	var leader *controller.RaftStatus
+	followers := make(map[string]controller.RaftStatus)
+	following := func() int {
+		count := 0
+		for _, status := range followers {
+			if status.State == "Follower" && status.Leader == leader.ID {
+				count++
+			}
+		}
+		return count
+	}
+	for {
+		_, err := api.hub.Publish(controller.RaftStatusRequestTopic, controller.RaftStatusRequest{
+			Requester: "highavailability",
+		})
+		if err != nil {
+			return controller.RaftStatus{}, errors.Trace(err)
+		}
+		poll := api.clock.After(failoverPollInterval)
+	collect:
+		for {
+			select {
+			case status := <-statuses:
+				if status.State == "Leader" {
+					leader = &status
+					if leader.Voters < minFailoverVoters {
+						return controller.RaftStatus{}, errors.Errorf(
+							"raft cluster has %d voting members, need at least %d", leader.Voters, minFailoverVoters)
+					}
+				} else {
+					followers[status.ID] = status
+				}
+				if leader != nil && following()+1 >= leader.Voters {
+					return *leader, nil
+				}
+			case <-poll:
+				break collect
+			case <-deadline:
+				return controller.RaftStatus{}, errors.New("timed out waiting for raft leader")
+			}
+		}
+		if leader != nil {
+			return controller.RaftStatus{}, errors.Errorf(
+				"only %d of %d raft voting members are following leader %s",
+				following()+1, leader.Voters, leader.ID)
+		}
+	}
+}
+
+// waitRaftLeader repeatedly asks the controllers for their raft
+// status until the leader reports a status accepted by the given
+// function.
+func (api *HighAvailabilityAPI) waitRaftLeader(
+	statuses <-chan controller.RaftStatus,
+	deadline <-chan time.Time,
+	accept func(controller.RaftStatus) bool,
+) (controller.RaftStatus, error) {
+	for {
+		_, err := api.hub.Publish(controller.RaftStatusRequestTopic, controller.RaftStatusRequest{
+			Requester: "highavailability",
+		})
+		if err != nil {
+			return controller.RaftStatus{}, errors.Trace(err)
+		}
+		poll := api.clock.After(failoverPollInterval)
+	collect:
+		for {
+			select {
+			case status := <-statuses:
+				if status.State == "Leader" && accept(status) {
+					return status, nil
+				}
+			case <-poll:
+				break collect
+			case <-deadline:
+				return controller.RaftStatus{}, errors.New("timed out waiting for raft leader")
+			}
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
+	"github.com/juju/pubsub"
+	"github.com/juju/replicaset"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/pubsub/controller"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type failoverSuite struct {
+	statetesting.StateSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	hub        *pubsub.StructuredHub
+	clock      *testclock.Clock
+	rs         *fakeReplicaSet
+}
+
+var _ = gc.Suite(&failoverSuite{})
+
+func (s *failoverSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        s.Owner,
+		AdminTag:   s.Owner,
+		Controller: true,
+	}
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.clock = testclock.NewClock(time.Now())
+	s.rs = &fakeReplicaSet{
+		members: []replicaset.Member{
+			{Id: 1, Address: "10.0.0.1:37017", Tags: map[string]string{"juju-machine-id": "0"}},
+			{Id: 2, Address: "10.0.0.2:37017", Tags: map[string]string{"juju-machine-id": "1"}},
+			{Id: 3, Address: "10.0.0.3:37017", Tags: map[string]string{"juju-machine-id": "2"}},
+		},
+		primary:      1,
+		newPrimary:   2,
+		healthy:      map[int]bool{1: true, 2: true, 3: true},
+		stepDownDone: make(chan struct{}),
+	}
+}
+
+func (s *failoverSuite) api() *highavailability.HighAvailabilityAPI {
+	return highavailability.NewHighAvailabilityAPIForTest(s.State, s.authorizer, s.hub, s.clock, s.rs)
+}
+
+func (s *failoverSuite) TestRehearseFailoverPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+
+	_, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Mongo: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.rs.CheckNoCalls(c)
+}
+
+func (s *failoverSuite) TestRehearseMongoFailover(c *gc.C) {
+	results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Mongo: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RehearseFailoverResults{
+		Results: []params.FailoverResult{{
+			Service:   "mongo",
+			OldLeader: "0",
+			NewLeader: "1",
+		}},
+	})
+	s.rs.CheckCallNames(c, "CurrentMembers", "CurrentStatus", "StepDownPrimary", "Refresh", "CurrentStatus", "Close")
+}
+
+func (s *failoverSuite) TestRehearseMongoFailoverTooFewVoters(c *gc.C) {
+	noVote := 0
+	s.rs.members[2].Votes = &noVote
+
+	results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Mongo: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "mongo replica set has 2 voting members, need at least 3")
+	s.rs.CheckCallNames(c, "CurrentMembers", "CurrentStatus", "Close")
+}
+
+func (s *failoverSuite) TestRehearseMongoFailoverUnhealthy(c *gc.C) {
+	s.rs.healthy[3] = false
+
+	results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Mongo: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "voting mongo member 2 is not healthy")
+	s.rs.CheckCallNames(c, "CurrentMembers", "CurrentStatus", "Close")
+}
+
+func (s *failoverSuite) TestRehearseMongoFailoverTimeout(c *gc.C) {
+	s.rs.newPrimary = 0
+
+	errs := make(chan error, 1)
+	go func() {
+		results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{
+			Mongo:   true,
+			Timeout: time.Minute,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(results.Results, gc.HasLen, 1)
+		errs <- results.Results[0].Error
+	}()
+	select {
+	case <-s.rs.stepDownDone:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for step down")
+	}
+	// Wait for the deadline and the poll interval.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, "timed out after 1m0s waiting for new mongo primary")
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for result")
+	}
+}
+
+func (s *failoverSuite) TestRehearseRaftFailover(c *gc.C) {
+	s.startRaftCluster(c, 3)
+
+	results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Raft: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RehearseFailoverResults{
+		Results: []params.FailoverResult{{
+			Service:   "raft",
+			OldLeader: "0",
+			NewLeader: "2",
+		}},
+	})
+}
+
+func (s *failoverSuite) TestRehearseRaftFailoverTooFewVoters(c *gc.C) {
+	s.startRaftCluster(c, 1)
+
+	results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{Raft: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "raft cluster has 1 voting members, need at least 3")
+}
+
+func (s *failoverSuite) TestRehearseRaftFailoverUnhealthyVoter(c *gc.C) {
+	s.startRaftCluster(c, 3, "1")
+	stepDowns := make(chan controller.RaftStepDown, 1)
+	unsubscribe, err := s.hub.Subscribe(controller.RaftStepDownTopic,
+		func(_ string, msg controller.RaftStepDown, err error) {
+			stepDowns <- msg
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	errs := make(chan error, 1)
+	go func() {
+		results, err := s.api().RehearseFailover(params.RehearseFailoverArgs{
+			Raft:    true,
+			Timeout: time.Minute,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(results.Results, gc.HasLen, 1)
+		errs <- results.Results[0].Error
+	}()
+	// Wait for the deadline and the poll interval.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, `only [12] of 3 raft voting members are following leader 0`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for result")
+	}
+	select {
+	case msg := <-stepDowns:
+		c.Fatalf("unexpected step down %+v", msg)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// startRaftCluster answers raft status requests on the hub as the
+// raft workers would, with "0" as the leader until it is asked to step
+// down, when "2" announces itself as the new leader. The other voters
+// follow the leader, except for the unhealthy ones, which don't know
+// who the leader is.
+func (s *failoverSuite) startRaftCluster(c *gc.C, voters int, unhealthy ...string) {
+	var mu sync.Mutex
+	leader := controller.RaftStatus{ID: "0", State: "Leader", Leader: "0", Term: 1, Voters: voters}
+	unsubscribe, err := s.hub.Subscribe(controller.RaftStatusRequestTopic,
+		func(_ string, _ controller.RaftStatusRequest, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			mu.Lock()
+			statuses := []controller.RaftStatus{leader}
+			for i := 0; i < voters; i++ {
+				id := fmt.Sprint(i)
+				if id == leader.ID {
+					continue
+				}
+				follower := controller.RaftStatus{ID: id, State: "Follower", Leader: leader.ID, Term: leader.Term}
+				if set.NewStrings(unhealthy...).Contains(id) {
+					follower.Leader = ""
+				}
+				statuses = append(statuses, follower)
+			}
+			mu.Unlock()
+			for _, status := range statuses {
+				_, err = s.hub.Publish(controller.RaftStatusTopic, status)
+				c.Check(err, jc.ErrorIsNil)
+			}
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+	unsubscribe, err = s.hub.Subscribe(controller.RaftStepDownTopic,
+		func(_ string, msg controller.RaftStepDown, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(msg.Leader, gc.Equals, "0")
+			mu.Lock()
+			leader = controller.RaftStatus{ID: "2", State: "Leader", Leader: "2", Term: 2, Voters: voters}
+			status := leader
+			mu.Unlock()
+			_, err = s.hub.Publish(controller.RaftStatusTopic, status)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+}
+
+type fakeReplicaSet struct {
+	jujutesting.Stub
+
+	members      []replicaset.Member
+	healthy      map[int]bool
+	primary      int
+	newPrimary   int
+	steppedDown  bool
+	stepDownDone chan struct{}
+}
+
+func (rs *fakeReplicaSet) CurrentMembers() ([]replicaset.Member, error) {
+	rs.MethodCall(rs, "CurrentMembers")
+	return rs.members, rs.NextErr()
+}
+
+func (rs *fakeReplicaSet) CurrentStatus() (*replicaset.Status, error) {
+	rs.MethodCall(rs, "CurrentStatus")
+	primary := rs.primary
+	if rs.steppedDown {
+		primary = rs.newPrimary
+	}
+	status := &replicaset.Status{}
+	for _, m := range rs.members {
+		state := replicaset.SecondaryState
+		if m.Id == primary {
+			state = replicaset.PrimaryState
+		}
+		status.Members = append(status.Members, replicaset.MemberStatus{
+			Id:      m.Id,
+			Address: m.Address,
+			Healthy: rs.healthy[m.Id],
+			State:   state,
+		})
+	}
+	return status, rs.NextErr()
+}
+
+func (rs *fakeReplicaSet) StepDownPrimary() error {
+	rs.MethodCall(rs, "StepDownPrimary")
+	rs.steppedDown = true
+	close(rs.stepDownDone)
+	return rs.NextErr()
+}
+
+func (rs *fakeReplicaSet) Refresh() {
+	rs.MethodCall(rs, "Refresh")
+}
+
+func (rs *fakeReplicaSet) Close() {
+	rs.MethodCall(rs, "Close")
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -30,6 +31,8 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	RehearseFailover(args params.RehearseFailoverArgs) (params.RehearseFailoverResults, error)
+	RemoveControllers(args params.RemoveControllersArgs) (params.ControllersChangeResults, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -38,10 +41,67 @@ type HighAvailabilityAPI struct {
 	state      *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
+
+	// The following are used by RehearseFailover.
+	hub            facade.Hub
+	clock          clock.Clock
+	openReplicaSet func() ReplicaSet
+}
+
+// HighAvailabilityAPIV3 implements the v3 high availability API,
+// which lacks RemoveControllers.
+type HighAvailabilityAPIV3 struct {
+	*HighAvailabilityAPI
+}
+
+// HighAvailabilityAPIV2 implements the v2 high availability API,
+// which also lacks RehearseFailover.
+type HighAvailabilityAPIV2 struct {
+	*HighAvailabilityAPIV3
 }
 
 var _ HighAvailability = (*HighAvailabilityAPI)(nil)
 
+// NewHighAvailabilityAPIV4 creates a new server-side highavailability
+// API end point, able to rehearse failover and remove controllers.
+func NewHighAvailabilityAPIV4(ctx facade.Context) (*HighAvailabilityAPI, error) {
+	api, err := NewHighAvailabilityAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.hub = ctx.Hub()
+	api.openReplicaSet = func() ReplicaSet {
+		return mongoReplicaSet{ctx.State().MongoSession().Copy()}
+	}
+	return api, nil
+}
+
+// NewHighAvailabilityAPIV3 creates a new server-side v3
+// highavailability API end point.
+func NewHighAvailabilityAPIV3(ctx facade.Context) (*HighAvailabilityAPIV3, error) {
+	api, err := NewHighAvailabilityAPIV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HighAvailabilityAPIV3{api}, nil
+}
+
+// NewHighAvailabilityAPIV2 creates a new server-side v2
+// highavailability API end point.
+func NewHighAvailabilityAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV2, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HighAvailabilityAPIV2{&HighAvailabilityAPIV3{api}}, nil
+}
+
+// RehearseFailover isn't on the v2 API.
+func (api *HighAvailabilityAPIV2) RehearseFailover(_, _ struct{}) {}
+
+// RemoveControllers isn't on the v3 API.
+func (api *HighAvailabilityAPIV3) RemoveControllers(_, _ struct{}) {}
+
 // NewHighAvailabilityAPI creates a new server-side highavailability API end point.
 func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPI, error) {
 	// Only clients can access the high availability facade.
@@ -52,6 +112,7 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 		state:      st,
 		resources:  resources,
 		authorizer: authorizer,
+		clock:      clock.WallClock,
 	}, nil
 }
 
@@ -141,6 +202,53 @@ func (api *HighAvailabilityAPI) enableHASingle(st *state.State, spec params.Cont
 	return controllersChanges(changes), nil
 }
 
+// RemoveControllers decommissions the given controller machines. Each
+// machine gives up its vote, to another controller machine which is
+// promoted or added in its place unless no replacement is requested,
+// and is then removed from the mongo replica set and raft cluster, and
+// finally removed altogether.
+func (api *HighAvailabilityAPI) RemoveControllers(args params.RemoveControllersArgs) (params.ControllersChangeResults, error) {
+	results := params.ControllersChangeResults{}
+
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return results, errors.Trace(err)
+	}
+	if !admin {
+		return results, common.ServerError(common.ErrPerm)
+	}
+	if !api.state.IsController() {
+		return results, errors.New("unsupported with hosted models")
+	}
+	if err := common.NewBlockChecker(api.state).RemoveAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	results.Results = make([]params.ControllersChangeResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		result, err := api.removeController(entity.Tag, !args.NoReplacement)
+		results.Results[i].Result = result
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *HighAvailabilityAPI) removeController(tag string, replace bool) (params.ControllersChanges, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	m, err := api.state.Machine(machineTag.Id())
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	changes, err := api.state.DecommissionController(m, replace)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	return controllersChanges(changes), nil
+}
+
 // getReferenceController looks up the ideal controller to use as a reference for Constraints and Series
 func getReferenceController(st *state.State, machineIds []string) (*state.Machine, error) {
 	// Sort the controller IDs from low to high and take the first.
@@ -599,3 +599,86 @@ func (s *clientSuite) TestEnableHABootstrap(c *gc.C) {
 	c.Assert(enableHAResult.Converted, gc.HasLen, 0)
 	c.Assert(enableHAResult.Demoted, gc.HasLen, 0)
 }
+
+func (s *clientSuite) TestRemoveControllers(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-42"}, {Tag: "application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0], jc.DeepEquals, params.ControllersChangeResult{
+		Result: params.ControllersChanges{
+			Added:   []string{"machine-3"},
+			Removed: []string{"machine-1"},
+		},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine 42 not found")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"application-foo" is not a valid machine tag`)
+
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m1.Life(), gc.Equals, state.Dying)
+	c.Check(m1.WantsVote(), jc.IsFalse)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.MachineIds, jc.SameContents, []string{"0", "1", "2", "3"})
+}
+
+func (s *clientSuite) TestRemoveControllersNoReplacement(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Entities:      []params.Entity{{Tag: "machine-1"}},
+		NoReplacement: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0], jc.DeepEquals, params.ControllersChangeResult{
+		Result: params.ControllersChanges{
+			Removed: []string{"machine-1"},
+			Demoted: []string{"machine-2"},
+		},
+	})
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.MachineIds, jc.SameContents, []string{"0", "1", "2"})
+}
+
+func (s *clientSuite) TestRemoveControllersLastController(c *gc.C) {
+	results, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches,
+		"cannot decommission controller machine 0: machine 0 is the only controller machine")
+}
+
+func (s *clientSuite) TestBlockRemoveControllers(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockRemoveObject(c, "TestBlockRemoveControllers")
+
+	_, err = s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockRemoveControllers")
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m1.Life(), gc.Equals, state.Alive)
+}
+
+func (s *clientSuite) TestRemoveControllersPermission(c *gc.C) {
+	s.authoriser.Tag = s.Factory.MakeUser(c, nil).Tag()
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = haServer.RemoveControllers(params.RemoveControllersArgs{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
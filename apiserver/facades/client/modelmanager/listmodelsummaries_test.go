@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -220,3 +221,47 @@ func (s *ListModelsWithInfoSuite) TestListModelSummariesNoModelsForUser(c *gc.C)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 0)
 }
+
+func (s *ListModelsWithInfoSuite) TestListUnhealthyEntities(c *gc.C) {
+	now := time.Now()
+	s.st.unhealthyStatuses = []state.UnhealthyStatus{{
+		ModelUUID: s.st.ModelUUID(),
+		Entity:    names.NewUnitTag("mysql/0"),
+		Kind:      status.KindWorkload,
+		StatusInfo: status.StatusInfo{
+			Status:  status.Error,
+			Message: `hook failed: "install"`,
+			Since:   &now,
+		},
+	}}
+	s.st.ResetCalls()
+	result, err := s.api.ListUnhealthyEntities(params.ModelSummariesRequest{
+		UserTag: s.adminUser.String(),
+		All:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnhealthyEntities{
+		Entities: []params.UnhealthyEntity{{
+			ModelUUID:     s.st.ModelUUID(),
+			ModelName:     "only",
+			ModelOwnerTag: s.adminUser.String(),
+			Tag:           "unit-mysql-0",
+			Kind:          "workload",
+			Status: params.EntityStatus{
+				Status: status.Error,
+				Info:   `hook failed: "install"`,
+				Since:  &now,
+			},
+		}},
+	})
+	s.st.CheckCall(c, 0, "ModelSummariesForUser", s.adminUser, true)
+	s.st.CheckCall(c, 1, "UnhealthyStatuses", []string{s.st.ModelUUID()})
+}
+
+func (s *ListModelsWithInfoSuite) TestListUnhealthyEntitiesDenied(c *gc.C) {
+	user := names.NewUserTag("external@remote")
+	s.setAPIUser(c, user)
+	other := names.NewUserTag("other@remote")
+	_, err := s.api.ListUnhealthyEntities(params.ModelSummariesRequest{UserTag: other.String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -561,6 +561,7 @@ type mockState struct {
 	modelConfig     *config.Config
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
+	unhealthyStatuses   []state.UnhealthyStatus
 }
 
 type fakeModelDescription struct {
@@ -751,6 +752,11 @@ func (st *mockState) ModelSummariesForUser(user names.UserTag, all bool) ([]stat
 	return st.modelDetailsForUser()
 }
 
+func (st *mockState) UnhealthyStatuses(modelUUIDs []string) ([]state.UnhealthyStatus, error) {
+	st.MethodCall(st, "UnhealthyStatuses", modelUUIDs)
+	return st.unhealthyStatuses, st.NextErr()
+}
+
 func (st *mockState) ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error) {
 	st.MethodCall(st, "ModelBasicInfoForUser", user)
 	return []state.ModelAccessInfo{}, st.NextErr()
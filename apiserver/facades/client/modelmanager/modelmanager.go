@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	k8s "github.com/juju/juju/caas/kubernetes/provider"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs"
@@ -39,7 +40,21 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
-// ModelManagerV4 defines the methods on the version 2 facade for the
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModelSummaries(request params.ModelSummariesRequest) (params.ModelSummaryResults, error)
+	ListModels(user params.Entity) (params.UserModelList, error)
+	ListUnhealthyEntities(request params.ModelSummariesRequest) (params.UnhealthyEntities, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+	ModelInfo(args params.Entities) (params.ModelInfoResults, error)
+	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
+}
+
+// ModelManagerV4 defines the methods on the version 4 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
@@ -90,7 +105,13 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
-// ModelManagerAPIV2 provides a way to wrap the different calls between
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV3 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
 	*ModelManagerAPI
@@ -103,13 +124,14 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -138,13 +160,22 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
-	v4, err := NewFacadeV4(ctx)
+	v5, err := NewFacadeV5(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &ModelManagerAPIV3{v4}, nil
+	return &ModelManagerAPIV3{v5}, nil
 }
 
 // NewFacade is used for API registration.
@@ -272,6 +303,13 @@ func (m *ModelManagerAPI) newModelConfig(
 	return creator.NewModelConfig(cloudSpec, baseConfig, joint)
 }
 
+// caasModelConfigKeys are the attributes of the config supplied
+// when creating a CAAS model which are used in the model's config.
+var caasModelConfigKeys = []string{
+	k8s.AdoptNamespaceKey,
+	k8s.PreserveNamespaceKey,
+}
+
 func (m *ModelManagerAPI) newCAASModelConfig(
 	cloudSpec environs.CloudSpec,
 	args params.ModelCreateArgs,
@@ -284,18 +322,32 @@ func (m *ModelManagerAPI) newCAASModelConfig(
 		return nil, errors.NewNotValid(nil, "Name must be specified")
 	}
 
-	attrs := map[string]interface{}{
-		config.NameKey:         args.Name,
-		config.TypeKey:         cloudSpec.Type,
-		config.UUIDKey:         uuid.String(),
-		config.AgentVersionKey: jujuversion.Current.String(),
+	// Only the attributes controlling how the model's namespace is
+	// managed are taken from the supplied config.
+	attrs := make(map[string]interface{})
+	for _, k := range caasModelConfigKeys {
+		if v, ok := args.Config[k]; ok {
+			attrs[k] = v
+		}
 	}
+	attrs[config.NameKey] = args.Name
+	attrs[config.TypeKey] = cloudSpec.Type
+	attrs[config.UUIDKey] = uuid.String()
+	attrs[config.AgentVersionKey] = jujuversion.Current.String()
 
 	cfg, err := config.New(config.UseDefaults, attrs)
 	if err != nil {
 		return nil, errors.Annotate(err, "creating config from values failed")
 	}
 
+	provider, err := environs.Provider(cloudSpec.Type)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err = provider.Validate(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
 	return cfg, nil
 }
 
@@ -458,6 +510,19 @@ func (m *ModelManagerAPI) newCAASModel(cloudSpec environs.CloudSpec,
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to open kubernetes client")
 	}
+
+	controllerCfg, err := m.state.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = broker.Create(
+		m.callContext,
+		environs.CreateParams{
+			ControllerUUID: controllerCfg.ControllerUUID(),
+		},
+	); err != nil {
+		return nil, errors.Annotatef(err, "failed to prepare namespace %q", newConfig.Name())
+	}
 	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(broker)
 
 	model, st, err := m.state.NewModel(state.ModelArgs{
@@ -786,6 +851,53 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 	return result, nil
 }
 
+// ListUnhealthyEntities returns the statuses of the machines and units
+// that need attention, across the models that the specified user has
+// access to. Controller admins can ask for the models of any user, and
+// for all of the models in the controller.
+func (m *ModelManagerAPI) ListUnhealthyEntities(req params.ModelSummariesRequest) (params.UnhealthyEntities, error) {
+	result := params.UnhealthyEntities{}
+
+	userTag, err := names.ParseUserTag(req.UserTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := m.authCheck(userTag); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// Users have at least read access to every model
+	// summarised for them.
+	summaries, err := m.state.ModelSummariesForUser(userTag, req.All)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	modelUUIDs := make([]string, len(summaries))
+	byUUID := make(map[string]state.ModelSummary, len(summaries))
+	for i, summary := range summaries {
+		modelUUIDs[i] = summary.UUID
+		byUUID[summary.UUID] = summary
+	}
+
+	statuses, err := m.state.UnhealthyStatuses(modelUUIDs)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entities = make([]params.UnhealthyEntity, len(statuses))
+	for i, s := range statuses {
+		summary := byUUID[s.ModelUUID]
+		result.Entities[i] = params.UnhealthyEntity{
+			ModelUUID:     s.ModelUUID,
+			ModelName:     summary.Name,
+			ModelOwnerTag: names.NewUserTag(summary.Owner).String(),
+			Tag:           s.Entity.String(),
+			Kind:          string(s.Kind),
+			Status:        common.EntityStatusFromState(s.StatusInfo),
+		}
+	}
+	return result, nil
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Controller admins (superuser)
 // can list models for any user.  Other users
@@ -1349,3 +1461,13 @@ func (s *ModelManagerAPI) oldModelStatus(req params.Entities) (params.ModelStatu
 	}
 	return results, nil
 }
+
+// Mask the new methods from the V4 and earlier APIs. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the method as far as the RPC machinery is concerned.
+
+// ListUnhealthyEntities isn't on the V4 API.
+func (m *ModelManagerAPIV4) ListUnhealthyEntities(_, _ struct{}) {}
+
+// ListUnhealthyEntities isn't on the V3 API.
+func (m *ModelManagerAPIV3) ListUnhealthyEntities(_, _ struct{}) {}
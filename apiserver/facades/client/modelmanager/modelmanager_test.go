@@ -433,6 +433,7 @@ func (s *modelManagerSuite) TestCreateCAASModelArgs(c *gc.C) {
 		"ControllerTag",
 		"Cloud",
 		"CloudCredential",
+		"ControllerConfig",
 		"NewModel",
 		"Close",
 		"GetBackend",
@@ -480,6 +481,29 @@ func (s *modelManagerSuite) TestCreateCAASModelArgs(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestCreateCAASModelConfigPassThrough(c *gc.C) {
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Config: map[string]interface{}{
+			"preserve-namespace": true,
+			"logging-config":     "<root>=TRACE",
+			"default-series":     "bionic",
+		},
+		CloudTag:           "cloud-k8s-cloud",
+		CloudCredentialTag: "cloudcred-k8s-cloud_admin_some-credential",
+	}
+	_, err := s.caasApi.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the namespace attributes are taken from the supplied config.
+	newModelArgs := getModelArgsFor(c, s.caasSt)
+	attrs := newModelArgs.Config.AllAttrs()
+	c.Assert(attrs["preserve-namespace"], gc.Equals, true)
+	c.Assert(attrs["logging-config"], gc.Not(gc.Equals), "<root>=TRACE")
+	c.Assert(newModelArgs.Config.DefaultSeries(), gc.Not(gc.Equals), "bionic")
+}
+
 func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
 	result, err := s.api.ModelDefaults()
 	c.Assert(err, jc.ErrorIsNil)
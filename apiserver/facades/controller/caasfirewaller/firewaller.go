@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
+// Facade implements the latest version (v2) of the CAAS firewaller API.
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
@@ -31,6 +32,29 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	)
 }
 
+// FacadeV1 is the v1 CAAS firewaller API, which lacks RelatedApplications.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for facade
+// registration of the v1 API.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
+// Mask the RelatedApplications method from the v1 API. The API
+// reflection code in rpc/rpcreflect/type.go:newMethod skips 2-argument
+// methods, so this removes the method as far as the RPC machinery is
+// concerned.
+
+// RelatedApplications isn't on the v1 API.
+func (f *FacadeV1) RelatedApplications(_, _ struct{}) {}
+
 // NewFacade returns a new CAAS firewaller Facade facade.
 func NewFacade(
 	resources facade.Resources,
@@ -123,3 +147,32 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// RelatedApplications returns the names of the applications each of the
+// specified applications is related to.
+func (f *Facade) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *Facade) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.RelatedApplications()
+}
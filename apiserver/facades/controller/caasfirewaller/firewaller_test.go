@@ -123,6 +123,29 @@ func (s *CAASFirewallerSuite) TestIsExposed(c *gc.C) {
 	})
 }
 
+func (s *CAASFirewallerSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mysql", "haproxy"}
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mysql", "haproxy"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCallNames(c, "Application")
+	s.st.CheckCall(c, 0, "Application", "gitlab")
+	s.st.application.CheckCallNames(c, "RelatedApplications")
+}
+
 func (s *CAASFirewallerSuite) TestLife(c *gc.C) {
 	results, err := s.facade.Life(params.Entities{
 		Entities: []params.Entity{
@@ -45,6 +45,7 @@ type mockApplication struct {
 	testing.Stub
 	life    state.Life
 	exposed bool
+	related []string
 	watcher state.NotifyWatcher
 }
 
@@ -70,3 +71,8 @@ func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, err
 func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
@@ -4,6 +4,8 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/application"
@@ -24,6 +26,7 @@ type Application interface {
 	IsExposed() bool
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
+	RelatedApplications() ([]string, error)
 }
 
 type stateShim struct {
@@ -31,5 +34,41 @@ type stateShim struct {
 }
 
 func (s stateShim) Application(id string) (Application, error) {
-	return s.State.Application(id)
+	app, err := s.State.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// RelatedApplications returns the names of the live applications
+// the application is related to, excluding peer relations.
+func (a applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []string
+	seen := set.NewStrings()
+	for _, rel := range relations {
+		if rel.Life() != state.Alive {
+			continue
+		}
+		endpoints, err := rel.RelatedEndpoints(a.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range endpoints {
+			if ep.ApplicationName == a.Name() || seen.Contains(ep.ApplicationName) {
+				continue
+			}
+			seen.Add(ep.ApplicationName)
+			result = append(result, ep.ApplicationName)
+		}
+	}
+	return result, nil
 }
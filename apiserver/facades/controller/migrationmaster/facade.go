@@ -6,11 +6,9 @@ package migrationmaster
 import (
 	"encoding/json"
 
-	"github.com/juju/collections/set"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/naturalsort"
-	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -18,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -202,10 +201,14 @@ func (api *API) Export() (params.SerializedModel, error) {
 	if err != nil {
 		return serialized, err
 	}
+	resources, err := getUsedResources(model)
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
 	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
+	serialized.Charms = migration.UsedCharms(model)
 	serialized.Tools = getUsedTools(model)
-	serialized.Resources = getUsedResources(model)
+	serialized.Resources = resources
 	return serialized, nil
 }
 
@@ -297,96 +300,54 @@ func (api *API) MinionReports() (params.MinionReports, error) {
 	return out, nil
 }
 
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
 func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
+	used := migration.UsedTools(model)
+	out := make([]params.SerializedModelTools, 0, len(used))
+	for v, uri := range used {
 		out = append(out, params.SerializedModelTools{
 			Version: v.String(),
-			URI:     common.ToolsURL("", v),
+			URI:     uri,
 		})
 	}
 	return out
 }
 
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
-
-func getUsedResources(model description.Model) []params.SerializedModelResource {
-	var out []params.SerializedModelResource
-	for _, app := range model.Applications() {
-		for _, resource := range app.Resources() {
-			outRes := resourceToSerialized(app.Name(), resource)
-
-			// Hunt through the application's units and look for
-			// revisions of this resource. This is particularly
-			// efficient or clever but will be fine even with 1000's
-			// of units and 10's of resources.
-			outRes.UnitRevisions = make(map[string]params.SerializedModelResourceRevision)
-			for _, unit := range app.Units() {
-				for _, unitResource := range unit.Resources() {
-					if unitResource.Name() == resource.Name() {
-						outRes.UnitRevisions[unit.Name()] = revisionToSerialized(unitResource.Revision())
-					}
-				}
-			}
-
-			out = append(out, outRes)
+func getUsedResources(model description.Model) ([]params.SerializedModelResource, error) {
+	used, err := migration.UsedResources(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]params.SerializedModelResource, 0, len(used))
+	for _, res := range used {
+		outRes := params.SerializedModelResource{
+			Application:         res.ApplicationRevision.ApplicationID,
+			Name:                res.ApplicationRevision.Name,
+			ApplicationRevision: revisionToSerialized(res.ApplicationRevision),
+			CharmStoreRevision:  revisionToSerialized(res.CharmStoreRevision),
+			UnitRevisions:       make(map[string]params.SerializedModelResourceRevision),
 		}
-
-	}
-	return out
-}
-
-func resourceToSerialized(app string, desc description.Resource) params.SerializedModelResource {
-	return params.SerializedModelResource{
-		Application:         app,
-		Name:                desc.Name(),
-		ApplicationRevision: revisionToSerialized(desc.ApplicationRevision()),
-		CharmStoreRevision:  revisionToSerialized(desc.CharmStoreRevision()),
+		for unitName, unitRev := range res.UnitRevisions {
+			outRes.UnitRevisions[unitName] = revisionToSerialized(unitRev)
+		}
+		out = append(out, outRes)
 	}
+	return out, nil
 }
 
-func revisionToSerialized(rr description.ResourceRevision) params.SerializedModelResourceRevision {
-	if rr == nil {
-		return params.SerializedModelResourceRevision{}
+func revisionToSerialized(res resource.Resource) params.SerializedModelResourceRevision {
+	var fingerprint string
+	if !res.Fingerprint.IsZero() {
+		fingerprint = res.Fingerprint.Hex()
 	}
 	return params.SerializedModelResourceRevision{
-		Revision:       rr.Revision(),
-		Type:           rr.Type(),
-		Path:           rr.Path(),
-		Description:    rr.Description(),
-		Origin:         rr.Origin(),
-		FingerprintHex: rr.FingerprintHex(),
-		Size:           rr.Size(),
-		Timestamp:      rr.Timestamp(),
-		Username:       rr.Username(),
+		Revision:       res.Revision,
+		Type:           res.Type.String(),
+		Path:           res.Path,
+		Description:    res.Description,
+		Origin:         res.Origin.String(),
+		FingerprintHex: fingerprint,
+		Size:           res.Size,
+		Timestamp:      res.Timestamp,
+		Username:       res.Username,
 	}
 }
@@ -5,6 +5,7 @@ package migrationmaster_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/description"
@@ -208,7 +209,7 @@ func (s *Suite) TestExport(c *gc.C) {
 		Path:           "bin.tar.gz",
 		Description:    "who knows",
 		Origin:         "upload",
-		FingerprintHex: "abcd",
+		FingerprintHex: strings.Repeat("ab", 48),
 		Size:           123,
 		Timestamp:      time.Now(),
 		Username:       "bob",
@@ -219,7 +220,7 @@ func (s *Suite) TestExport(c *gc.C) {
 		Path:           "fink.tar.gz",
 		Description:    "knows who",
 		Origin:         "store",
-		FingerprintHex: "deaf",
+		FingerprintHex: strings.Repeat("de", 48),
 		Size:           321,
 		Timestamp:      time.Now(),
 		Username:       "xena",
@@ -239,7 +240,7 @@ func (s *Suite) TestExport(c *gc.C) {
 			Path:           "bin.tar.gz",
 			Description:    "nose knows",
 			Origin:         "upload",
-			FingerprintHex: "beef",
+			FingerprintHex: strings.Repeat("be", 48),
 			Size:           222,
 			Timestamp:      time.Now(),
 			Username:       "bambam",
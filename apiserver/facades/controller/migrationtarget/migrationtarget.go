@@ -35,11 +35,40 @@ type API struct {
 	callContext context.ProviderCallContext
 }
 
+// APIV2 implements the v2 MigrationTarget API, which has no
+// ImportRebuild.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements the v1 MigrationTarget API, which has no DryRun.
+type APIV1 struct {
+	*APIV2
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New), state.CallContext(ctx.State()))
 }
 
+// NewFacadeV2 is used for API registration of the v2 API.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// NewFacadeV1 is used for API registration of the v1 API.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -76,7 +105,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := migrationModelInfo(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -92,15 +121,60 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	return migration.TargetPrecheck(
 		backend,
 		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+}
+
+// DryRun reports every reason the target controller would not accept
+// the migration of the model: the failures of the prechecks, and any
+// reason the serialized model would not import. Nothing is imported.
+func (api *API) DryRun(args params.MigrationDryRunArgs) (params.ErrorResults, error) {
+	modelInfo, err := migrationModelInfo(args.ModelInfo)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	controllerState := api.pool.SystemState()
+	backend, err := migration.PrecheckShim(api.state, controllerState)
+	if err != nil {
+		return params.ErrorResults{}, errors.Annotate(err, "creating backend")
+	}
+	problems, err := migration.TargetPrecheckAll(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	importProblems, err := migration.CheckImport(controllerState, args.Bytes)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	problems = append(problems, importProblems...)
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(problems)),
+	}
+	for i, problem := range problems {
+		results.Results[i].Error = common.ServerError(problem)
+	}
+	return results, nil
+}
+
+func migrationModelInfo(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -117,6 +191,23 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ImportRebuild takes a serialized Juju model, deserializes it, and
+// recreates it in the receiving controller to be rebuilt on the
+// controller's cloud: the model's machines are provisioned afresh
+// rather than being taken over from the source cloud. As with Import,
+// the model is left importing until it is activated.
+func (api *API) ImportRebuild(args params.RebuildImportArgs) error {
+	_, st, err := migration.ImportRebuiltModel(api.state, args.Bytes, migration.RebuildArgs{
+		CloudRegion:     args.CloudRegion,
+		CloudCredential: args.CloudCredential,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st.Close()
+	return nil
+}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
@@ -270,3 +361,9 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// ImportRebuild isn't on the v2 API.
+func (*APIV2) ImportRebuild(_, _ struct{}) {}
+
+// DryRun isn't on the v1 API.
+func (*APIV1) DryRun(_, _ struct{}) {}
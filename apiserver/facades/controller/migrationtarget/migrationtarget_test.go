@@ -71,6 +71,28 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -128,6 +150,59 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestDryRun(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	results, err := api.DryRun(params.MigrationDryRunArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 0)
+
+	// Nothing was imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestDryRunReportsAllProblems(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	results, err := api.DryRun(params.MigrationDryRunArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   s.State.ModelUUID(),
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           modelVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "model has higher version than target controller .*")
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "model with same UUID already exists .*")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "model description not valid: .*")
+}
+
+func (s *Suite) TestDryRunBadOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.DryRun(params.MigrationDryRunArgs{
+		ModelInfo: params.MigrationModelInfo{OwnerTag: "not-a-tag"},
+	})
+	c.Assert(err, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -139,6 +214,45 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportRebuild(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	uuid, bytes := s.makeExportedModel(c)
+
+	api := s.mustNewAPI(c)
+	err := api.ImportRebuild(params.RebuildImportArgs{
+		Bytes:       bytes,
+		CloudRegion: "nether-region",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	c.Check(model.Name(), gc.Equals, "some-model")
+	c.Check(model.CloudRegion(), gc.Equals, "nether-region")
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	machines, err := st.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	_, err = machines[0].InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *Suite) TestImportRebuildBadRegion(c *gc.C) {
+	_, bytes := s.makeExportedModel(c)
+
+	api := s.mustNewAPI(c)
+	err := api.ImportRebuild(params.RebuildImportArgs{
+		Bytes:       bytes,
+		CloudRegion: "nowhere",
+	})
+	c.Assert(err, gc.ErrorMatches, `region "nowhere" not found .*`)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
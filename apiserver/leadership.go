@@ -65,3 +65,36 @@ func (m leadershipClaimer) BlockUntilLeadershipReleased(applicationname string,
 	}
 	return errors.Trace(err)
 }
+
+// leadershipPinner implements leadership.Pinner by wrapping a lease.Pinner.
+type leadershipPinner struct {
+	pinner lease.Pinner
+}
+
+// PinLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) PinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.Pin(applicationname, entity))
+}
+
+// UnpinLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) UnpinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.Unpin(applicationname, entity))
+}
+
+// leadershipTransferrer implements leadership.Transferrer by wrapping
+// a lease.Transferrer.
+type leadershipTransferrer struct {
+	transferrer lease.Transferrer
+}
+
+// TransferLeadership is part of the leadership.Transferrer interface.
+func (m leadershipTransferrer) TransferLeadership(applicationname, unitName string, duration time.Duration) error {
+	err := m.transferrer.Transfer(applicationname, unitName, duration)
+	switch errors.Cause(err) {
+	case lease.ErrPinned:
+		return leadership.ErrPinned
+	case lease.ErrClaimDenied:
+		return leadership.ErrClaimDenied
+	}
+	return errors.Trace(err)
+}
@@ -14,6 +14,14 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+	Storage    string `json:"storage,omitempty"`
+
+	// Passphrase, if set, is used to encrypt the backup archive.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PublicKey, if set, is an armored OpenPGP public key
+	// used to encrypt the backup archive.
+	PublicKey string `json:"public-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -64,6 +72,7 @@ type BackupsMetadataResult struct {
 	Started  time.Time      `json:"started"`
 	Finished time.Time      `json:"finished"` // May be zero...
 	Notes    string         `json:"notes"`
+	Storage  string         `json:"storage,omitempty"`
 	Model    string         `json:"model"`
 	Machine  string         `json:"machine"`
 	Hostname string         `json:"hostname"`
@@ -73,10 +82,60 @@ type BackupsMetadataResult struct {
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 	Filename     string `json:"filename"`
+	Encrypted    bool   `json:"encrypted,omitempty"`
+	Scheduled    bool   `json:"scheduled,omitempty"`
 }
 
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// DecryptionKey holds the key needed to decrypt
+	// an encrypted backup.
+	DecryptionKey *BackupDecryptionKey `json:"decryption-key,omitempty"`
+}
+
+// BackupDecryptionKey holds what is needed to decrypt an encrypted
+// backup archive.
+type BackupDecryptionKey struct {
+	// Passphrase is the passphrase the archive was encrypted with,
+	// or the passphrase protecting the private key.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PrivateKey is an armored OpenPGP private key
+	// the archive was encrypted for.
+	PrivateKey string `json:"private-key,omitempty"`
+}
+
+// RestoreModelArgs holds the arguments for restoring a single model
+// from a controller backup.
+type RestoreModelArgs struct {
+	// BackupId holds the id of the backup in the server.
+	BackupId string `json:"backup-id"`
+
+	// ModelUUID identifies the model in the backup to restore.
+	ModelUUID string `json:"model-uuid"`
+
+	// NewName, if set, restores the model as a new model with
+	// this name and a new UUID, alongside any existing model.
+	NewName string `json:"new-name,omitempty"`
+
+	// Replace, if set, replaces the existing model with the
+	// same UUID.
+	Replace bool `json:"replace,omitempty"`
+
+	// DecryptionKey holds the key needed to decrypt
+	// an encrypted backup.
+	DecryptionKey *BackupDecryptionKey `json:"decryption-key,omitempty"`
+}
+
+// RestoreModelResult holds the details of a model restored from a
+// controller backup.
+type RestoreModelResult struct {
+	// ModelUUID is the UUID of the restored model.
+	ModelUUID string `json:"model-uuid"`
+
+	// Name is the name of the restored model.
+	Name string `json:"name"`
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -96,6 +98,16 @@ type ControllerConfigSet struct {
 	Config map[string]interface{} `json:"config"`
 }
 
+// ControllerBackupStatus holds the result of Controller.BackupStatus.
+type ControllerBackupStatus struct {
+	// LastSuccessful is when the most recent backup of the
+	// controller finished, if there is one.
+	LastSuccessful *time.Time `json:"last-successful,omitempty"`
+
+	// LastSuccessfulID is the ID of that backup.
+	LastSuccessfulID string `json:"last-successful-id,omitempty"`
+}
+
 // ControllerAction is an action that can be performed on a model.
 type ControllerAction string
 
@@ -52,6 +52,102 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults holds the results of checking whether a
+// collection of model migrations would succeed.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult holds every problem that would prevent the
+// migration of a single model. Error is set if the checks could not
+// be made at all.
+type MigrationDryRunResult struct {
+	ModelTag string   `json:"model-tag"`
+	Problems []string `json:"problems,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// MigrationProgressArgs identifies the model migrations to report the
+// progress of.
+type MigrationProgressArgs struct {
+	MigrationIds []string `json:"migration-ids"`
+}
+
+// MigrationProgressResults holds the progress of a collection of model
+// migrations.
+type MigrationProgressResults struct {
+	Results []MigrationProgressResult `json:"results"`
+}
+
+// MigrationProgressResult holds the progress of a single model
+// migration, as reported by the migrationmaster worker.
+type MigrationProgressResult struct {
+	MigrationId      string    `json:"migration-id"`
+	ModelTag         string    `json:"model-tag,omitempty"`
+	Phase            string    `json:"phase,omitempty"`
+	PhaseChangedTime time.Time `json:"phase-changed-time,omitempty"`
+	StatusMessage    string    `json:"status-message,omitempty"`
+	Error            *Error    `json:"error,omitempty"`
+}
+
+// RebuildModelArgs holds the details of models to be rebuilt on
+// other controllers.
+type RebuildModelArgs struct {
+	Specs []RebuildModelSpec `json:"specs"`
+}
+
+// RebuildModelSpec holds the details required to rebuild a single
+// model on another controller's cloud.
+type RebuildModelSpec struct {
+	ModelTag        string              `json:"model-tag"`
+	TargetInfo      MigrationTargetInfo `json:"target-info"`
+	CloudRegion     string              `json:"cloud-region,omitempty"`
+	CloudCredential string              `json:"cloud-credential,omitempty"`
+}
+
+// RebuildModelResults holds the results of starting model rebuilds.
+type RebuildModelResults struct {
+	Results []RebuildModelResult `json:"results"`
+}
+
+// RebuildModelResult holds the ID of a model rebuild that has been
+// started, or the error that stopped it from starting.
+type RebuildModelResult struct {
+	RebuildId string `json:"rebuild-id,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// RebuildProgressArgs identifies model rebuilds to report on.
+type RebuildProgressArgs struct {
+	RebuildIds []string `json:"rebuild-ids"`
+}
+
+// RebuildProgressResults holds the progress of model rebuilds.
+type RebuildProgressResults struct {
+	Results []RebuildProgressResult `json:"results"`
+}
+
+// RebuildProgressResult reports the progress of a single model
+// rebuild. ModelTag identifies the rebuilt model on the target
+// controller, once it is known. Failure holds the error that stopped
+// a finished rebuild, and Error any error reading its progress.
+type RebuildProgressResult struct {
+	RebuildId     string `json:"rebuild-id"`
+	ModelTag      string `json:"model-tag,omitempty"`
+	StatusMessage string `json:"status-message,omitempty"`
+	Done          bool   `json:"done"`
+	Failure       string `json:"failure,omitempty"`
+	Error         *Error `json:"error,omitempty"`
+}
+
+// RebuildImportArgs holds a serialized model for the target
+// controller to import and rebuild on its cloud.
+type RebuildImportArgs struct {
+	Bytes           []byte `json:"bytes"`
+	CloudRegion     string `json:"cloud-region,omitempty"`
+	CloudCredential string `json:"cloud-credential,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -134,6 +230,14 @@ type MigrationModelInfo struct {
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
 }
 
+// MigrationDryRunArgs holds the details of a model that the target
+// controller of a migration should check it would accept, without
+// importing it.
+type MigrationDryRunArgs struct {
+	ModelInfo MigrationModelInfo `json:"model-info"`
+	Bytes     []byte             `json:"bytes"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
 	MigrationId string `json:"migration-id"`
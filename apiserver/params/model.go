@@ -250,6 +250,22 @@ type ModelSummariesRequest struct {
 	All     bool   `json:"all,omitempty"`
 }
 
+// UnhealthyEntity describes a status of a machine or unit, in one
+// of the controller's models, that needs attention.
+type UnhealthyEntity struct {
+	ModelUUID     string       `json:"model-uuid"`
+	ModelName     string       `json:"model-name"`
+	ModelOwnerTag string       `json:"model-owner-tag"`
+	Tag           string       `json:"tag"`
+	Kind          string       `json:"kind"`
+	Status        EntityStatus `json:"status"`
+}
+
+// UnhealthyEntities holds the result of a ListUnhealthyEntities call.
+type UnhealthyEntities struct {
+	Entities []UnhealthyEntity `json:"entities"`
+}
+
 // ModelInfoResult holds the result of a ModelInfo call.
 type ModelInfoResult struct {
 	Result *ModelInfo `json:"result,omitempty"`
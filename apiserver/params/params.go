@@ -1162,6 +1162,48 @@ type ResumeReplicationParams struct {
 	Members []replicaset.Member `json:"members"`
 }
 
+// RemoveControllersArgs holds the arguments for the RemoveControllers
+// API call, which decommissions controller machines.
+type RemoveControllersArgs struct {
+	// Entities holds the tags of the controller machines to remove.
+	Entities []Entity `json:"entities"`
+
+	// NoReplacement requests that no other controller machine is
+	// promoted or added to take the vote of a removed machine, so
+	// that the controller shrinks.
+	NoReplacement bool `json:"no-replacement,omitempty"`
+}
+
+// RehearseFailoverArgs holds the arguments for the RehearseFailover
+// API call, which demotes the current mongo primary and raft leader
+// and waits for the controllers to recover.
+type RehearseFailoverArgs struct {
+	// Mongo requests that the mongo primary steps down.
+	Mongo bool `json:"mongo"`
+
+	// Raft requests that the raft leader steps down.
+	Raft bool `json:"raft"`
+
+	// Timeout is how long to wait for each service to recover.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// FailoverResult reports how a single service recovered from its
+// leader being demoted.
+type FailoverResult struct {
+	Service      string        `json:"service"`
+	OldLeader    string        `json:"old-leader,omitempty"`
+	NewLeader    string        `json:"new-leader,omitempty"`
+	RecoveryTime time.Duration `json:"recovery-time"`
+	Error        *Error        `json:"error,omitempty"`
+}
+
+// RehearseFailoverResults holds the results of the RehearseFailover
+// API call, mongo first, then raft.
+type RehearseFailoverResults struct {
+	Results []FailoverResult `json:"results"`
+}
+
 // MeterStatusParam holds meter status information to be set for the specified tag.
 type MeterStatusParam struct {
 	Tag  string `json:"tag"`
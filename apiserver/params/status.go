@@ -16,6 +16,18 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// WorkloadStatuses, if set, restricts the units reported
+	// to those with one of the given workload statuses.
+	WorkloadStatuses []string `json:"workload-statuses,omitempty"`
+
+	// AgentStatuses, if set, restricts the units reported
+	// to those with one of the given agent statuses.
+	AgentStatuses []string `json:"agent-statuses,omitempty"`
+
+	// Message, if set, restricts the units reported to those
+	// with a workload or agent status message containing it.
+	Message string `json:"message,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -260,6 +272,40 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// StatusTimelineRequest holds the parameters for a model status
+// timeline query.
+type StatusTimelineRequest struct {
+	// From, if set, excludes entries recorded before it.
+	From *time.Time `json:"from,omitempty"`
+
+	// To, if set, excludes entries recorded after it.
+	To *time.Time `json:"to,omitempty"`
+
+	// Kinds, if not empty, restricts the entries to the given
+	// status history kinds.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Exclude holds status messages to exclude from the timeline.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Size, if non-zero, restricts the timeline to the most
+	// recent entries.
+	Size int `json:"size,omitempty"`
+}
+
+// StatusTimelineEntry holds a status history entry of an
+// application, machine or unit in a model status timeline.
+type StatusTimelineEntry struct {
+	Tag    string         `json:"tag"`
+	Status DetailedStatus `json:"status"`
+}
+
+// StatusTimeline holds the entries of a model status timeline,
+// oldest first.
+type StatusTimeline struct {
+	Entries []StatusTimelineEntry `json:"entries"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
@@ -93,9 +94,15 @@ type Broker interface {
 	// Provider returns the ContainerEnvironProvider that created this Broker.
 	Provider() ContainerEnvironProvider
 
+	// Create prepares this broker's namespace for use by a new model.
+	Create(context.ProviderCallContext, environs.CreateParams) error
+
 	// Destroy terminates all containers and other resources in this broker's namespace.
 	Destroy(context.ProviderCallContext) error
 
+	// SetConfig updates the model config used by this broker.
+	SetConfig(cfg *config.Config) error
+
 	// EnsureNamespace ensures this broker's namespace is created.
 	EnsureNamespace() error
 
@@ -124,6 +131,14 @@ type Broker interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureNetworkPolicy creates or updates the network policy
+	// restricting ingress to the pods of the specified application.
+	EnsureNetworkPolicy(appName string, params NetworkPolicyParams) error
+
+	// DeleteNetworkPolicy deletes the network policy for the
+	// specified application.
+	DeleteNetworkPolicy(appName string) error
+
 	// WatchUnits returns a watcher which notifies when there
 	// are changes to units of the specified application.
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
@@ -137,6 +152,18 @@ type Broker interface {
 	storage.ProviderRegistry
 }
 
+// NetworkPolicyParams defines the parameters used to restrict
+// ingress to the pods of an application.
+type NetworkPolicyParams struct {
+	// RelatedApplications are the applications whose pods
+	// may connect to the application's pods.
+	RelatedApplications []string
+
+	// Exposed is true if the application's service ports
+	// should be reachable from any source.
+	Exposed bool
+}
+
 // Service represents information about the status of a caas service entity.
 type Service struct {
 	Id        string
@@ -61,6 +61,55 @@ type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+	ServiceAccount            *ServiceAccountSpec        `yaml:"serviceAccount,omitempty"`
+}
+
+// PolicyRule defines a rule granting access to resources
+// in the model's namespace on the CAAS substrate.
+type PolicyRule struct {
+	Verbs         []string `yaml:"verbs"`
+	APIGroups     []string `yaml:"apiGroups,omitempty"`
+	Resources     []string `yaml:"resources"`
+	ResourceNames []string `yaml:"resourceNames,omitempty"`
+}
+
+// Validate returns an error if the rule is not valid.
+func (r *PolicyRule) Validate() error {
+	if len(r.Verbs) == 0 {
+		return errors.NotValidf("rule with no verbs")
+	}
+	if len(r.Resources) == 0 {
+		return errors.NotValidf("rule with no resources")
+	}
+	return nil
+}
+
+// ServiceAccountSpec defines the service account the workload pods
+// run as, and the namespace scoped rules it is granted.
+type ServiceAccountSpec struct {
+	// Name is the name of the service account. If not specified,
+	// a name is derived from the application name.
+	Name string `yaml:"name,omitempty"`
+
+	// AutomountServiceAccountToken indicates whether the service
+	// account credentials are mounted into the workload pods.
+	AutomountServiceAccountToken *bool `yaml:"automountServiceAccountToken,omitempty"`
+
+	// Rules are the namespace scoped rules granted to the service account.
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// Validate returns an error if the service account spec is not valid.
+func (sa *ServiceAccountSpec) Validate() error {
+	if len(sa.Rules) == 0 {
+		return errors.NotValidf("service account with no rules")
+	}
+	for _, r := range sa.Rules {
+		if err := r.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -106,6 +155,9 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ServiceAccount != nil {
+		return spec.ServiceAccount.Validate()
+	}
 	return nil
 }
 
@@ -0,0 +1,469 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/storage"
+)
+
+// Operator records the parameters an operator was last ensured with.
+type Operator struct {
+	AgentPath string
+	Config    caas.OperatorConfig
+}
+
+// Service records the state of an application's service
+// and the units running it.
+type Service struct {
+	Id      string
+	Params  caas.ServiceParams
+	Config  application.ConfigAttributes
+	Exposed bool
+
+	// ExposedConfig is the config passed to the
+	// most recent call to ExposeService.
+	ExposedConfig application.ConfigAttributes
+
+	// CustomResourceDefinitions holds the custom resource
+	// definitions ensured for the application.
+	CustomResourceDefinitions []caas.CustomResourceDefinition
+
+	Units []caas.Unit
+}
+
+type namespaceState struct {
+	mu          sync.Mutex
+	created     bool
+	maxUnitId   int
+	maxAddr     int
+	operators   map[string]Operator
+	services    map[string]*Service
+	policies    map[string]caas.NetworkPolicyParams
+	unitWatches map[string][]chan struct{}
+}
+
+func newNamespaceState() *namespaceState {
+	return &namespaceState{
+		operators:   make(map[string]Operator),
+		services:    make(map[string]*Service),
+		policies:    make(map[string]caas.NetworkPolicyParams),
+		unitWatches: make(map[string][]chan struct{}),
+	}
+}
+
+func (st *namespaceState) isCreated() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.created
+}
+
+// destroy removes everything in the namespace. Unit watchers
+// are notified, since all units have gone away.
+func (st *namespaceState) destroy() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.created = false
+	st.operators = make(map[string]Operator)
+	for appName := range st.services {
+		st.notifyUnitsLocked(appName)
+	}
+	st.services = make(map[string]*Service)
+	st.policies = make(map[string]caas.NetworkPolicyParams)
+}
+
+// notifyUnitsLocked sends a change to the unit watchers of the
+// specified application. Pending changes are coalesced, so this
+// never blocks. The caller must hold st.mu.
+func (st *namespaceState) notifyUnitsLocked(appName string) {
+	for _, ch := range st.unitWatches[appName] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// removeUnitWatch stops the specified channel being notified
+// of changes to the units of the specified application.
+func (st *namespaceState) removeUnitWatch(appName string, ch chan struct{}) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	watches := st.unitWatches[appName]
+	for i, w := range watches {
+		if w == ch {
+			watches = append(watches[:i], watches[i+1:]...)
+			break
+		}
+	}
+	if len(watches) == 0 {
+		delete(st.unitWatches, appName)
+		return
+	}
+	st.unitWatches[appName] = watches
+}
+
+// Broker is an in-memory implementation of caas.Broker.
+type Broker struct {
+	storage.ProviderRegistry
+	namespace string
+	state     *namespaceState
+}
+
+var _ caas.Broker = (*Broker)(nil)
+
+// Provider is part of the caas.Broker interface.
+func (*Broker) Provider() caas.ContainerEnvironProvider {
+	return &dummy
+}
+
+// Create is part of the caas.Broker interface.
+func (b *Broker) Create(context.ProviderCallContext, environs.CreateParams) error {
+	return nil
+}
+
+// Destroy is part of the caas.Broker interface.
+func (b *Broker) Destroy(context.ProviderCallContext) error {
+	logger.Debugf("destroying namespace %q", b.namespace)
+	b.state.destroy()
+	return nil
+}
+
+// SetConfig is part of the caas.Broker interface.
+func (b *Broker) SetConfig(cfg *config.Config) error {
+	return nil
+}
+
+// EnsureNamespace is part of the caas.Broker interface.
+func (b *Broker) EnsureNamespace() error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	b.state.created = true
+	return nil
+}
+
+// EnsureOperator is part of the caas.Broker interface.
+func (b *Broker) EnsureOperator(appName, agentPath string, config *caas.OperatorConfig) error {
+	if config == nil {
+		return errors.NotValidf("missing operator config")
+	}
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	b.state.created = true
+	b.state.operators[appName] = Operator{
+		AgentPath: agentPath,
+		Config:    *config,
+	}
+	return nil
+}
+
+// DeleteOperator is part of the caas.Broker interface.
+func (b *Broker) DeleteOperator(appName string) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	delete(b.state.operators, appName)
+	return nil
+}
+
+// EnsureService is part of the caas.Broker interface. Units are
+// added or removed to match numUnits, and the application's unit
+// watchers are notified of any change.
+func (b *Broker) EnsureService(
+	appName string, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes,
+) error {
+	if numUnits < 0 {
+		return errors.Errorf("number of units must be >= 0")
+	}
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if numUnits == 0 {
+		if ok && len(svc.Units) > 0 {
+			svc.Units = nil
+			b.state.notifyUnitsLocked(appName)
+		}
+		return nil
+	}
+	if params == nil || params.PodSpec == nil {
+		return errors.Errorf("missing pod spec")
+	}
+	if !ok {
+		svc = &Service{Id: utils.MustNewUUID().String()}
+		b.state.services[appName] = svc
+	}
+	b.state.created = true
+	svc.Params = *params
+	svc.Config = config
+
+	changed := false
+	for len(svc.Units) < numUnits {
+		svc.Units = append(svc.Units, b.newUnitLocked(appName, params))
+		changed = true
+	}
+	if len(svc.Units) > numUnits {
+		svc.Units = svc.Units[:numUnits]
+		changed = true
+	}
+	if changed {
+		b.state.notifyUnitsLocked(appName)
+	}
+	return nil
+}
+
+// newUnitLocked returns a new running unit for the application,
+// with a filesystem attached for each of the requested filesystems.
+// The caller must hold b.state.mu.
+func (b *Broker) newUnitLocked(appName string, params *caas.ServiceParams) caas.Unit {
+	id := b.state.maxUnitId
+	b.state.maxUnitId++
+	b.state.maxAddr++
+	now := time.Now()
+
+	var ports []string
+	for _, c := range params.PodSpec.Containers {
+		for _, p := range c.Ports {
+			ports = append(ports, fmt.Sprintf("%v/%v", p.ContainerPort, p.Protocol))
+		}
+	}
+	unit := caas.Unit{
+		Id:      fmt.Sprintf("%s-%d", appName, id),
+		Address: fmt.Sprintf("10.0.%d.%d", b.state.maxAddr/256, b.state.maxAddr%256),
+		Ports:   ports,
+		Status: status.StatusInfo{
+			Status: status.Running,
+			Since:  &now,
+		},
+	}
+	attached := status.StatusInfo{Status: status.Attached, Since: &now}
+	for i, fs := range params.Filesystems {
+		info := caas.FilesystemInfo{
+			StorageName:  fs.StorageName,
+			FilesystemId: fmt.Sprintf("%s-%d-%d", fs.StorageName, id, i),
+			Size:         fs.Size,
+			Status:       attached,
+			Volume: caas.VolumeInfo{
+				VolumeId:   fmt.Sprintf("volume-%s-%d-%d", fs.StorageName, id, i),
+				Size:       fs.Size,
+				Persistent: true,
+				Status:     attached,
+			},
+		}
+		if fs.Attachment != nil {
+			info.MountPoint = fs.Attachment.Path
+			info.ReadOnly = fs.Attachment.ReadOnly
+		}
+		unit.FilesystemInfo = append(unit.FilesystemInfo, info)
+	}
+	return unit
+}
+
+// EnsureCustomResourceDefinition is part of the caas.Broker interface.
+func (b *Broker) EnsureCustomResourceDefinition(appName string, podSpec *caas.PodSpec) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return errors.NotFoundf("service for %q", appName)
+	}
+	svc.CustomResourceDefinitions = podSpec.CustomResourceDefinitions
+	return nil
+}
+
+// Service is part of the caas.Broker interface.
+func (b *Broker) Service(appName string) (*caas.Service, error) {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return nil, errors.NotFoundf("service for %q", appName)
+	}
+	result := &caas.Service{
+		Id: svc.Id,
+		Addresses: []network.Address{
+			network.NewScopedAddress("10.1.0.1", network.ScopeCloudLocal),
+		},
+	}
+	if svc.Exposed {
+		result.Addresses = append(result.Addresses,
+			network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+		)
+	}
+	return result, nil
+}
+
+// DeleteService is part of the caas.Broker interface.
+func (b *Broker) DeleteService(appName string) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	if _, ok := b.state.services[appName]; !ok {
+		return nil
+	}
+	delete(b.state.services, appName)
+	b.state.notifyUnitsLocked(appName)
+	return nil
+}
+
+// ExposeService is part of the caas.Broker interface.
+func (b *Broker) ExposeService(appName string, config application.ConfigAttributes) error {
+	if config.GetString(caas.JujuExternalHostNameKey, "") == "" {
+		return errors.Errorf("external hostname required")
+	}
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return errors.NotFoundf("service for %q", appName)
+	}
+	svc.Exposed = true
+	svc.ExposedConfig = config
+	return nil
+}
+
+// UnexposeService is part of the caas.Broker interface.
+func (b *Broker) UnexposeService(appName string) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	if svc, ok := b.state.services[appName]; ok {
+		svc.Exposed = false
+		svc.ExposedConfig = nil
+	}
+	return nil
+}
+
+// EnsureNetworkPolicy is part of the caas.Broker interface.
+// Policies are recorded separately from services, since they
+// can be ensured before the service exists.
+func (b *Broker) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	b.state.policies[appName] = params
+	return nil
+}
+
+// DeleteNetworkPolicy is part of the caas.Broker interface.
+func (b *Broker) DeleteNetworkPolicy(appName string) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	delete(b.state.policies, appName)
+	return nil
+}
+
+// WatchUnits is part of the caas.Broker interface. The watcher
+// sends an initial event, and then one whenever the units of the
+// application are added, removed or changed.
+func (b *Broker) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	b.state.mu.Lock()
+	b.state.unitWatches[appName] = append(b.state.unitWatches[appName], ch)
+	b.state.mu.Unlock()
+	return newUnitsWatcher(ch, func() {
+		b.state.removeUnitWatch(appName, ch)
+	})
+}
+
+// Units is part of the caas.Broker interface.
+func (b *Broker) Units(appName string) ([]caas.Unit, error) {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return nil, nil
+	}
+	units := make([]caas.Unit, len(svc.Units))
+	copy(units, svc.Units)
+	return units, nil
+}
+
+// Operator returns the operator ensured for the specified application.
+func (b *Broker) Operator(appName string) (Operator, error) {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	op, ok := b.state.operators[appName]
+	if !ok {
+		return Operator{}, errors.NotFoundf("operator for %q", appName)
+	}
+	return op, nil
+}
+
+// ServiceState returns a copy of the recorded state of the service
+// for the specified application.
+func (b *Broker) ServiceState(appName string) (Service, error) {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return Service{}, errors.NotFoundf("service for %q", appName)
+	}
+	result := *svc
+	result.Units = make([]caas.Unit, len(svc.Units))
+	copy(result.Units, svc.Units)
+	return result, nil
+}
+
+// NetworkPolicy returns the most recently ensured network policy
+// for the specified application.
+func (b *Broker) NetworkPolicy(appName string) (caas.NetworkPolicyParams, error) {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	params, ok := b.state.policies[appName]
+	if !ok {
+		return caas.NetworkPolicyParams{}, errors.NotFoundf("network policy for %q", appName)
+	}
+	return params, nil
+}
+
+// SetUnitStatus sets the status of the specified unit, as if
+// its pod had changed state, and notifies the unit watchers.
+func (b *Broker) SetUnitStatus(appName, unitId string, info status.StatusInfo) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return errors.NotFoundf("service for %q", appName)
+	}
+	for i, u := range svc.Units {
+		if u.Id != unitId {
+			continue
+		}
+		svc.Units[i].Status = info
+		b.state.notifyUnitsLocked(appName)
+		return nil
+	}
+	return errors.NotFoundf("unit %q", unitId)
+}
+
+// RemoveUnit removes the specified unit, as if its pod had been
+// deleted outside of Juju, and notifies the unit watchers.
+func (b *Broker) RemoveUnit(appName, unitId string) error {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	svc, ok := b.state.services[appName]
+	if !ok {
+		return errors.NotFoundf("service for %q", appName)
+	}
+	for i, u := range svc.Units {
+		if u.Id != unitId {
+			continue
+		}
+		svc.Units = append(svc.Units[:i], svc.Units[i+1:]...)
+		b.state.notifyUnitsLocked(appName)
+		return nil
+	}
+	return errors.NotFoundf("unit %q", unitId)
+}
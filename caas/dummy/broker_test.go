@@ -0,0 +1,253 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/dummy"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type BrokerSuite struct {
+	testing.BaseSuite
+	broker *dummy.Broker
+}
+
+var _ = gc.Suite(&BrokerSuite{})
+
+var podSpec = &caas.PodSpec{
+	Containers: []caas.ContainerSpec{{
+		Name:  "gitlab",
+		Image: "gitlab/latest",
+		Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+	}},
+}
+
+func (s *BrokerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.AddCleanup(func(*gc.C) { dummy.Reset() })
+	s.broker = s.openBroker(c, "test")
+}
+
+func (s *BrokerSuite) openBroker(c *gc.C, name string) *dummy.Broker {
+	provider, err := environs.Provider(dummy.ProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	broker, err := caas.Open(provider, environs.OpenParams{
+		Config: testing.CustomModelConfig(c, testing.Attrs{"name": name}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return broker.(*dummy.Broker)
+}
+
+func (s *BrokerSuite) TestNamespaces(c *gc.C) {
+	c.Assert(dummy.Namespaces(), gc.HasLen, 0)
+	c.Assert(s.broker.EnsureNamespace(), jc.ErrorIsNil)
+	other := s.openBroker(c, "other")
+	c.Assert(other.EnsureNamespace(), jc.ErrorIsNil)
+	c.Assert(dummy.Namespaces(), jc.DeepEquals, []string{"other", "test"})
+
+	c.Assert(other.Destroy(context.NewCloudCallContext()), jc.ErrorIsNil)
+	c.Assert(dummy.Namespaces(), jc.DeepEquals, []string{"test"})
+}
+
+func (s *BrokerSuite) TestSharedState(c *gc.C) {
+	err := s.broker.EnsureOperator("gitlab", "/var/lib/juju", &caas.OperatorConfig{
+		OperatorImagePath: "jujud-operator",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err := s.openBroker(c, "test").Operator("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, dummy.Operator{
+		AgentPath: "/var/lib/juju",
+		Config:    caas.OperatorConfig{OperatorImagePath: "jujud-operator"},
+	})
+
+	c.Assert(s.broker.DeleteOperator("gitlab"), jc.ErrorIsNil)
+	_, err = s.broker.Operator("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BrokerSuite) TestWatchUnitsStopRemovesWatch(c *gc.C) {
+	w1, err := s.broker.WatchUnits("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	wc1 := watchertest.NewNotifyWatcherC(c, w1, nil)
+	wc1.AssertOneChange()
+	w2, err := s.broker.WatchUnits("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	wc2 := watchertest.NewNotifyWatcherC(c, w2, nil)
+	defer wc2.AssertStops()
+	wc2.AssertOneChange()
+	c.Assert(dummy.UnitWatchCount(s.broker, "gitlab"), gc.Equals, 2)
+
+	wc1.AssertStops()
+	c.Assert(dummy.UnitWatchCount(s.broker, "gitlab"), gc.Equals, 1)
+
+	// The remaining watcher is still notified.
+	params := &caas.ServiceParams{PodSpec: podSpec}
+	err = s.broker.EnsureService("gitlab", params, 1, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc2.AssertOneChange()
+}
+
+func (s *BrokerSuite) TestEnsureServiceScalesUnits(c *gc.C) {
+	w, err := s.broker.WatchUnits("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, nil)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	params := &caas.ServiceParams{PodSpec: podSpec}
+	err = s.broker.EnsureService("gitlab", params, 2, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units[0].Id, gc.Equals, "gitlab-0")
+	c.Assert(units[0].Ports, jc.DeepEquals, []string{"80/TCP"})
+	c.Assert(units[0].Status.Status, gc.Equals, status.Running)
+	c.Assert(units[1].Id, gc.Equals, "gitlab-1")
+
+	// Ensuring the same scale again does not change the units.
+	err = s.broker.EnsureService("gitlab", params, 2, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.broker.EnsureService("gitlab", params, 0, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	units, err = s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+}
+
+func (s *BrokerSuite) TestEnsureServiceMissingPodSpec(c *gc.C) {
+	err := s.broker.EnsureService("gitlab", &caas.ServiceParams{}, 1, nil)
+	c.Assert(err, gc.ErrorMatches, "missing pod spec")
+}
+
+func (s *BrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
+	params := &caas.ServiceParams{
+		PodSpec: podSpec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+		}},
+	}
+	err := s.broker.EnsureService("gitlab", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].FilesystemInfo, gc.HasLen, 1)
+	fs := units[0].FilesystemInfo[0]
+	c.Assert(fs.StorageName, gc.Equals, "database")
+	c.Assert(fs.Size, gc.Equals, uint64(100))
+	c.Assert(fs.MountPoint, gc.Equals, "path/to/here")
+	c.Assert(fs.Status.Status, gc.Equals, status.Attached)
+	c.Assert(fs.Volume.Persistent, jc.IsTrue)
+}
+
+func (s *BrokerSuite) TestSetUnitStatus(c *gc.C) {
+	err := s.broker.EnsureService("gitlab", &caas.ServiceParams{PodSpec: podSpec}, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := s.broker.WatchUnits("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, nil)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	err = s.broker.SetUnitStatus("gitlab", "gitlab-0", status.StatusInfo{
+		Status:  status.Error,
+		Message: "crash loop",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units[0].Status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "crash loop",
+	})
+
+	c.Assert(s.broker.RemoveUnit("gitlab", "gitlab-0"), jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = s.broker.RemoveUnit("gitlab", "gitlab-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BrokerSuite) TestExposeService(c *gc.C) {
+	err := s.broker.EnsureService("gitlab", &caas.ServiceParams{PodSpec: podSpec}, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.broker.ExposeService("gitlab", application.ConfigAttributes{})
+	c.Assert(err, gc.ErrorMatches, "external hostname required")
+
+	cfg := application.ConfigAttributes{caas.JujuExternalHostNameKey: "gitlab.example.com"}
+	c.Assert(s.broker.ExposeService("gitlab", cfg), jc.ErrorIsNil)
+	svc, err := s.broker.Service("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Addresses, gc.HasLen, 2)
+
+	c.Assert(s.broker.UnexposeService("gitlab"), jc.ErrorIsNil)
+	state, err := s.broker.ServiceState("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.Exposed, jc.IsFalse)
+}
+
+func (s *BrokerSuite) TestNetworkPolicy(c *gc.C) {
+	params := caas.NetworkPolicyParams{RelatedApplications: []string{"mysql"}}
+	c.Assert(s.broker.EnsureNetworkPolicy("gitlab", params), jc.ErrorIsNil)
+	policy, err := s.broker.NetworkPolicy("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, params)
+
+	// Ensuring a policy doesn't create the service.
+	_, err = s.broker.ServiceState("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(s.broker.DeleteNetworkPolicy("gitlab"), jc.ErrorIsNil)
+	_, err = s.broker.NetworkPolicy("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BrokerSuite) TestDeleteService(c *gc.C) {
+	err := s.broker.EnsureService("gitlab", &caas.ServiceParams{PodSpec: podSpec}, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.broker.DeleteService("gitlab"), jc.ErrorIsNil)
+	_, err = s.broker.Service("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BrokerSuite) TestParsePodSpec(c *gc.C) {
+	spec, err := s.broker.Provider().ParsePodSpec(`
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    ports:
+    - containerPort: 80
+      protocol: TCP
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Containers, gc.HasLen, 1)
+	c.Assert(spec.Containers[0].Name, gc.Equals, "gitlab")
+	c.Assert(spec.Containers[0].Ports, jc.DeepEquals, []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}})
+}
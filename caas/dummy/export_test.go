@@ -0,0 +1,12 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy
+
+// UnitWatchCount returns the number of running unit watchers
+// for the specified application.
+func UnitWatchCount(b *Broker, appName string) int {
+	b.state.mu.Lock()
+	defer b.state.mu.Unlock()
+	return len(b.state.unitWatches[appName])
+}
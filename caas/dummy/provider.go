@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dummy implements an in-memory caas.Broker, for testing
+// CAAS workers and facades without a Kubernetes cluster.
+//
+// All brokers opened for the same model share the same underlying
+// namespace state, which persists until Reset is called. The state
+// can be inspected and manipulated with the exported Broker methods,
+// for example to simulate a unit failing.
+package dummy
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+var logger = loggo.GetLogger("juju.caas.dummy")
+
+// ProviderType is the name under which the dummy CAAS provider
+// is registered.
+const ProviderType = "caas-dummy"
+
+func init() {
+	caas.RegisterContainerProvider(ProviderType, &dummy)
+}
+
+// dummy is the dummy container provider singleton.
+var dummy = containerProvider{
+	state: make(map[string]*namespaceState),
+}
+
+type containerProvider struct {
+	mu    sync.Mutex
+	state map[string]*namespaceState
+}
+
+var _ caas.ContainerEnvironProvider = (*containerProvider)(nil)
+
+// Reset forgets all namespaces, and everything in them.
+// Brokers opened before Reset should not be used afterwards.
+func Reset() {
+	logger.Infof("reset dummy caas provider")
+	dummy.mu.Lock()
+	defer dummy.mu.Unlock()
+	for _, st := range dummy.state {
+		st.destroy()
+	}
+	dummy.state = make(map[string]*namespaceState)
+}
+
+// Namespaces returns the names of the namespaces that have been
+// created and not destroyed, in alphabetical order.
+func Namespaces() []string {
+	dummy.mu.Lock()
+	defer dummy.mu.Unlock()
+	var names []string
+	for name, st := range dummy.state {
+		if st.isCreated() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namespace returns the state of the named namespace,
+// creating it if necessary.
+func (p *containerProvider) namespace(name string) *namespaceState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.state[name]
+	if !ok {
+		st = newNamespaceState()
+		p.state[name] = st
+	}
+	return st
+}
+
+// Version is part of the EnvironProvider interface.
+func (*containerProvider) Version() int {
+	return 0
+}
+
+// Open is part of the ContainerEnvironProvider interface.
+func (p *containerProvider) Open(args environs.OpenParams) (caas.Broker, error) {
+	if args.Config == nil {
+		return nil, errors.NotValidf("nil config")
+	}
+	name := args.Config.Name()
+	return &Broker{
+		ProviderRegistry: dummystorage.StorageProviders(),
+		namespace:        name,
+		state:            p.namespace(name),
+	}, nil
+}
+
+// ParsePodSpec is part of the ContainerEnvironProvider interface.
+// The spec is expected to be in the same YAML format accepted by
+// the Kubernetes provider, without any provider specific parts.
+func (*containerProvider) ParsePodSpec(in string) (*caas.PodSpec, error) {
+	var spec struct {
+		caas.PodSpec `yaml:",inline"`
+		Containers   []caas.ContainerSpec `yaml:"containers"`
+	}
+	if err := yaml.Unmarshal([]byte(in), &spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec.PodSpec.Containers = spec.Containers
+	return &spec.PodSpec, spec.PodSpec.Validate()
+}
+
+// CloudSchema is part of the EnvironProvider interface.
+func (*containerProvider) CloudSchema() *jsonschema.Schema {
+	return nil
+}
+
+// Ping is part of the EnvironProvider interface.
+func (*containerProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	return nil
+}
+
+// PrepareConfig is part of the EnvironProvider interface.
+func (*containerProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	return args.Config, nil
+}
+
+// Validate is part of the EnvironProvider interface.
+func (*containerProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// CredentialSchemas is part of the EnvironProvider interface.
+func (*containerProvider) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.EmptyAuthType: {},
+	}
+}
+
+// DetectCredentials is part of the EnvironProvider interface.
+func (*containerProvider) DetectCredentials() (*cloud.CloudCredential, error) {
+	return cloud.NewEmptyCloudCredential(), nil
+}
+
+// FinalizeCredential is part of the EnvironProvider interface.
+func (*containerProvider) FinalizeCredential(
+	ctx environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams,
+) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy
+
+import (
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// unitsWatcher is a watcher.NotifyWatcher which reports changes to
+// the units of an application. Changes are signalled on the in
+// channel, and pending changes are coalesced into a single event.
+type unitsWatcher struct {
+	catacomb catacomb.Catacomb
+
+	in   <-chan struct{}
+	out  chan struct{}
+	done func()
+}
+
+// newUnitsWatcher returns a watcher which sends an event on its
+// Changes channel whenever in is signalled. The done function is
+// called once the watcher has stopped.
+func newUnitsWatcher(in <-chan struct{}, done func()) (*unitsWatcher, error) {
+	w := &unitsWatcher{
+		in:   in,
+		out:  make(chan struct{}),
+		done: done,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		done()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *unitsWatcher) loop() error {
+	defer close(w.out)
+	defer w.done()
+
+	var out chan struct{}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.in:
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *unitsWatcher) Changes() watcher.NotifyChannel {
+	return w.out
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *unitsWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *unitsWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
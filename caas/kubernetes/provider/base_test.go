@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/mocks"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
 
@@ -27,6 +28,10 @@ type BaseSuite struct {
 
 	broker caas.Broker
 
+	// cfgAttrs are extra model config attributes
+	// used when setting up the broker.
+	cfgAttrs testing.Attrs
+
 	k8sClient                  *mocks.MockInterface
 	mockNamespaces             *mocks.MockNamespaceInterface
 	mockApps                   *mocks.MockAppsV1Interface
@@ -41,6 +46,11 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+	mockSecrets                *mocks.MockSecretInterface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -49,6 +59,11 @@ type BaseSuite struct {
 
 const testNamespace = "test"
 
+func (s *BaseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.cfgAttrs = nil
+}
+
 func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
 		"username":              "fred",
@@ -90,6 +105,12 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockPersistentVolumeClaims = mocks.NewMockPersistentVolumeClaimInterface(ctrl)
 	mockCoreV1.EXPECT().PersistentVolumeClaims(testNamespace).AnyTimes().Return(s.mockPersistentVolumeClaims)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
+	s.mockSecrets = mocks.NewMockSecretInterface(ctrl)
+	mockCoreV1.EXPECT().Secrets(testNamespace).AnyTimes().Return(s.mockSecrets)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -106,6 +127,18 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	mockRbacV1 := mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(mockRbacV1)
+	mockRbacV1.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	mockRbacV1.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+
+	mockNetworkingV1 := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworkingV1)
+	mockNetworkingV1.EXPECT().NetworkPolicies(testNamespace).AnyTimes().Return(s.mockNetworkPolicies)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
@@ -126,12 +159,22 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	}
 
 	var err error
-	s.broker, err = provider.NewK8sBroker(cloudSpec, testNamespace, newClient)
+	s.broker, err = provider.NewK8sBroker(cloudSpec, s.modelConfig(c), newClient)
 	c.Assert(err, jc.ErrorIsNil)
 
 	return ctrl
 }
 
+func (s *BaseSuite) modelConfig(c *gc.C) *config.Config {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"name": testNamespace,
+		"type": "kubernetes",
+	}).Merge(s.cfgAttrs)
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
 func (s *BaseSuite) k8sNotFoundError() *k8serrors.StatusError {
 	return k8serrors.NewNotFound(schema.GroupResource{}, "test")
 }
@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/environs/config"
 )
 
 const (
@@ -101,3 +104,77 @@ func ConfigSchema() environschema.Fields {
 func ConfigDefaults() schema.Defaults {
 	return schemaDefaults
 }
+
+const (
+	// AdoptNamespaceKey is the model config key which, when true, allows
+	// a model to adopt an existing namespace with the same name as the
+	// model, rather than requiring the namespace to be created by Juju.
+	AdoptNamespaceKey = "adopt-namespace"
+
+	// PreserveNamespaceKey is the model config key which, when true,
+	// causes the model's namespace to be left intact when the model is
+	// destroyed. Only the resources created by Juju are removed.
+	PreserveNamespaceKey = "preserve-namespace"
+)
+
+var modelConfigFields = environschema.Fields{
+	AdoptNamespaceKey: {
+		Description: "whether to adopt an existing namespace with the same name as the model",
+		Type:        environschema.Tbool,
+		Immutable:   true,
+	},
+	PreserveNamespaceKey: {
+		Description: "whether to leave the model's namespace intact when the model is destroyed",
+		Type:        environschema.Tbool,
+	},
+}
+
+var modelConfigChecker = func() schema.Fields {
+	fields, _, err := modelConfigFields.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}()
+
+// modelConfigDefaults omits the kubernetes specific model config
+// attributes when they are not set, so existing model config is
+// left as is.
+var modelConfigDefaults = schema.Defaults{
+	AdoptNamespaceKey:    schema.Omit,
+	PreserveNamespaceKey: schema.Omit,
+}
+
+// validateModelConfig validates the kubernetes specific model config
+// attributes, returning the config with those attributes coerced to
+// their expected types.
+func validateModelConfig(cfg, old *config.Config) (*config.Config, error) {
+	validated, err := cfg.ValidateUnknownAttrs(modelConfigChecker, modelConfigDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err = cfg.Apply(validated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old != nil {
+		if was, now := adoptNamespace(old), adoptNamespace(cfg); was != now {
+			return nil, errors.Errorf("cannot change %s from %v to %v", AdoptNamespaceKey, was, now)
+		}
+	}
+	return cfg, nil
+}
+
+// adoptNamespace returns whether the model described by
+// the config should adopt an existing namespace.
+func adoptNamespace(cfg *config.Config) bool {
+	adopt, _ := cfg.UnknownAttrs()[AdoptNamespaceKey].(bool)
+	return adopt
+}
+
+// preserveNamespace returns whether the namespace of the model described
+// by the config should be left intact when the model is destroyed.
+func preserveNamespace(cfg *config.Config) bool {
+	preserve, _ := cfg.UnknownAttrs()[PreserveNamespaceKey].(bool)
+	return preserve
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/network"
@@ -69,13 +71,21 @@ type kubernetesClient struct {
 	// namespace is the k8s namespace to use when
 	// creating k8s resources.
 	namespace string
+
+	// lock guards envCfg, which is updated by SetConfig.
+	lock sync.Mutex
+
+	// envCfg is the config of the model using the namespace.
+	envCfg *config.Config
 }
 
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,ServiceAccountInterface,SecretInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,RoleInterface,RoleBindingInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 
@@ -83,19 +93,20 @@ type kubernetesClient struct {
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
 
 // NewK8sBroker returns a kubernetes client for the specified k8s cluster.
-func NewK8sBroker(cloudSpec environs.CloudSpec, namespace string, newClient NewK8sClientFunc) (caas.Broker, error) {
-	config, err := newK8sConfig(cloudSpec)
+func NewK8sBroker(cloudSpec environs.CloudSpec, cfg *config.Config, newClient NewK8sClientFunc) (caas.Broker, error) {
+	k8sConfig, err := newK8sConfig(cloudSpec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	k8sClient, apiextensionsClient, err := newClient(config)
+	k8sClient, apiextensionsClient, err := newClient(k8sConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &kubernetesClient{
 		Interface:           k8sClient,
 		apiextensionsClient: apiextensionsClient,
-		namespace:           namespace,
+		namespace:           cfg.Name(),
+		envCfg:              cfg,
 	}, nil
 }
 
@@ -127,8 +138,31 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 	return providerInstance
 }
 
+// SetConfig is part of the Broker interface.
+func (k *kubernetesClient) SetConfig(cfg *config.Config) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	newCfg, err := validateModelConfig(cfg, k.envCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	k.envCfg = newCfg
+	return nil
+}
+
+// modelConfig returns the current config of the model using the namespace.
+func (k *kubernetesClient) modelConfig() *config.Config {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return k.envCfg
+}
+
 // Destroy is part of the Broker interface.
 func (k *kubernetesClient) Destroy(context.ProviderCallContext) error {
+	if preserveNamespace(k.modelConfig()) {
+		logger.Debugf("preserving namespace %q, deleting Juju resources only", k.namespace)
+		return k.deleteJujuResources()
+	}
 	return k.deleteNamespace()
 }
 
@@ -255,7 +289,13 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 			ClaimName: params.pvcName,
 		}
 	}
+	saName, err := k.ensureOperatorServiceAccount(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	pod := operatorPod(appName, agentPath, config.OperatorImagePath, config.Version.String())
+	pod.Spec.ServiceAccountName = saName
 	if storageVol != nil {
 		// TODO(caas) - if claim is pending, backoff until it is ready
 		logger.Debugf("using persistent volume for operator: %+v", storageVol)
@@ -455,9 +495,14 @@ func (k *kubernetesClient) DeleteOperator(appName string) (err error) {
 		return nil
 	}
 
-	// Finally the pod itself.
+	// Then the pod itself.
 	podName := operatorPodName(appName)
-	return k.deletePod(podName)
+	if err := k.deletePod(podName); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Finally the service account the pod ran as.
+	return errors.Trace(k.deleteOperatorServiceAccount(appName))
 }
 
 // Service returns the service for the specified application.
@@ -522,7 +567,10 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return errors.Trace(k.deleteDeployment(appName))
+	if err := k.deleteDeployment(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteServiceAccountForApp(appName))
 }
 
 // EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
@@ -622,6 +670,16 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
+	if sa := params.PodSpec.ServiceAccount; sa != nil {
+		saName, err := k.ensureServiceAccountForApp(appName, sa)
+		if err != nil {
+			return errors.Annotatef(err, "configuring service account for %s", appName)
+		}
+		cleanups = append(cleanups, func() { k.deleteServiceAccountForApp(appName) })
+		unitSpec.Pod.ServiceAccountName = saName
+		unitSpec.Pod.AutomountServiceAccountToken = sa.AutomountServiceAccountToken
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -650,6 +708,18 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
 
+	// The pod spec may previously have requested a service account; if
+	// it no longer does, or asks for it under a different name, remove
+	// what was created for it now that the pods have been updated not
+	// to use it.
+	if params.PodSpec.ServiceAccount == nil {
+		if err := k.deleteServiceAccountForApp(appName); err != nil {
+			return errors.Annotatef(err, "removing service account for %s", appName)
+		}
+	} else if err := k.deleteStaleServiceAccountsForApp(appName, unitSpec.Pod.ServiceAccountName); err != nil {
+		return errors.Annotatef(err, "removing previous service account for %s", appName)
+	}
+
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
 		for _, p := range c.Ports {
@@ -773,12 +843,14 @@ func (k *kubernetesClient) configureDevices(unitSpec *unitSpec, devices []device
 
 type configMapNameFunc func(fileSetName string) string
 
-func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers []caas.ContainerSpec, cfgMapName configMapNameFunc) error {
+func (k *kubernetesClient) configurePodFiles(
+	appName string, podSpec *core.PodSpec, containers []caas.ContainerSpec, cfgMapName configMapNameFunc,
+) error {
 	for i, container := range containers {
 		for _, fileSet := range container.Files {
 			cfgName := cfgMapName(fileSet.Name)
 			vol := core.Volume{Name: cfgName}
-			if err := k.ensureConfigMap(filesetConfigMap(cfgName, &fileSet)); err != nil {
+			if err := k.ensureConfigMap(filesetConfigMap(appName, cfgName, &fileSet)); err != nil {
 				return errors.Annotatef(err, "creating or updating ConfigMap for file set %v", cfgName)
 			}
 			vol.ConfigMap = &core.ConfigMapVolumeSource{
@@ -804,7 +876,7 @@ func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpe
 		return applicationConfigMapName(appName, fileSetName)
 	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(appName, &podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -877,7 +949,7 @@ func (k *kubernetesClient) configureStatefulSet(
 		},
 	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(appName, &podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
 	}
 	existingPodSpec := podSpec
@@ -1334,11 +1406,12 @@ func (k *kubernetesClient) jujuVolumeStatus(pvPhase core.PersistentVolumePhase)
 }
 
 // filesetConfigMap returns a *core.ConfigMap for a pod
-// of the specified unit, with the specified files.
-func filesetConfigMap(configMapName string, files *caas.FileSet) *core.ConfigMap {
+// of the specified application, with the specified files.
+func filesetConfigMap(appName, configMapName string, files *caas.FileSet) *core.ConfigMap {
 	result := &core.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
-			Name: configMapName,
+			Name:   configMapName,
+			Labels: map[string]string{labelApplication: appName},
 		},
 		Data: map[string]string{},
 	}
@@ -1483,7 +1556,8 @@ func operatorConfigMap(appName string, config *caas.OperatorConfig) *core.Config
 	configMapName := operatorConfigMapName(appName)
 	return &core.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
-			Name: configMapName,
+			Name:   configMapName,
+			Labels: map[string]string{labelOperator: appName},
 		},
 		Data: map[string]string{
 			"agent.conf": string(config.AgentConf),
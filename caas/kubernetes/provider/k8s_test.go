@@ -10,6 +10,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -175,6 +176,15 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 			Return(&core.PodList{Items: []core.Pod{}}, nil),
 		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(s.k8sNotFoundError()),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -254,6 +264,15 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
 		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -273,6 +292,306 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	automount := false
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "services"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	workloadSpec := provider.PodSpec(unitSpec)
+	workloadSpec.ServiceAccountName = "juju-test"
+	workloadSpec.AutomountServiceAccountToken = &automount
+
+	labels := map[string]string{"juju-application": "test"}
+	saArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: &automount,
+	}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "services"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "juju-test",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-test",
+			Namespace: "test",
+		}, {
+			Kind:      "ServiceAccount",
+			Name:      "juju-operator-test",
+			Namespace: "test",
+		}},
+	}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       labels,
+				},
+				Spec: workloadSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: labels},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(saArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServiceAccounts.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceAccountList{Items: []core.ServiceAccount{*saArg}}, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceRemovesServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get"},
+		}},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	labels := map[string]string{"juju-application": "test"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       labels,
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+
+	gomock.InOrder(
+		// The first pod spec requests a service account.
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&core.ServiceAccount{ObjectMeta: v1.ObjectMeta{Name: "juju-test", Labels: labels}}, nil),
+		s.mockServiceAccounts.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockRoles.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockServiceAccounts.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceAccountList{}, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+
+		// The updated pod spec drops it, so the pods stop using the
+		// service account and then it is removed.
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).Return(nil, nil),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+	)
+
+	err = s.broker.EnsureService("test", &caas.ServiceParams{PodSpec: &podSpec}, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.EnsureService("test", &caas.ServiceParams{PodSpec: basicPodspec}, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceRenamesServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Name: "new-sa",
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get"},
+		}},
+	}
+
+	labels := map[string]string{"juju-application": "test"}
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("new-sa", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockRoles.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+
+		// The service account the pods used before is removed, along
+		// with its role and role binding.
+		s.mockServiceAccounts.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceAccountList{Items: []core.ServiceAccount{
+				{ObjectMeta: v1.ObjectMeta{Name: "old-sa", Labels: labels}},
+				{ObjectMeta: v1.ObjectMeta{Name: "new-sa", Labels: labels}},
+			}}, nil),
+		s.mockRoleBindings.EXPECT().Delete("old-sa", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("old-sa", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("old-sa", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).Return(nil, nil),
+	)
+
+	err := s.broker.EnsureService("test", &caas.ServiceParams{PodSpec: &podSpec}, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceAccountNotManagedByJuju(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Name: "default",
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get"},
+		}},
+	}
+
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("default", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&core.ServiceAccount{ObjectMeta: v1.ObjectMeta{Name: "default"}}, nil),
+	)
+
+	err := s.broker.EnsureService("test", &caas.ServiceParams{PodSpec: &podSpec}, 2, nil)
+	c.Assert(err, gc.ErrorMatches, `configuring service account for test: creating or updating service account "default": service account "default" exists and is not managed by Juju`)
+}
+
+func (s *K8sBrokerSuite) TestDeleteOperator(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPersistentVolumeClaims.EXPECT().Delete("test-operator-volume", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Delete("juju-operator-test-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPods.EXPECT().Delete("juju-operator-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("juju-operator-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	err := s.broker.DeleteOperator("test")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -552,6 +871,15 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
 		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -640,6 +968,15 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
 		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -748,6 +1085,15 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{LabelSelector: "juju-application==test"},
+		).Times(1).Return(nil),
 		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -80,5 +80,10 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 			spec.Containers[i].ProviderContainer = c.K8sContainerSpec
 		}
 	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return &spec, nil
 }
@@ -143,3 +143,52 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseServiceAccount(c *gc.C) {
+	specStr := `
+containers:
+  - name: nginx-ingress
+    image: nginx-ingress/latest
+serviceAccount:
+  name: ingress
+  automountServiceAccountToken: true
+  rules:
+    - apiGroups: [""]
+      resources: ["services", "endpoints"]
+      verbs: ["get", "list", "watch"]
+    - apiGroups: ["extensions"]
+      resources: ["ingresses/status"]
+      verbs: ["update"]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	automount := true
+	c.Assert(spec.ServiceAccount, jc.DeepEquals, &caas.ServiceAccountSpec{
+		Name:                         "ingress",
+		AutomountServiceAccountToken: &automount,
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"services", "endpoints"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			APIGroups: []string{"extensions"},
+			Resources: []string{"ingresses/status"},
+			Verbs:     []string{"update"},
+		}},
+	})
+}
+
+func (s *ContainersSuite) TestParseServiceAccountMissingVerbs(c *gc.C) {
+	specStr := `
+containers:
+  - name: nginx-ingress
+    image: nginx-ingress/latest
+serviceAccount:
+  rules:
+    - resources: ["services"]
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, "rule with no verbs not valid")
+}
@@ -0,0 +1,359 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: k8s.io/client-go/kubernetes/typed/rbac/v1 (interfaces: RbacV1Interface,RoleInterface,RoleBindingInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/rbac/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v11 "k8s.io/client-go/kubernetes/typed/rbac/v1"
+	rest "k8s.io/client-go/rest"
+	reflect "reflect"
+)
+
+// MockRbacV1Interface is a mock of RbacV1Interface interface
+type MockRbacV1Interface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRbacV1InterfaceMockRecorder
+}
+
+// MockRbacV1InterfaceMockRecorder is the mock recorder for MockRbacV1Interface
+type MockRbacV1InterfaceMockRecorder struct {
+	mock *MockRbacV1Interface
+}
+
+// NewMockRbacV1Interface creates a new mock instance
+func NewMockRbacV1Interface(ctrl *gomock.Controller) *MockRbacV1Interface {
+	mock := &MockRbacV1Interface{ctrl: ctrl}
+	mock.recorder = &MockRbacV1InterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRbacV1Interface) EXPECT() *MockRbacV1InterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClusterRoleBindings mocks base method
+func (m *MockRbacV1Interface) ClusterRoleBindings() v11.ClusterRoleBindingInterface {
+	ret := m.ctrl.Call(m, "ClusterRoleBindings")
+	ret0, _ := ret[0].(v11.ClusterRoleBindingInterface)
+	return ret0
+}
+
+// ClusterRoleBindings indicates an expected call of ClusterRoleBindings
+func (mr *MockRbacV1InterfaceMockRecorder) ClusterRoleBindings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterRoleBindings", reflect.TypeOf((*MockRbacV1Interface)(nil).ClusterRoleBindings))
+}
+
+// ClusterRoles mocks base method
+func (m *MockRbacV1Interface) ClusterRoles() v11.ClusterRoleInterface {
+	ret := m.ctrl.Call(m, "ClusterRoles")
+	ret0, _ := ret[0].(v11.ClusterRoleInterface)
+	return ret0
+}
+
+// ClusterRoles indicates an expected call of ClusterRoles
+func (mr *MockRbacV1InterfaceMockRecorder) ClusterRoles() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterRoles", reflect.TypeOf((*MockRbacV1Interface)(nil).ClusterRoles))
+}
+
+// RESTClient mocks base method
+func (m *MockRbacV1Interface) RESTClient() rest.Interface {
+	ret := m.ctrl.Call(m, "RESTClient")
+	ret0, _ := ret[0].(rest.Interface)
+	return ret0
+}
+
+// RESTClient indicates an expected call of RESTClient
+func (mr *MockRbacV1InterfaceMockRecorder) RESTClient() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RESTClient", reflect.TypeOf((*MockRbacV1Interface)(nil).RESTClient))
+}
+
+// RoleBindings mocks base method
+func (m *MockRbacV1Interface) RoleBindings(arg0 string) v11.RoleBindingInterface {
+	ret := m.ctrl.Call(m, "RoleBindings", arg0)
+	ret0, _ := ret[0].(v11.RoleBindingInterface)
+	return ret0
+}
+
+// RoleBindings indicates an expected call of RoleBindings
+func (mr *MockRbacV1InterfaceMockRecorder) RoleBindings(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleBindings", reflect.TypeOf((*MockRbacV1Interface)(nil).RoleBindings), arg0)
+}
+
+// Roles mocks base method
+func (m *MockRbacV1Interface) Roles(arg0 string) v11.RoleInterface {
+	ret := m.ctrl.Call(m, "Roles", arg0)
+	ret0, _ := ret[0].(v11.RoleInterface)
+	return ret0
+}
+
+// Roles indicates an expected call of Roles
+func (mr *MockRbacV1InterfaceMockRecorder) Roles(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Roles", reflect.TypeOf((*MockRbacV1Interface)(nil).Roles), arg0)
+}
+
+// MockRoleInterface is a mock of RoleInterface interface
+type MockRoleInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleInterfaceMockRecorder
+}
+
+// MockRoleInterfaceMockRecorder is the mock recorder for MockRoleInterface
+type MockRoleInterfaceMockRecorder struct {
+	mock *MockRoleInterface
+}
+
+// NewMockRoleInterface creates a new mock instance
+func NewMockRoleInterface(ctrl *gomock.Controller) *MockRoleInterface {
+	mock := &MockRoleInterface{ctrl: ctrl}
+	mock.recorder = &MockRoleInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRoleInterface) EXPECT() *MockRoleInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockRoleInterface) Create(arg0 *v1.Role) (*v1.Role, error) {
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockRoleInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleInterface)(nil).Create), arg0)
+}
+
+// Delete mocks base method
+func (m *MockRoleInterface) Delete(arg0 string, arg1 *v10.DeleteOptions) error {
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockRoleInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoleInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method
+func (m *MockRoleInterface) DeleteCollection(arg0 *v10.DeleteOptions, arg1 v10.ListOptions) error {
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockRoleInterfaceMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockRoleInterface)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Get mocks base method
+func (m *MockRoleInterface) Get(arg0 string, arg1 v10.GetOptions) (*v1.Role, error) {
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockRoleInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRoleInterface)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method
+func (m *MockRoleInterface) List(arg0 v10.ListOptions) (*v1.RoleList, error) {
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.RoleList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockRoleInterfaceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleInterface)(nil).List), arg0)
+}
+
+// Patch mocks base method
+func (m *MockRoleInterface) Patch(arg0 string, arg1 types.PatchType, arg2 []byte, arg3 ...string) (*v1.Role, error) {
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockRoleInterfaceMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRoleInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockRoleInterface) Update(arg0 *v1.Role) (*v1.Role, error) {
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockRoleInterfaceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRoleInterface)(nil).Update), arg0)
+}
+
+// Watch mocks base method
+func (m *MockRoleInterface) Watch(arg0 v10.ListOptions) (watch.Interface, error) {
+	ret := m.ctrl.Call(m, "Watch", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockRoleInterfaceMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockRoleInterface)(nil).Watch), arg0)
+}
+
+// MockRoleBindingInterface is a mock of RoleBindingInterface interface
+type MockRoleBindingInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleBindingInterfaceMockRecorder
+}
+
+// MockRoleBindingInterfaceMockRecorder is the mock recorder for MockRoleBindingInterface
+type MockRoleBindingInterfaceMockRecorder struct {
+	mock *MockRoleBindingInterface
+}
+
+// NewMockRoleBindingInterface creates a new mock instance
+func NewMockRoleBindingInterface(ctrl *gomock.Controller) *MockRoleBindingInterface {
+	mock := &MockRoleBindingInterface{ctrl: ctrl}
+	mock.recorder = &MockRoleBindingInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRoleBindingInterface) EXPECT() *MockRoleBindingInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockRoleBindingInterface) Create(arg0 *v1.RoleBinding) (*v1.RoleBinding, error) {
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*v1.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockRoleBindingInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleBindingInterface)(nil).Create), arg0)
+}
+
+// Delete mocks base method
+func (m *MockRoleBindingInterface) Delete(arg0 string, arg1 *v10.DeleteOptions) error {
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockRoleBindingInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoleBindingInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method
+func (m *MockRoleBindingInterface) DeleteCollection(arg0 *v10.DeleteOptions, arg1 v10.ListOptions) error {
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockRoleBindingInterfaceMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockRoleBindingInterface)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Get mocks base method
+func (m *MockRoleBindingInterface) Get(arg0 string, arg1 v10.GetOptions) (*v1.RoleBinding, error) {
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*v1.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockRoleBindingInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRoleBindingInterface)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method
+func (m *MockRoleBindingInterface) List(arg0 v10.ListOptions) (*v1.RoleBindingList, error) {
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.RoleBindingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockRoleBindingInterfaceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleBindingInterface)(nil).List), arg0)
+}
+
+// Patch mocks base method
+func (m *MockRoleBindingInterface) Patch(arg0 string, arg1 types.PatchType, arg2 []byte, arg3 ...string) (*v1.RoleBinding, error) {
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockRoleBindingInterfaceMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRoleBindingInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockRoleBindingInterface) Update(arg0 *v1.RoleBinding) (*v1.RoleBinding, error) {
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(*v1.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockRoleBindingInterfaceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRoleBindingInterface)(nil).Update), arg0)
+}
+
+// Watch mocks base method
+func (m *MockRoleBindingInterface) Watch(arg0 v10.ListOptions) (watch.Interface, error) {
+	ret := m.ctrl.Call(m, "Watch", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockRoleBindingInterfaceMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockRoleBindingInterface)(nil).Watch), arg0)
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// jujuResourceSelectors are the label selectors matching
+// the resources Juju creates in a model's namespace.
+var jujuResourceSelectors = []string{labelApplication, labelOperator}
+
+// Create is part of the Broker interface.
+//
+// A model's namespace is created when it is first needed. If the model
+// is configured to adopt an existing namespace, Create checks that the
+// namespace exists and holds no resources which conflict with those
+// managed by Juju.
+func (k *kubernetesClient) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	if !adoptNamespace(k.modelConfig()) {
+		return nil
+	}
+	logger.Debugf("adopting existing namespace %q", k.namespace)
+	_, err := k.CoreV1().Namespaces().Get(k.namespace, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("namespace %q", k.namespace)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conflicts, err := k.jujuResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(conflicts) > 0 {
+		return errors.Errorf(
+			"cannot adopt namespace %q: it already contains resources managed by Juju: %v",
+			k.namespace, conflicts,
+		)
+	}
+	return nil
+}
+
+// jujuResources returns the kind and name of any resources
+// in the broker's namespace which are managed by Juju.
+func (k *kubernetesClient) jujuResources() ([]string, error) {
+	var result []string
+	found := func(kind, name string) {
+		result = append(result, fmt.Sprintf("%s/%s", kind, name))
+	}
+	for _, selector := range jujuResourceSelectors {
+		opts := v1.ListOptions{LabelSelector: selector}
+
+		deployments, err := k.AppsV1().Deployments(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, d := range deployments.Items {
+			found("deployment", d.Name)
+		}
+		statefulSets, err := k.AppsV1().StatefulSets(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, s := range statefulSets.Items {
+			found("statefulset", s.Name)
+		}
+		pods, err := k.CoreV1().Pods(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, p := range pods.Items {
+			found("pod", p.Name)
+		}
+		services, err := k.CoreV1().Services(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, s := range services.Items {
+			found("service", s.Name)
+		}
+		configMaps, err := k.CoreV1().ConfigMaps(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, cm := range configMaps.Items {
+			found("configmap", cm.Name)
+		}
+		pvClaims, err := k.CoreV1().PersistentVolumeClaims(k.namespace).List(opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, pvc := range pvClaims.Items {
+			found("persistentvolumeclaim", pvc.Name)
+		}
+	}
+	return result, nil
+}
+
+// deleteJujuResources deletes the resources Juju manages in the
+// broker's namespace, leaving the namespace itself intact.
+func (k *kubernetesClient) deleteJujuResources() error {
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+	for _, selector := range jujuResourceSelectors {
+		opts := v1.ListOptions{LabelSelector: selector}
+
+		// Services don't support deleting a collection.
+		services := k.CoreV1().Services(k.namespace)
+		servicesList, err := services.List(opts)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, s := range servicesList.Items {
+			err := services.Delete(s.Name, deleteOptions)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Annotatef(err, "deleting service %q", s.Name)
+			}
+		}
+
+		for _, collection := range []struct {
+			kind   string
+			delete func(*v1.DeleteOptions, v1.ListOptions) error
+		}{
+			{"ingresses", k.ExtensionsV1beta1().Ingresses(k.namespace).DeleteCollection},
+			{"network policies", k.NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection},
+			{"deployments", k.AppsV1().Deployments(k.namespace).DeleteCollection},
+			{"stateful sets", k.AppsV1().StatefulSets(k.namespace).DeleteCollection},
+			{"pods", k.CoreV1().Pods(k.namespace).DeleteCollection},
+			{"config maps", k.CoreV1().ConfigMaps(k.namespace).DeleteCollection},
+			{"secrets", k.CoreV1().Secrets(k.namespace).DeleteCollection},
+			{"persistent volume claims", k.CoreV1().PersistentVolumeClaims(k.namespace).DeleteCollection},
+			{"role bindings", k.RbacV1().RoleBindings(k.namespace).DeleteCollection},
+			{"roles", k.RbacV1().Roles(k.namespace).DeleteCollection},
+			{"service accounts", k.CoreV1().ServiceAccounts(k.namespace).DeleteCollection},
+		} {
+			err := collection.delete(deleteOptions, opts)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Annotatef(err, "deleting %s", collection.kind)
+			}
+		}
+	}
+	return nil
+}
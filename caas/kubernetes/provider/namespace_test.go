@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/testing"
+)
+
+type NamespaceSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&NamespaceSuite{})
+
+func (s *NamespaceSuite) TestCreateNoAdopt(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// The namespace is created when first needed, so nothing is expected.
+	err := s.broker.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NamespaceSuite) expectNoJujuResources() {
+	for _, selector := range []string{"juju-application", "juju-operator"} {
+		opts := v1.ListOptions{LabelSelector: selector}
+		s.mockDeployments.EXPECT().List(opts).Return(&apps.DeploymentList{}, nil)
+		s.mockStatefulSets.EXPECT().List(opts).Return(&apps.StatefulSetList{}, nil)
+		s.mockPods.EXPECT().List(opts).Return(&core.PodList{}, nil)
+		s.mockServices.EXPECT().List(opts).Return(&core.ServiceList{}, nil)
+		s.mockConfigMaps.EXPECT().List(opts).Return(&core.ConfigMapList{}, nil)
+		s.mockPersistentVolumeClaims.EXPECT().List(opts).Return(&core.PersistentVolumeClaimList{}, nil)
+	}
+}
+
+func (s *NamespaceSuite) TestCreateAdoptNamespace(c *gc.C) {
+	s.cfgAttrs = testing.Attrs{provider.AdoptNamespaceKey: true}
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{}).Times(1).
+		Return(&core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
+	s.expectNoJujuResources()
+
+	err := s.broker.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NamespaceSuite) TestCreateAdoptNamespaceNotFound(c *gc.C) {
+	s.cfgAttrs = testing.Attrs{provider.AdoptNamespaceKey: true}
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+
+	err := s.broker.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `namespace "test" not found`)
+}
+
+func (s *NamespaceSuite) TestCreateAdoptNamespaceConflict(c *gc.C) {
+	s.cfgAttrs = testing.Attrs{provider.AdoptNamespaceKey: true}
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{}).Times(1).
+		Return(&core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
+	opts := v1.ListOptions{LabelSelector: "juju-application"}
+	s.mockDeployments.EXPECT().List(opts).Return(&apps.DeploymentList{
+		Items: []apps.Deployment{{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab"}}},
+	}, nil)
+	s.mockStatefulSets.EXPECT().List(opts).Return(&apps.StatefulSetList{}, nil)
+	s.mockPods.EXPECT().List(opts).Return(&core.PodList{
+		Items: []core.Pod{{ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab-0"}}},
+	}, nil)
+	s.mockServices.EXPECT().List(opts).Return(&core.ServiceList{}, nil)
+	s.mockConfigMaps.EXPECT().List(opts).Return(&core.ConfigMapList{}, nil)
+	s.mockPersistentVolumeClaims.EXPECT().List(opts).Return(&core.PersistentVolumeClaimList{}, nil)
+	opts = v1.ListOptions{LabelSelector: "juju-operator"}
+	s.mockDeployments.EXPECT().List(opts).Return(&apps.DeploymentList{}, nil)
+	s.mockStatefulSets.EXPECT().List(opts).Return(&apps.StatefulSetList{}, nil)
+	s.mockPods.EXPECT().List(opts).Return(&core.PodList{}, nil)
+	s.mockServices.EXPECT().List(opts).Return(&core.ServiceList{}, nil)
+	s.mockConfigMaps.EXPECT().List(opts).Return(&core.ConfigMapList{}, nil)
+	s.mockPersistentVolumeClaims.EXPECT().List(opts).Return(&core.PersistentVolumeClaimList{}, nil)
+
+	err := s.broker.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot adopt namespace "test": it already contains resources managed by Juju: \[deployment/juju-gitlab pod/juju-gitlab-0\]`)
+}
+
+func (s *NamespaceSuite) TestDestroy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(nil)
+
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NamespaceSuite) TestDestroyPreserveNamespace(c *gc.C) {
+	s.cfgAttrs = testing.Attrs{provider.PreserveNamespaceKey: true}
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.expectDeleteJujuResources()
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NamespaceSuite) TestDestroyPreserveNamespaceSetAfterOpen(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.cfgAttrs = testing.Attrs{provider.PreserveNamespaceKey: true}
+	err := s.broker.SetConfig(s.modelConfig(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectDeleteJujuResources()
+	err = s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NamespaceSuite) TestSetConfigCannotChangeAdoptNamespace(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.cfgAttrs = testing.Attrs{provider.AdoptNamespaceKey: true}
+	err := s.broker.SetConfig(s.modelConfig(c))
+	c.Assert(err, gc.ErrorMatches, "cannot change adopt-namespace from false to true")
+}
+
+func (s *NamespaceSuite) expectDeleteJujuResources() {
+	deleteOptions := s.deleteOptions(v1.DeletePropagationForeground)
+	for _, selector := range []string{"juju-application", "juju-operator"} {
+		opts := v1.ListOptions{LabelSelector: selector}
+		s.mockServices.EXPECT().List(opts).Return(&core.ServiceList{
+			Items: []core.Service{{ObjectMeta: v1.ObjectMeta{Name: "juju-" + selector}}},
+		}, nil)
+		gomock.InOrder(
+			s.mockServices.EXPECT().Delete("juju-"+selector, deleteOptions).Return(s.k8sNotFoundError()),
+			s.mockIngressInterface.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockNetworkPolicies.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockDeployments.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockStatefulSets.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockPods.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockConfigMaps.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockSecrets.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockPersistentVolumeClaims.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockRoleBindings.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockRoles.EXPECT().DeleteCollection(deleteOptions, opts).Return(nil),
+			s.mockServiceAccounts.EXPECT().DeleteCollection(deleteOptions, opts).Return(s.k8sNotFoundError()),
+		)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// EnsureNetworkPolicy is part of the Broker interface.
+// The policy selects the pods of the specified application and only
+// admits ingress from the pods of the application itself, its operator
+// and the related applications. If the application is exposed, ingress
+// to the ports of its service is admitted from any source.
+func (k *kubernetesClient) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error {
+	logger.Debugf("creating/updating network policy for %s", appName)
+
+	peers := append([]string{appName}, params.RelatedApplications...)
+	rules := []networkingv1.NetworkPolicyIngressRule{{
+		From: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      labelApplication,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   peers,
+				}},
+			},
+		}, {
+			PodSelector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelOperator: appName},
+			},
+		}},
+	}}
+
+	if params.Exposed {
+		ports, err := k.servicePolicyPorts(appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(ports) > 0 {
+			rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: ports})
+		}
+	}
+
+	spec := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Ingress:     rules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	return k.ensureNetworkPolicy(spec)
+}
+
+// servicePolicyPorts returns the target ports of the specified
+// application's service, or nil if there is no service yet.
+func (k *kubernetesClient) servicePolicyPorts(appName string) ([]networkingv1.NetworkPolicyPort, error) {
+	svc, err := k.CoreV1().Services(k.namespace).Get(deploymentName(appName), v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ports := make([]networkingv1.NetworkPolicyPort, len(svc.Spec.Ports))
+	for i, p := range svc.Spec.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = core.ProtocolTCP
+		}
+		port := p.TargetPort
+		ports[i] = networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		}
+	}
+	return ports, nil
+}
+
+func (k *kubernetesClient) ensureNetworkPolicy(spec *networkingv1.NetworkPolicy) error {
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := policies.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = policies.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+// DeleteNetworkPolicy is part of the Broker interface.
+func (k *kubernetesClient) DeleteNetworkPolicy(appName string) error {
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	err := policies.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
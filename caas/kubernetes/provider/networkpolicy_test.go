@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+type NetworkPolicySuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&NetworkPolicySuite{})
+
+func relationIngressRule(peers ...string) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      "juju-application",
+					Operator: v1.LabelSelectorOpIn,
+					Values:   peers,
+				}},
+			},
+		}, {
+			PodSelector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-operator": peers[0]},
+			},
+		}},
+	}
+}
+
+func networkPolicy(rules ...networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-gitlab",
+			Labels: map[string]string{"juju-application": "gitlab"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "gitlab"},
+			},
+			Ingress:     rules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func (s *NetworkPolicySuite) TestEnsureNetworkPolicy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	policy := networkPolicy(relationIngressRule("gitlab", "mysql"))
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Update(policy).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policy).Times(1).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NetworkPolicySuite) TestEnsureNetworkPolicyExposed(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-gitlab"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+				Protocol:   core.ProtocolTCP,
+			}, {
+				Port:       53,
+				TargetPort: intstr.FromInt(53),
+				Protocol:   core.ProtocolUDP,
+			}},
+		},
+	}
+	tcp, udp := core.ProtocolTCP, core.ProtocolUDP
+	httpPort, dnsPort := intstr.FromInt(8080), intstr.FromInt(53)
+	policy := networkPolicy(
+		relationIngressRule("gitlab"),
+		networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &tcp, Port: &httpPort},
+				{Protocol: &udp, Port: &dnsPort},
+			},
+		},
+	)
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-gitlab", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+		s.mockNetworkPolicies.EXPECT().Update(policy).Times(1).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{Exposed: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NetworkPolicySuite) TestEnsureNetworkPolicyExposedNoService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	policy := networkPolicy(relationIngressRule("gitlab"))
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-gitlab", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(policy).Times(1).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{Exposed: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NetworkPolicySuite) TestDeleteNetworkPolicy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNetworkPolicies.EXPECT().Delete("juju-gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(s.k8sNotFoundError())
+
+	err := s.broker.DeleteNetworkPolicy("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -50,7 +50,7 @@ func (kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker, er
 	if err := validateCloudSpec(args.Cloud); err != nil {
 		return nil, errors.Annotate(err, "validating cloud spec")
 	}
-	broker, err := NewK8sBroker(args.Cloud, args.Config.Name(), newK8sClient)
+	broker, err := NewK8sBroker(args.Cloud, args.Config, newK8sClient)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +101,7 @@ func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Co
 	if err := config.Validate(cfg, old); err != nil {
 		return nil, err
 	}
-	return cfg, nil
+	return validateModelConfig(cfg, old)
 }
 
 func validateCloudSpec(spec environs.CloudSpec) error {
@@ -129,6 +129,27 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
 
+func (s *providerSuite) TestValidateNamespaceAttrs(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		provider.AdoptNamespaceKey:    "true",
+		provider.PreserveNamespaceKey: false,
+	})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attrs := validCfg.UnknownAttrs()
+	c.Assert(attrs[provider.AdoptNamespaceKey], jc.IsTrue)
+	c.Assert(attrs[provider.PreserveNamespaceKey], jc.IsFalse)
+}
+
+func (s *providerSuite) TestValidateCannotChangeAdoptNamespace(c *gc.C) {
+	old := fakeConfig(c, coretesting.Attrs{provider.AdoptNamespaceKey: true})
+	config, err := old.Apply(coretesting.Attrs{provider.AdoptNamespaceKey: false})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(config, old)
+	c.Assert(err, gc.ErrorMatches, "cannot change adopt-namespace from true to false")
+}
+
 func (s *providerSuite) TestParsePodSpec(c *gc.C) {
 
 	specStr := `
@@ -0,0 +1,219 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// ensureServiceAccountForApp creates or updates the service account
+// requested in the pod spec of the specified application, along with
+// a role and role binding granting it the requested rules within the
+// model's namespace. The application's operator service account is
+// bound to the same role, since the charm's hooks run in the operator
+// pod. The name of the service account is returned.
+func (k *kubernetesClient) ensureServiceAccountForApp(appName string, spec *caas.ServiceAccountSpec) (string, error) {
+	name := spec.Name
+	if name == "" {
+		name = deploymentName(appName)
+	}
+	labels := map[string]string{labelApplication: appName}
+
+	sa := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+	}
+	if err := k.ensureServiceAccount(sa); err != nil {
+		return "", errors.Annotatef(err, "creating or updating service account %q", name)
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		Rules: policyRules(spec.Rules),
+	}
+	if err := k.ensureRole(role); err != nil {
+		return "", errors.Annotatef(err, "creating or updating role %q", name)
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: k.namespace,
+		}, {
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      operatorServiceAccountName(appName),
+			Namespace: k.namespace,
+		}},
+	}
+	if err := k.ensureRoleBinding(binding); err != nil {
+		return "", errors.Annotatef(err, "creating or updating role binding %q", name)
+	}
+	return name, nil
+}
+
+// ensureOperatorServiceAccount creates or updates the service account
+// the operator pod of the specified application runs as. It is granted
+// no rules of its own; any rules requested in the application's pod
+// spec are bound to it by ensureServiceAccountForApp.
+func (k *kubernetesClient) ensureOperatorServiceAccount(appName string) (string, error) {
+	name := operatorServiceAccountName(appName)
+	sa := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    map[string]string{labelOperator: appName},
+		},
+	}
+	if err := k.ensureServiceAccount(sa); err != nil {
+		return "", errors.Annotatef(err, "creating or updating service account %q", name)
+	}
+	return name, nil
+}
+
+// deleteOperatorServiceAccount deletes the service account the
+// operator pod of the specified application runs as.
+func (k *kubernetesClient) deleteOperatorServiceAccount(appName string) error {
+	err := k.CoreV1().ServiceAccounts(k.namespace).Delete(
+		operatorServiceAccountName(appName),
+		&v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy},
+	)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func operatorServiceAccountName(appName string) string {
+	return operatorPodName(appName)
+}
+
+func policyRules(in []caas.PolicyRule) []rbacv1.PolicyRule {
+	rules := make([]rbacv1.PolicyRule, len(in))
+	for i, r := range in {
+		rules[i] = rbacv1.PolicyRule{
+			Verbs:         r.Verbs,
+			APIGroups:     r.APIGroups,
+			Resources:     r.Resources,
+			ResourceNames: r.ResourceNames,
+		}
+	}
+	return rules
+}
+
+// ensureServiceAccount creates the service account, or updates it if
+// it already exists. An existing service account without the labels
+// Juju gives it wasn't created by Juju, and is left alone.
+func (k *kubernetesClient) ensureServiceAccount(sa *core.ServiceAccount) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	existing, err := serviceAccounts.Get(sa.Name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(sa)
+		return errors.Trace(err)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, value := range sa.Labels {
+		if existing.Labels[key] != value {
+			return errors.Errorf("service account %q exists and is not managed by Juju", sa.Name)
+		}
+	}
+	_, err = serviceAccounts.Update(sa)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRole(role *rbacv1.Role) error {
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRoleBinding(binding *rbacv1.RoleBinding) error {
+	roleBindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err := roleBindings.Update(binding)
+	if k8serrors.IsNotFound(err) {
+		_, err = roleBindings.Create(binding)
+	}
+	return errors.Trace(err)
+}
+
+// deleteStaleServiceAccountsForApp deletes the service accounts, roles
+// and role bindings created for the specified application under any
+// name other than the one its pod spec now requests.
+func (k *kubernetesClient) deleteStaleServiceAccountsForApp(appName, name string) error {
+	listOptions := v1.ListOptions{LabelSelector: applicationSelector(appName)}
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+
+	serviceAccounts, err := k.CoreV1().ServiceAccounts(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Annotatef(err, "listing service accounts for %v", appName)
+	}
+	for _, sa := range serviceAccounts.Items {
+		if sa.Name == name {
+			continue
+		}
+		err := k.RbacV1().RoleBindings(k.namespace).Delete(sa.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting role binding %q", sa.Name)
+		}
+		err = k.RbacV1().Roles(k.namespace).Delete(sa.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting role %q", sa.Name)
+		}
+		err = k.CoreV1().ServiceAccounts(k.namespace).Delete(sa.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting service account %q", sa.Name)
+		}
+	}
+	return nil
+}
+
+// deleteServiceAccountForApp deletes any service account, role and
+// role binding created for the specified application.
+func (k *kubernetesClient) deleteServiceAccountForApp(appName string) error {
+	listOptions := v1.ListOptions{LabelSelector: applicationSelector(appName)}
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+
+	err := k.RbacV1().RoleBindings(k.namespace).DeleteCollection(deleteOptions, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting role bindings for %v", appName)
+	}
+	err = k.RbacV1().Roles(k.namespace).DeleteCollection(deleteOptions, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting roles for %v", appName)
+	}
+	err = k.CoreV1().ServiceAccounts(k.namespace).DeleteCollection(deleteOptions, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting service accounts for %v", appName)
+	}
+	return nil
+}
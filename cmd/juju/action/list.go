@@ -28,7 +28,7 @@ type listCommand struct {
 	ActionCommandBase
 	applicationTag names.ApplicationTag
 	fullSchema     bool
-	out            cmd.Output
+	out            output.Output
 }
 
 const listDoc = `
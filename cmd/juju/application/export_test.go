@@ -139,3 +139,23 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewTransferLeadershipCommandForTest returns a TransferLeadershipCommand with the api provided as specified.
+func NewTransferLeadershipCommandForTest(api LeadershipAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &transferLeadershipCommand{}
+	cmd.newAPIFunc = func() (LeadershipAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewPinLeadershipCommandForTest returns a PinLeadershipCommand with the api provided as specified.
+func NewPinLeadershipCommandForTest(api LeadershipAPI, store jujuclient.ClientStore, unpin bool) modelcmd.ModelCommand {
+	cmd := &pinLeadershipCommand{unpin: unpin}
+	cmd.newAPIFunc = func() (LeadershipAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationleadership"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var transferLeadershipHelpSummary = `
+Makes a unit the leader of its application.`[1:]
+
+var transferLeadershipHelpDetails = `
+The current leader of the unit's application keeps leadership until its
+lease runs out, which is usually within a minute, but can no longer
+extend it. Leadership is then handed to the named unit, whose agent will
+hold on to it as usual. The transfer is recorded in the application's
+status history.
+
+Leadership can't be transferred while it is pinned, and pinning it
+cancels a pending transfer.
+
+Examples:
+    juju transfer-leadership mysql/1
+
+See also:
+    pin-leadership
+    unpin-leadership
+    show-status-log`[1:]
+
+var pinLeadershipHelpSummary = `
+Stops an application's leadership from changing hands.`[1:]
+
+var pinLeadershipHelpDetails = `
+The current leader of the application keeps leadership, even if its
+agent stops running, until the application is unpinned. This is useful
+while carrying out maintenance on the application's units. Pinning is
+recorded in the application's status history.
+
+Leadership stays pinned until every user that pinned it has unpinned it.
+
+Examples:
+    juju pin-leadership mysql
+
+See also:
+    unpin-leadership
+    transfer-leadership
+    show-status-log`[1:]
+
+var unpinLeadershipHelpSummary = `
+Allows an application's leadership to change hands again.`[1:]
+
+var unpinLeadershipHelpDetails = `
+Removes the current user's pin from the application's leadership. The
+unpinning is recorded in the application's status history.
+
+Examples:
+    juju unpin-leadership mysql
+
+See also:
+    pin-leadership
+    transfer-leadership
+    show-status-log`[1:]
+
+// LeadershipAPI defines the API methods that the leadership commands use.
+type LeadershipAPI interface {
+	Close() error
+	TransferLeadership(unitName string) error
+	PinLeadership(application string) error
+	UnpinLeadership(application string) error
+}
+
+// leadershipCommandBase holds what's common to the leadership commands.
+type leadershipCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (LeadershipAPI, error)
+}
+
+func (c *leadershipCommandBase) getAPI() (LeadershipAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationleadership.NewClient(root), nil
+}
+
+func (c *leadershipCommandBase) run(call func(LeadershipAPI) error) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(call(client), block.BlockChange)
+}
+
+// NewTransferLeadershipCommand returns a command to transfer an
+// application's leadership to one of its units.
+func NewTransferLeadershipCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&transferLeadershipCommand{})
+}
+
+type transferLeadershipCommand struct {
+	leadershipCommandBase
+	unitName string
+}
+
+func (c *transferLeadershipCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "transfer-leadership",
+		Args:    "<unit name>",
+		Purpose: transferLeadershipHelpSummary,
+		Doc:     transferLeadershipHelpDetails,
+	}
+}
+
+func (c *transferLeadershipCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	c.unitName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *transferLeadershipCommand) Run(_ *cmd.Context) error {
+	return c.run(func(client LeadershipAPI) error {
+		return client.TransferLeadership(c.unitName)
+	})
+}
+
+// NewPinLeadershipCommand returns a command to pin an application's
+// leadership.
+func NewPinLeadershipCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&pinLeadershipCommand{})
+}
+
+type pinLeadershipCommand struct {
+	leadershipCommandBase
+	applicationName string
+	unpin           bool
+}
+
+func (c *pinLeadershipCommand) Info() *cmd.Info {
+	if c.unpin {
+		return &cmd.Info{
+			Name:    "unpin-leadership",
+			Args:    "<application name>",
+			Purpose: unpinLeadershipHelpSummary,
+			Doc:     unpinLeadershipHelpDetails,
+		}
+	}
+	return &cmd.Info{
+		Name:    "pin-leadership",
+		Args:    "<application name>",
+		Purpose: pinLeadershipHelpSummary,
+		Doc:     pinLeadershipHelpDetails,
+	}
+}
+
+func (c *pinLeadershipCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *pinLeadershipCommand) Run(_ *cmd.Context) error {
+	return c.run(func(client LeadershipAPI) error {
+		if c.unpin {
+			return client.UnpinLeadership(c.applicationName)
+		}
+		return client.PinLeadership(c.applicationName)
+	})
+}
+
+// NewUnpinLeadershipCommand returns a command to unpin an
+// application's leadership.
+func NewUnpinLeadershipCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&pinLeadershipCommand{unpin: true})
+}
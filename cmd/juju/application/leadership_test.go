@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LeadershipSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockLeadershipAPI
+}
+
+var _ = gc.Suite(&LeadershipSuite{})
+
+func (s *LeadershipSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockLeadershipAPI{Stub: &testing.Stub{}}
+}
+
+func (s *LeadershipSuite) run(c *gc.C, command cmd.Command, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *LeadershipSuite) runTransfer(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	return s.run(c, application.NewTransferLeadershipCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *LeadershipSuite) runPin(c *gc.C, unpin bool, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	return s.run(c, application.NewPinLeadershipCommandForTest(s.mockAPI, store, unpin), args...)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipInit(c *gc.C) {
+	err := s.runTransfer(c)
+	c.Assert(err, gc.ErrorMatches, "no unit name specified")
+	err = s.runTransfer(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	err = s.runTransfer(c, "mysql/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql/1"\]`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *LeadershipSuite) TestTransferLeadership(c *gc.C) {
+	err := s.runTransfer(c, "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"TransferLeadership", []interface{}{"mysql/1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`cannot transfer leadership of "mysql": leadership pinned`))
+	err := s.runTransfer(c, "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `cannot transfer leadership of "mysql": leadership pinned`)
+	s.mockAPI.CheckCallNames(c, "TransferLeadership", "Close")
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestTransferLeadershipBlocked"))
+	err := s.runTransfer(c, "mysql/1")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestTransferLeadershipBlocked.*")
+}
+
+func (s *LeadershipSuite) TestPinLeadershipInit(c *gc.C) {
+	err := s.runPin(c, false)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	err = s.runPin(c, true, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+	err = s.runPin(c, false, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *LeadershipSuite) TestPinLeadership(c *gc.C) {
+	err := s.runPin(c, false, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"PinLeadership", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *LeadershipSuite) TestUnpinLeadership(c *gc.C) {
+	err := s.runPin(c, true, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UnpinLeadership", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *LeadershipSuite) TestPinLeadershipBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestPinLeadershipBlocked"))
+	err := s.runPin(c, false, "mysql")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestPinLeadershipBlocked.*")
+}
+
+type mockLeadershipAPI struct {
+	*testing.Stub
+}
+
+func (m *mockLeadershipAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockLeadershipAPI) TransferLeadership(unitName string) error {
+	m.MethodCall(m, "TransferLeadership", unitName)
+	return m.NextErr()
+}
+
+func (m *mockLeadershipAPI) PinLeadership(application string) error {
+	m.MethodCall(m, "PinLeadership", application)
+	return m.NextErr()
+}
+
+func (m *mockLeadershipAPI) UnpinLeadership(application string) error {
+	m.MethodCall(m, "UnpinLeadership", application)
+	return m.NextErr()
+}
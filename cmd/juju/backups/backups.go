@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -29,6 +31,12 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// CreateOnStorage sends an RPC request to create a new backup,
+	// stored in the given backup storage target.
+	CreateOnStorage(notes, storage string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// CreateEncrypted sends an RPC request to create a new backup,
+	// encrypted with the given passphrase or for the given public key.
+	CreateEncrypted(notes, storage string, keepCopy, noDownload bool, passphrase, publicKey string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -40,9 +48,12 @@ type APIClient interface {
 	// Remove removes the stored backups.
 	Remove(ids ...string) ([]params.ErrorResult, error)
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, *params.BackupDecryptionKey, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
-	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, *params.BackupDecryptionKey, backups.ClientConnection) error
+	// RestoreModel will restore a single model from the backup with
+	// the given id into the controller.
+	RestoreModel(backupId, modelUUID, newName string, replace bool, key *params.BackupDecryptionKey) (params.RestoreModelResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -103,6 +114,12 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	if result.Storage != "" {
+		fmt.Fprintf(ctx.Stdout, "storage:         %q\n", result.Storage)
+	}
+	if result.Encrypted {
+		fmt.Fprintf(ctx.Stdout, "encrypted:       %v\n", result.Encrypted)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -110,6 +127,54 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
 }
 
+// readKeyFile returns the contents of the named file, which holds an
+// armored OpenPGP key. An empty filename gives an empty key.
+func readKeyFile(ctx *cmd.Context, filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// readPassphraseFile returns the passphrase held in the named file,
+// without any trailing newline. An empty filename gives an empty
+// passphrase.
+func readPassphraseFile(ctx *cmd.Context, filename string) (string, error) {
+	passphrase, err := readKeyFile(ctx, filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+	if filename != "" && passphrase == "" {
+		return "", errors.Errorf("passphrase file %q is empty", filename)
+	}
+	return passphrase, nil
+}
+
+// readDecryptionKey returns the key held in the given passphrase and
+// private key files, or nil if neither is given.
+func readDecryptionKey(ctx *cmd.Context, passphraseFile, privateKeyFile string) (*params.BackupDecryptionKey, error) {
+	if passphraseFile == "" && privateKeyFile == "" {
+		return nil, nil
+	}
+	passphrase, err := readPassphraseFile(ctx, passphraseFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	privateKey, err := readKeyFile(ctx, privateKeyFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.BackupDecryptionKey{
+		Passphrase: passphrase,
+		PrivateKey: privateKey,
+	}, nil
+}
+
 // ArchiveReader can read a backup archive.
 //
 // To regenerate the mocks for the ArchiveReader used by this package,
@@ -120,7 +185,10 @@ type ArchiveReader interface {
 	io.Closer
 }
 
-var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
+// getArchive opens the named backup archive and reads its metadata. If
+// the archive is encrypted, key must hold what is needed to decrypt it;
+// the archive itself is returned still encrypted.
+var getArchive = func(filename string, key *params.BackupDecryptionKey) (rc ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
 	defer func() {
 		if err != nil && rc != nil {
 			rc.Close()
@@ -132,8 +200,24 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 		return nil, nil, errors.Trace(err)
 	}
 
-	// Extract the metadata.
-	ad, err := statebackups.NewArchiveDataReader(archive)
+	// Extract the metadata, decrypting the archive to get at it.
+	encrypted, r, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		if key == nil {
+			return nil, nil, errors.Errorf("backup archive %q is encrypted: a decryption key is required", filename)
+		}
+		r, err = statebackups.DecryptArchive(r, statebackups.DecryptionKey{
+			Passphrase: key.Passphrase,
+			PrivateKey: key.PrivateKey,
+		})
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	ad, err := statebackups.NewArchiveDataReader(r)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -170,6 +254,7 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 	if meta.Finished == nil || meta.Finished.IsZero() {
 		meta.Finished = fileMeta.Finished
 	}
+	meta.Encrypted = encrypted
 	_, err = archive.Seek(0, os.SEEK_SET)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
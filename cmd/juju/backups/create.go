@@ -35,6 +35,18 @@ at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
 
+Use --storage to store the backup archive somewhere other than the storage
+given by the backup-storage controller config: "controller" for the
+controller's database, "file:///<dir>" for a directory on the controller
+machines, or "s3://<bucket>[/<prefix>]" for an S3 compatible object store.
+Backups in any storage can be listed, downloaded and removed as usual.
+
+Use --passphrase-file or --public-key to have the controller encrypt the
+backup archive before it is stored or downloaded. The archive is encrypted
+as an OpenPGP message, either with the passphrase held in the given file or
+for the armored OpenPGP public key held in the given file, and can only be
+restored with the same passphrase or the matching private key.
+
 Use --verbose to see extra information about backup.
 
 To access remote backups stored on the controller, see 'juju download-backup'.
@@ -44,6 +56,9 @@ Examples:
     juju create-backup --no-download
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
+    juju create-backup --no-download --storage s3://backups/juju
+    juju create-backup --passphrase-file ~/.juju-backup-passphrase
+    juju create-backup --public-key ~/backups.pub.asc
     juju create-backup --verbose
 
 See also:
@@ -67,7 +82,15 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
-	fs       *gnuflag.FlagSet
+	// Storage is the backup storage target to store the archive in.
+	Storage string
+	// PassphraseFile is the file holding the passphrase to encrypt
+	// the archive with.
+	PassphraseFile string
+	// PublicKeyFile is the file holding the public key to encrypt
+	// the archive for.
+	PublicKeyFile string
+	fs            *gnuflag.FlagSet
 }
 
 // Info implements Command.Info.
@@ -86,6 +109,9 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.Storage, "storage", "", "Store the archive in this backup storage target")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
+	f.StringVar(&c.PublicKeyFile, "public-key", "", "Encrypt the archive for the OpenPGP public key in this file")
 	c.fs = f
 }
 
@@ -119,6 +145,10 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+
+	if c.PassphraseFile != "" && c.PublicKeyFile != "" {
+		return errors.Errorf("cannot mix --passphrase-file and --public-key")
+	}
 	return nil
 }
 
@@ -136,6 +166,14 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.Storage != "" && apiVersion < 3 {
+		return errors.New("--storage is not supported by this controller")
+	}
+	encrypted := c.PassphraseFile != "" || c.PublicKeyFile != ""
+	if encrypted && apiVersion < 4 {
+		return errors.New("encrypted backups are not supported by this controller")
+	}
+
 	if apiVersion < 2 {
 		if c.KeepCopy {
 			return errors.New("--keep-copy is not supported by this controller")
@@ -149,7 +187,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.KeepCopy = true
 	}
 
-	metadataResult, copyFrom, err := c.create(client, apiVersion)
+	metadataResult, copyFrom, err := c.create(ctx, client, apiVersion)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -160,7 +198,9 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.dumpMetadata(ctx, metadataResult)
 	}
 
-	if c.KeepCopy {
+	if c.KeepCopy && metadataResult.Storage != "" {
+		ctx.Infof("Remote backup stored in %v as %v.", metadataResult.Storage, metadataResult.ID)
+	} else if c.KeepCopy {
 		ctx.Infof("Remote backup stored on the controller as %v.", metadataResult.ID)
 	} else {
 		ctx.Infof("Remote backup was not created.")
@@ -206,8 +246,23 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 	return nil
 }
 
-func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+func (c *createCommand) create(ctx *cmd.Context, client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
+	var result *params.BackupsMetadataResult
+	var err error
+	if c.PassphraseFile != "" || c.PublicKeyFile != "" {
+		var passphrase, publicKey string
+		if passphrase, err = readPassphraseFile(ctx, c.PassphraseFile); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		if publicKey, err = readKeyFile(ctx, c.PublicKeyFile); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		result, err = client.CreateEncrypted(c.Notes, c.Storage, c.KeepCopy, c.NoDownload, passphrase, publicKey)
+	} else if c.Storage != "" {
+		result, err = client.CreateOnStorage(c.Notes, c.Storage, c.KeepCopy, c.NoDownload)
+	} else {
+		result, err = client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	}
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -282,3 +283,56 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestStorage(c *gc.C) {
+	s.apiVersion = 3
+	s.metaresult.Storage = "file:///srv/backups"
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--storage", "file:///srv/backups")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateOnStorage")
+	client.CheckArgs(c, "", "file:///srv/backups", "true", "true")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `storage:         "file:///srv/backups"`)
+	expectedMsg := fmt.Sprintf("WARNING %v\nRemote backup stored in file:///srv/backups as %v.\n", backups.DownloadWarning, s.metaresult.ID)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, expectedMsg)
+}
+
+func (s *createSuite) TestStorageNotSupported(c *gc.C) {
+	client := s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--storage", "file:///srv/backups")
+
+	c.Assert(err, gc.ErrorMatches, "--storage is not supported by this controller")
+	client.CheckCalls(c)
+}
+
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	s.apiVersion = 4
+	s.metaresult.Encrypted = true
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateEncrypted")
+	client.CheckArgs(c, "", "", "true", "true", "sekrit", "")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "encrypted:       true")
+}
+
+func (s *createSuite) TestEncryptedNotSupported(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", "passphrase")
+
+	c.Assert(err, gc.ErrorMatches, "encrypted backups are not supported by this controller")
+	client.CheckCalls(c)
+}
+
+func (s *createSuite) TestPassphraseAndPublicKey(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--passphrase-file", "passphrase", "--public-key", "key.asc")
+
+	c.Check(err, gc.ErrorMatches, "cannot mix --passphrase-file and --public-key")
+}
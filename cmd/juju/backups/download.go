@@ -17,7 +17,8 @@ import (
 )
 
 const downloadDoc = `
-download-backup retrieves a backup archive file.
+download-backup retrieves a backup archive file, from whichever backup
+storage it was stored in.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
@@ -16,9 +16,10 @@ const (
 )
 
 var (
-	NewAPIClient = &newAPIClient
-	NewGetAPI    = &getAPI
-	GetArchive   = &getArchive
+	NewAPIClient  = &newAPIClient
+	NewGetAPI     = &getAPI
+	GetArchive    = &getArchive
+	VerifyArchive = &verifyArchive
 )
 
 type CreateCommand struct {
@@ -75,6 +76,13 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &verifyCommand{}
+	c.Log = &cmd.Log{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func NewRestoreCommandForTest(
 	store jujuclient.ClientStore,
 ) (cmd.Command, *RestoreCommand) {
@@ -121,6 +121,32 @@ func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreateEncrypted mocks base method
+func (m *MockAPIClient) CreateEncrypted(arg0, arg1 string, arg2, arg3 bool, arg4, arg5 string) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "CreateEncrypted", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*params.BackupsMetadataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEncrypted indicates an expected call of CreateEncrypted
+func (mr *MockAPIClientMockRecorder) CreateEncrypted(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEncrypted", reflect.TypeOf((*MockAPIClient)(nil).CreateEncrypted), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateOnStorage mocks base method
+func (m *MockAPIClient) CreateOnStorage(arg0, arg1 string, arg2, arg3 bool) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "CreateOnStorage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*params.BackupsMetadataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOnStorage indicates an expected call of CreateOnStorage
+func (mr *MockAPIClientMockRecorder) CreateOnStorage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOnStorage", reflect.TypeOf((*MockAPIClient)(nil).CreateOnStorage), arg0, arg1, arg2, arg3)
+}
+
 // Download mocks base method
 func (m *MockAPIClient) Download(arg0 string) (io.ReadCloser, error) {
 	ret := m.ctrl.Call(m, "Download", arg0)
@@ -178,27 +204,40 @@ func (mr *MockAPIClientMockRecorder) Remove(arg0 ...interface{}) *gomock.Call {
 }
 
 // Restore mocks base method
-func (m *MockAPIClient) Restore(arg0 string, arg1 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+func (m *MockAPIClient) Restore(arg0 string, arg1 *params.BackupDecryptionKey, arg2 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Restore indicates an expected call of Restore
-func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1)
+func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1, arg2)
 }
 
 // RestoreReader mocks base method
-func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2)
+func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 *params.BackupDecryptionKey, arg3 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RestoreReader indicates an expected call of RestoreReader
-func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
+func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2, arg3)
+}
+
+// RestoreModel mocks base method
+func (m *MockAPIClient) RestoreModel(arg0, arg1, arg2 string, arg3 bool, arg4 *params.BackupDecryptionKey) (params.RestoreModelResult, error) {
+	ret := m.ctrl.Call(m, "RestoreModel", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(params.RestoreModelResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreModel indicates an expected call of RestoreModel
+func (mr *MockAPIClientMockRecorder) RestoreModel(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreModel", reflect.TypeOf((*MockAPIClient)(nil).RestoreModel), arg0, arg1, arg2, arg3, arg4)
 }
 
 // Upload mocks base method
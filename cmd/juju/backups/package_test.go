@@ -174,6 +174,28 @@ func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params
 	return createResult, nil
 }
 
+func (c *fakeAPIClient) CreateOnStorage(notes, storage string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateOnStorage")
+	c.args = append(c.args, notes, storage, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
+	c.notes = notes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
+func (c *fakeAPIClient) CreateEncrypted(
+	notes, storage string, keepCopy, noDownload bool, passphrase, publicKey string,
+) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateEncrypted")
+	c.args = append(c.args, notes, storage, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload), passphrase, publicKey)
+	c.notes = notes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, id)
@@ -227,10 +249,14 @@ func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
-func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, *params.BackupDecryptionKey, apibackups.ClientConnection) error {
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, *params.BackupDecryptionKey, apibackups.ClientConnection) error {
 	return nil
 }
+
+func (c *fakeAPIClient) RestoreModel(string, string, string, bool, *params.BackupDecryptionKey) (params.RestoreModelResult, error) {
+	return params.RestoreModelResult{}, nil
+}
@@ -35,8 +35,16 @@ type restoreCommand struct {
 	CommandBase
 	getModelStatusAPI func() (ModelStatusAPI, error)
 
-	Filename string
-	BackupId string
+	Filename       string
+	BackupId       string
+	PassphraseFile string
+	PrivateKeyFile string
+
+	// Model, NewName and Replace are used to restore a single model
+	// from the backup, rather than the whole controller.
+	Model   string
+	NewName string
+	Replace bool
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -45,10 +53,12 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(backupId string, key *params.BackupDecryptionKey, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
-	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+	RestoreReader(
+		r io.ReadSeeker, meta *params.BackupsMetadataResult, key *params.BackupDecryptionKey, newClient backups.ClientConnection,
+	) error
 }
 
 // ModelStatusAPI is used to invoke common.ModelStatus
@@ -71,8 +81,29 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://docs.jujucharms.com/stable/controllers-backup for more information.
 
+To restore an encrypted backup, use --passphrase-file to give the file
+holding the passphrase it was encrypted with, or --private-key to give the
+file holding the armored OpenPGP private key it was encrypted for. If that
+private key is protected by a passphrase, give it with --passphrase-file too.
+
+To restore a single model from a backup held by the controller, leaving
+the rest of the controller as it is, give the model with --model, by name
+or UUID. The model is restored alongside any existing model if given a
+new name with --as, or replaces the existing model with --replace. A
+model no longer on the controller is restored as it was. The charms,
+agent binaries and resources used by the model are restored from the
+backup too. An existing model is only replaced once the backed up model
+has been imported successfully under a temporary name. A single model
+can be restored in an HA environment.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
+
+Examples:
+    juju restore-backup --id 20180613-150000.deadbeef-0bad-400d-8000-4b1d0d06f00d
+    juju restore-backup --file juju-backup-20180613-150000.tar.gz
+    juju restore-backup --id <ID> --model prod --as prod-yesterday
+    juju restore-backup --id <ID> --model prod --replace
 `
 
 // Info returns the content for --help.
@@ -90,6 +121,11 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Decrypt the backup with the passphrase in this file")
+	f.StringVar(&c.PrivateKeyFile, "private-key", "", "Decrypt the backup with the OpenPGP private key in this file")
+	f.StringVar(&c.Model, "model", "", "Restore only this model, given by name or UUID")
+	f.StringVar(&c.NewName, "as", "", "Restore the model with this new name")
+	f.BoolVar(&c.Replace, "replace", false, "Replace the existing model with the restored model")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -101,6 +137,18 @@ func (c *restoreCommand) Init(args []string) error {
 		return errors.Errorf("you must specify either a file or a backup id but not both.")
 	}
 
+	if c.Model == "" && (c.NewName != "" || c.Replace) {
+		return errors.New("--as and --replace can only be used with --model")
+	}
+	if c.Model != "" {
+		if c.Filename != "" {
+			return errors.New("restoring a single model needs a backup held by the controller: upload the file with juju upload-backup")
+		}
+		if c.NewName != "" && c.Replace {
+			return errors.New("cannot mix --as and --replace")
+		}
+	}
+
 	if c.Filename != "" {
 		var err error
 		c.Filename, err = filepath.Abs(c.Filename)
@@ -159,6 +207,10 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.Model != "" {
+		return errors.Trace(c.restoreModel(ctx))
+	}
+
 	// Don't allow restore in an HA environment
 	controllerModelUUID, modelStatus, err := c.modelStatus()
 	if err != nil {
@@ -169,6 +221,11 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 	}
 
+	key, err := readDecryptionKey(ctx, c.PassphraseFile, c.PrivateKeyFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.BackupId
@@ -176,7 +233,7 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		// Read archive specified by the Filename
 		target = c.Filename
 		var err error
-		archive, meta, err = getArchive(c.Filename)
+		archive, meta, err = getArchive(c.Filename, key)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -192,9 +249,9 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
 	if c.Filename != "" {
-		err = client.RestoreReader(archive, meta, c.newClient)
+		err = client.RestoreReader(archive, meta, key, c.newClient)
 	} else {
-		err = client.Restore(c.BackupId, c.newClient)
+		err = client.Restore(c.BackupId, key, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -202,3 +259,36 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// restoreModel restores the single model given by --model.
+func (c *restoreCommand) restoreModel(ctx *cmd.Context) error {
+	modelUUID := c.Model
+	if !names.IsValidModel(modelUUID) {
+		modelUUIDs, err := c.ModelUUIDs([]string{c.Model})
+		if err != nil {
+			return errors.Annotatef(err, "cannot get uuid of model %q", c.Model)
+		}
+		modelUUID = modelUUIDs[0]
+	}
+
+	key, err := readDecryptionKey(ctx, c.PassphraseFile, c.PrivateKeyFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, apiVersion, err := c.NewGetAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if apiVersion < 5 {
+		return errors.New("restoring a single model is not supported by this controller")
+	}
+
+	result, err := client.RestoreModel(c.BackupId, modelUUID, c.NewName, c.Replace, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "restored model %q (%s) from %q\n", result.Name, result.ModelUUID, c.BackupId)
+	return nil
+}
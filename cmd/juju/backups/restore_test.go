@@ -5,6 +5,7 @@ package backups_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 
 	"github.com/golang/mock/gomock"
@@ -78,7 +79,7 @@ func (s *restoreSuite) patch(c *gc.C, archiveErr error) (*gomock.Controller, *Mo
 	)
 	archiveClient := NewMockArchiveReader(ctrl)
 	s.PatchValue(backups.GetArchive,
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *params.BackupDecryptionKey) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return archiveClient, &params.BackupsMetadataResult{}, archiveErr
 		},
 	)
@@ -128,6 +129,26 @@ var testRestoreBackupArgParsing = []restoreBackupArgParsing{
 		args:     []string{"--id", "anid", "--file", "afile"},
 		errMatch: "you must specify either a file or a backup id but not both.",
 	},
+	{
+		title:    "as without model",
+		args:     []string{"--id", "anid", "--as", "restored"},
+		errMatch: "--as and --replace can only be used with --model",
+	},
+	{
+		title:    "model from file",
+		args:     []string{"--file", "afile", "--model", "test1"},
+		errMatch: "restoring a single model needs a backup held by the controller: upload the file with juju upload-backup",
+	},
+	{
+		title:    "model as and replace",
+		args:     []string{"--id", "anid", "--model", "test1", "--as", "restored", "--replace"},
+		errMatch: "cannot mix --as and --replace",
+	},
+	{
+		title: "model",
+		args:  []string{"--id", "anid", "--model", "test1", "--replace"},
+		id:    "anid",
+	},
 	{
 		title: "id",
 		args:  []string{"--id", "anid"},
@@ -161,7 +182,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilename(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, nil, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -178,7 +199,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilenameFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, nil, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -193,7 +214,7 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", nil, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -209,7 +230,7 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", nil, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -265,3 +286,66 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 }
+
+func (s *restoreSuite) TestRestoreFromBackupIdEncrypted(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	gomock.InOrder(
+		apiClient.EXPECT().Restore("an_id", &params.BackupDecryptionKey{Passphrase: "sekrit"}, gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) patchGetAPI(c *gc.C, apiVersion int) (*gomock.Controller, *MockAPIClient) {
+	ctrl := gomock.NewController(c)
+	apiClient := NewMockAPIClient(ctrl)
+	s.PatchValue(backups.NewGetAPI,
+		func(*backups.CommandBase) (backups.APIClient, int, error) {
+			return apiClient, apiVersion, nil
+		},
+	)
+	return ctrl, apiClient
+}
+
+func (s *restoreSuite) TestRestoreModelByName(c *gc.C) {
+	ctrl, apiClient := s.patchGetAPI(c, 5)
+	defer ctrl.Finish()
+	gomock.InOrder(
+		apiClient.EXPECT().RestoreModel("an_id", test1ModelUUID, "test1-restored", false, nil).Return(
+			params.RestoreModelResult{ModelUUID: "deadbeef-0bad-400d-8000-5b1d0d06f002", Name: "test1-restored"}, nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--id", "an_id", "--model", "test1", "--as", "test1-restored")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `restored model "test1-restored" (deadbeef-0bad-400d-8000-5b1d0d06f002) from "an_id"`+"\n")
+}
+
+func (s *restoreSuite) TestRestoreModelByUUIDReplace(c *gc.C) {
+	ctrl, apiClient := s.patchGetAPI(c, 5)
+	defer ctrl.Finish()
+	gomock.InOrder(
+		apiClient.EXPECT().RestoreModel("an_id", "deadbeef-0bad-400d-8000-5b1d0d06f003", "", true, nil).Return(
+			params.RestoreModelResult{ModelUUID: "deadbeef-0bad-400d-8000-5b1d0d06f003", Name: "gone"}, nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--id", "an_id", "--model", "deadbeef-0bad-400d-8000-5b1d0d06f003", "--replace")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestRestoreModelNotSupported(c *gc.C) {
+	ctrl, apiClient := s.patchGetAPI(c, 4)
+	defer ctrl.Finish()
+	apiClient.EXPECT().Close()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--id", "an_id", "--model", "test1", "--replace")
+	c.Assert(err, gc.ErrorMatches, "restoring a single model is not supported by this controller")
+}
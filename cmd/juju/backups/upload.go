@@ -66,7 +66,7 @@ func (c *uploadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	archive, meta, err := getArchive(c.Filename)
+	archive, meta, err := getArchive(c.Filename, nil)
 	if err != nil {
 		return errors.Trace(err)
 	}
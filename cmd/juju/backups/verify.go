@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/cmd/modelcmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks whether a backup archive can be restored, without
+restoring it. The archive is either a local file, given with --file, or
+a backup stored by the controller, given by its ID.
+
+The whole archive is read, and the command fails if:
+ - its size or checksum don't match those recorded by the controller,
+   or the checksum given with --checksum;
+ - it, or the files bundle in it, is corrupt or truncated;
+ - its database dump is missing the juju database, or any collection
+   in the dump is not well formed.
+Otherwise the models and versions held in the backup are reported.
+
+Archives stored by the controller are downloaded to be checked; the
+running controller is not otherwise affected.
+
+A local archive cannot record its own checksum, so the checksum of a
+file given with --file is only checked when the expected checksum, as
+reported by create-backup or show-backup, is given with --checksum.
+Otherwise a warning is shown that the checksum was not verified.
+
+To verify an encrypted backup, use --passphrase-file and --private-key
+as for restore-backup.
+
+Examples:
+    juju verify-backup --file juju-backup-20180613-150000.tar.gz
+    juju verify-backup --file juju-backup-20180613-150000.tar.gz \
+        --checksum 4uUwqaAg/g8pEDnCwS2vXmr3m0E=
+    juju verify-backup 20180613-150000.deadbeef-0bad-400d-8000-4b1d0d06f00d
+    juju verify-backup --file backup.tar.gz --private-key ~/backups.asc
+
+See also:
+    create-backup
+    restore-backup
+`
+
+// NewVerifyCommand returns a command used to verify backups.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup archive.
+type verifyCommand struct {
+	CommandBase
+	// ID is the backup ID to verify.
+	ID string
+	// Filename is the local backup archive to verify.
+	Filename string
+	// Checksum is the expected checksum of the local backup archive.
+	Checksum string
+	// PassphraseFile and PrivateKeyFile hold the key to decrypt
+	// an encrypted archive with.
+	PassphraseFile string
+	PrivateKeyFile string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-backup",
+		Args:    "[<ID>]",
+		Purpose: "Check that a backup archive can be restored.",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "file", "", "Verify this local backup archive")
+	f.StringVar(&c.Checksum, "checksum", "", "The expected checksum of the local backup archive")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Decrypt the backup with the passphrase in this file")
+	f.StringVar(&c.PrivateKeyFile, "private-key", "", "Decrypt the backup with the OpenPGP private key in this file")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	id, err := cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if id == "" && c.Filename == "" {
+		return errors.New("you must specify either a file or a backup id")
+	}
+	if id != "" && c.Filename != "" {
+		return errors.New("you must specify either a file or a backup id but not both")
+	}
+	if c.Checksum != "" && c.Filename == "" {
+		return errors.New("--checksum can only be used with --file")
+	}
+	c.ID = id
+	return nil
+}
+
+var verifyArchive = statebackups.VerifyArchive
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	var key *statebackups.DecryptionKey
+	paramsKey, err := readDecryptionKey(ctx, c.PassphraseFile, c.PrivateKeyFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if paramsKey != nil {
+		key = &statebackups.DecryptionKey{
+			Passphrase: paramsKey.Passphrase,
+			PrivateKey: paramsKey.PrivateKey,
+		}
+	}
+
+	var archive io.ReadCloser
+	var expected *statebackups.Metadata
+	if c.Filename != "" {
+		archive, err = os.Open(ctx.AbsPath(c.Filename))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.Checksum != "" {
+			expected = statebackups.NewMetadata()
+			if err := expected.SetFileInfo(0, c.Checksum, statebackups.ChecksumFormat); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer client.Close()
+
+		info, err := client.Info(c.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		expected = apiserverbackups.MetadataFromResult(*info)
+		archive, err = client.Download(c.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	defer archive.Close()
+
+	result, err := verifyArchive(archive, expected, key)
+	if err != nil {
+		return errors.Annotate(err, "backup archive cannot be restored")
+	}
+	c.dumpVerifyResult(ctx, result)
+	return nil
+}
+
+// dumpVerifyResult writes the formatted result of verifying a backup
+// archive to stdout.
+func (c *verifyCommand) dumpVerifyResult(ctx *cmd.Context, result *statebackups.VerifyResult) {
+	fmt.Fprintf(ctx.Stdout, "checksum:        %q\n", result.Checksum)
+	fmt.Fprintf(ctx.Stdout, "size (B):        %d\n", result.Size)
+	fmt.Fprintf(ctx.Stdout, "encrypted:       %v\n", result.Encrypted)
+	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	if meta := result.Metadata; meta != nil {
+		fmt.Fprintf(ctx.Stdout, "started:         %v\n", meta.Started)
+		fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", meta.Origin.Model)
+		fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", meta.Origin.Machine)
+		fmt.Fprintf(ctx.Stdout, "created on host: %q\n", meta.Origin.Hostname)
+	}
+	fmt.Fprintf(ctx.Stdout, "databases:       %d (%d collections)\n", len(result.Databases), result.Collections)
+	fmt.Fprintf(ctx.Stdout, "bundled files:   %d\n", result.Files)
+	fmt.Fprintf(ctx.Stdout, "models:\n")
+	for _, model := range result.Models {
+		agentVersion := model.AgentVersion
+		if agentVersion == "" {
+			agentVersion = "unknown"
+		}
+		fmt.Fprintf(ctx.Stdout, "  %s/%s (%s): %s\n", model.Owner, model.Name, model.UUID, agentVersion)
+	}
+	if !result.ChecksumVerified {
+		ctx.Warningf("backup archive checksum not verified: use --checksum to give the expected checksum")
+	}
+	ctx.Infof("Backup archive can be restored.")
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	subcommand cmd.Command
+
+	archive  string
+	expected *statebackups.Metadata
+	key      *statebackups.DecryptionKey
+	result   *statebackups.VerifyResult
+	err      error
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.subcommand = backups.NewVerifyCommandForTest(jujuclienttesting.MinimalStore())
+
+	s.archive, s.expected, s.key, s.err = "", nil, nil, nil
+	s.result = &statebackups.VerifyResult{
+		Size:        42,
+		Checksum:    "checksum",
+		Databases:   []string{"admin", "juju"},
+		Collections: 12,
+		Files:       3,
+		Models: []statebackups.VerifiedModel{{
+			UUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Name:         "controller",
+			Owner:        "admin",
+			AgentVersion: "2.4.1",
+		}},
+	}
+	s.PatchValue(backups.VerifyArchive,
+		func(r io.Reader, expected *statebackups.Metadata, key *statebackups.DecryptionKey) (*statebackups.VerifyResult, error) {
+			data, err := ioutil.ReadAll(r)
+			c.Assert(err, jc.ErrorIsNil)
+			s.archive, s.expected, s.key = string(data), expected, key
+			if s.err != nil {
+				return nil, s.err
+			}
+			return s.result, nil
+		},
+	)
+}
+
+func (s *verifySuite) TestArgParsing(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Check(err, gc.ErrorMatches, "you must specify either a file or a backup id")
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "spam", "--file", "backup.tar.gz")
+	c.Check(err, gc.ErrorMatches, "you must specify either a file or a backup id but not both")
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "spam", "eggs")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["eggs"\]`)
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "spam", "--checksum", "checksum")
+	c.Check(err, gc.ErrorMatches, "--checksum can only be used with --file")
+}
+
+func (s *verifySuite) TestVerifyFile(c *gc.C) {
+	client := s.setSuccess()
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("<archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "--file", filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c)
+	c.Check(s.archive, gc.Equals, "<archive>")
+	c.Check(s.expected, gc.IsNil)
+	c.Check(s.key, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+checksum:        "checksum"
+size (B):        42
+encrypted:       false
+juju version:    0.0.0
+databases:       2 (12 collections)
+bundled files:   3
+models:
+  admin/controller (deadbeef-0bad-400d-8000-4b1d0d06f00d): 2.4.1
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+WARNING backup archive checksum not verified: use --checksum to give the expected checksum
+Backup archive can be restored.
+`[1:])
+}
+
+func (s *verifySuite) TestVerifyFileChecksum(c *gc.C) {
+	s.setSuccess()
+	s.result.ChecksumVerified = true
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("<archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "--file", filename, "--checksum", "checksum")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.expected, gc.NotNil)
+	c.Check(s.expected.Checksum(), gc.Equals, "checksum")
+	c.Check(s.expected.ChecksumFormat(), gc.Equals, statebackups.ChecksumFormat)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Backup archive can be restored.\n")
+}
+
+func (s *verifySuite) TestVerifyFileCorrupt(c *gc.C) {
+	s.setSuccess()
+	s.PatchValue(backups.VerifyArchive, statebackups.VerifyArchive)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	// A gzip file truncated in its header.
+	err := ioutil.WriteFile(filename, []byte{0x1f, 0x8b, 0x08, 0x00}, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "--file", filename)
+	c.Check(err, gc.ErrorMatches, "backup archive cannot be restored: while uncompressing archive: unexpected EOF")
+}
+
+func (s *verifySuite) TestVerifyID(c *gc.C) {
+	s.metaresult.Checksum = "checksum"
+	s.metaresult.Size = 42
+	client := s.setDownload()
+
+	_, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Info", "Download")
+	client.CheckArgs(c, "spam", "spam")
+	c.Check(s.archive, gc.Equals, s.data)
+	c.Assert(s.expected, gc.NotNil)
+	c.Check(s.expected.Checksum(), gc.Equals, "checksum")
+	c.Check(s.expected.Size(), gc.Equals, int64(42))
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	s.setSuccess()
+	dir := c.MkDir()
+	filename := filepath.Join(dir, "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("<archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	passphraseFile := filepath.Join(dir, "passphrase")
+	err = ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "--file", filename, "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.key, jc.DeepEquals, &statebackups.DecryptionKey{Passphrase: "sekrit"})
+}
+
+func (s *verifySuite) TestVerifyFails(c *gc.C) {
+	s.setSuccess()
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("<archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.err = errors.New("backup archive has no juju database dump")
+
+	_, err = cmdtesting.RunCommand(c, s.subcommand, "--file", filename)
+	c.Check(err, gc.ErrorMatches, "backup archive cannot be restored: backup archive has no juju database dump")
+}
@@ -48,7 +48,7 @@ type listCommand struct {
 	apiFunc           func(newAPIRoot) (blockListAPI, error)
 	controllerAPIFunc func(newControllerAPIRoot) (controllerListAPI, error)
 	all               bool
-	out               cmd.Output
+	out               output.Output
 }
 
 // Init implements Command.Init.
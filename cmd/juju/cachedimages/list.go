@@ -43,7 +43,7 @@ func NewListCommand() cmd.Command {
 // listCommand shows the images in the Juju server.
 type listCommand struct {
 	CachedImagesCommandBase
-	out                cmd.Output
+	out                output.Output
 	Kind, Series, Arch string
 }
 
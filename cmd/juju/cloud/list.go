@@ -23,7 +23,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.cloud")
 
 type listCloudsCommand struct {
 	cmd.CommandBase
-	out cmd.Output
+	out output.Output
 }
 
 // listCloudsDoc is multi-line since we need to use ` to denote
@@ -70,7 +70,7 @@ See also:
 
 type listCredentialsCommand struct {
 	cmd.CommandBase
-	out         cmd.Output
+	out         output.Output
 	cloudName   string
 	showSecrets bool
 
@@ -18,7 +18,7 @@ import (
 
 type listRegionsCommand struct {
 	cmd.CommandBase
-	out       cmd.Output
+	out       output.Output
 	cloudName string
 }
 
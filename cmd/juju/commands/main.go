@@ -298,6 +298,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewStatusTimelineCommand())
+	r.Register(status.NewSaveStatusSnapshotCommand())
+	r.Register(status.NewDiffStatusCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -330,6 +333,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -371,13 +375,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewWaitCommand())
 
 	r.Register(newMigrateCommand())
+	r.Register(newMigrateModelsCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
 		r.Register(model.NewDumpDBCommand())
 	}
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
+	r.Register(model.NewImportModelCommand())
 
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
@@ -388,6 +396,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newRehearseFailoverCommand())
+	r.Register(newRemoveControllerMachineCommand())
 
 	// Manage and control applications
 	r.Register(application.NewAddUnitCommand())
@@ -442,6 +452,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewControllerStatusCommand())
 	r.Register(controller.NewConfigCommand())
 
 	// Debug Metrics
@@ -473,6 +484,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
 
+	// Manage application leadership
+	r.Register(application.NewTransferLeadershipCommand())
+	r.Register(application.NewPinLeadershipCommand())
+	r.Register(application.NewUnpinLeadershipCommand())
+
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
 	r.Register(gui.NewUpgradeGUICommand())
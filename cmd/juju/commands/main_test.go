@@ -421,6 +421,7 @@ var commandNames = []string{
 	"config",
 	"consume",
 	"controller-config",
+	"controller-status",
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
@@ -432,6 +433,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-status",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -441,6 +443,7 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -453,6 +456,7 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -484,6 +488,7 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-models",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -491,9 +496,11 @@ var commandNames = []string{
 	"offer",
 	"offers",
 	"payloads",
+	"pin-leadership",
 	"plans",
 	"regions",
 	"register",
+	"rehearse-failover",
 	"relate", //alias for add-relation
 	"reload-spaces",
 	"remove-application",
@@ -501,6 +508,7 @@ var commandNames = []string{
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-consumed-application",
+	"remove-controller-machine",
 	"remove-credential",
 	"remove-k8s",
 	"remove-machine",
@@ -520,6 +528,7 @@ var commandNames = []string{
 	"revoke",
 	"run",
 	"run-action",
+	"save-status-snapshot",
 	"scale-application",
 	"scp",
 	"set-constraints",
@@ -536,6 +545,7 @@ var commandNames = []string{
 	"show-backup",
 	"show-cloud",
 	"show-controller",
+	"show-controller-status",
 	"show-credential",
 	"show-credentials",
 	"show-machine",
@@ -543,6 +553,7 @@ var commandNames = []string{
 	"show-offer",
 	"show-status",
 	"show-status-log",
+	"show-status-timeline",
 	"show-storage",
 	"show-user",
 	"show-wallet",
@@ -558,8 +569,10 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"transfer-leadership",
 	"trust",
 	"unexpose",
+	"unpin-leadership",
 	"unregister",
 	"update-clouds",
 	"update-credential",
@@ -570,7 +583,9 @@ var commandNames = []string{
 	"upgrade-model",
 	"upload-backup",
 	"users",
+	"verify-backup",
 	"version",
+	"wait",
 	"wallets",
 	"whoami",
 }
@@ -4,13 +4,19 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 )
@@ -18,6 +24,7 @@ import (
 func newMigrateCommand() modelcmd.ModelCommand {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
 	return modelcmd.Wrap(&cmd, modelcmd.WrapSkipModelFlags)
 }
 
@@ -27,11 +34,19 @@ type migrateCommand struct {
 	modelcmd.IAASOnlyCommand
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
+	clock            clock.Clock
 	targetController string
+	dryRun           bool
+	rebuild          bool
+	region           string
+	credential       string
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) ([]string, error)
+	RebuildModel(spec controller.MigrationSpec, region, credential string) (string, error)
+	RebuildProgress(ids []string) ([]params.RebuildProgressResult, error)
 }
 
 const migrateDoc = `
@@ -57,6 +72,34 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, no migration is started. Instead, all of the checks
+made before a migration starts are run against the model and both
+controllers, the model is exported, and the target controller checks
+that it would accept the export. Every problem found is reported, not
+just the first, so that they can all be fixed before the migration is
+attempted.
+
+A migration hands the model's machines over to the target controller,
+so the target controller must manage the cloud the machines are in.
+With --rebuild, the model is instead rebuilt on the target controller's
+cloud: new machines are provisioned there, in the --region given or
+the target controller's own region, using the model owner's
+--credential on the target controller. The model's charms are deployed
+to them again with the same config, relations, leadership settings
+and resources. The rebuilt model is given a new UUID, so that it never
+claims the original model's machines. The rebuild runs on the
+controller, and the command reports its progress until the model has
+been rebuilt, which may take up to an hour. Interrupting the command
+aborts the rebuild, removing the partly rebuilt model from the target
+controller. The model is left as it is on the current controller;
+destroy it with "juju destroy-model" once the rebuilt model is
+running. Models using storage can not be rebuilt.
+
+Examples:
+    juju migrate prod target-controller
+    juju migrate --dry-run prod target-controller
+    juju migrate --rebuild --region us-west-2 --credential aws prod target-controller
+
 See also:
     login
     controllers
@@ -73,6 +116,15 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the migration would succeed, without starting it")
+	f.BoolVar(&c.rebuild, "rebuild", false, "Rebuild the model with new machines on the target controller's cloud")
+	f.StringVar(&c.region, "region", "", "The region to rebuild the model in")
+	f.StringVar(&c.credential, "credential", "", "The credential on the target controller to rebuild the model with")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -84,6 +136,12 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.rebuild && c.dryRun {
+		return errors.New("cannot use --dry-run with --rebuild")
+	}
+	if !c.rebuild && (c.region != "" || c.credential != "") {
+		return errors.New("--region and --credential can only be used with --rebuild")
+	}
 
 	c.SetModelName(args[0], false)
 	c.targetController = args[1]
@@ -91,14 +149,23 @@ func (c *migrateCommand) Init(args []string) error {
 }
 
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
-	store := c.ClientStore()
+	return getMigrationSpec(&c.CommandBase, c.ClientStore(), c.targetController, c.newAPIRoot)
+}
 
-	controllerInfo, err := store.ControllerByName(c.targetController)
+// getMigrationSpec returns a spec for migrating a model to the target
+// controller, which must be in the client store. ModelUUID is not set.
+func getMigrationSpec(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	targetController string,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+) (*controller.MigrationSpec, error) {
+	controllerInfo, err := store.ControllerByName(targetController)
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := store.AccountDetails(c.targetController)
+	accountInfo, err := store.AccountDetails(targetController)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +173,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	var macs []macaroon.Slice
 	if accountInfo.Password == "" {
 		var err error
-		macs, err = c.getTargetControllerMacaroons()
+		macs, err = getTargetControllerMacaroons(base, store, targetController, newAPIRoot)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -141,6 +208,12 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, modelName, *spec)
+	}
+	if c.rebuild {
+		return c.runRebuild(ctx, api, modelName, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +222,69 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runRebuild(ctx *cmd.Context, api migrateAPI, modelName string, spec controller.MigrationSpec) error {
+	ctx.Infof("Rebuilding model %q on controller %q", modelName, c.targetController)
+	id, err := api.RebuildModel(spec, c.region, c.credential)
+	if err != nil {
+		return err
+	}
+
+	// The rebuild belongs to this command's API connection, and is
+	// aborted by the controller when the connection closes.
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var status string
+	for {
+		results, err := api.RebuildProgress([]string{id})
+		if err == nil && len(results) != 1 {
+			err = errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if err == nil && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil {
+			return errors.Annotate(err, "checking rebuild progress")
+		}
+		progress := results[0]
+		if progress.StatusMessage != status && progress.StatusMessage != "" {
+			status = progress.StatusMessage
+			ctx.Infof("%s", status)
+		}
+		if progress.Done {
+			if progress.Failure != "" {
+				return errors.Errorf("rebuilding model %q failed: %s", modelName, progress.Failure)
+			}
+			break
+		}
+		select {
+		case <-interrupted:
+			return errors.New("interrupted: rebuild aborted")
+		case <-c.clock.After(migrationPollInterval):
+		}
+	}
+	ctx.Infof("Model %q rebuilt on controller %q, where its machines are being provisioned.", modelName, c.targetController)
+	ctx.Infof("The model remains on this controller; destroy it once the rebuilt model is running.")
+	return nil
+}
+
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, modelName string, spec controller.MigrationSpec) error {
+	problems, err := api.MigrationDryRun(spec)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		ctx.Infof("Migration of model %q to controller %q would succeed", modelName, c.targetController)
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(ctx.Stdout, problem)
+	}
+	return errors.Errorf("migration of model %q to controller %q would fail: %d problem(s) found",
+		modelName, c.targetController, len(problems))
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -160,8 +296,13 @@ func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	return controller.NewClient(apiRoot), nil
 }
 
-func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
-	jar, err := c.CommandBase.CookieJar(c.ClientStore(), c.targetController)
+func getTargetControllerMacaroons(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	targetController string,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+) ([]macaroon.Slice, error) {
+	jar, err := base.CookieJar(store, targetController)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -171,7 +312,7 @@ func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error
 	//
 	// TODO(axw,mjs) add a controller API that returns a macaroon that
 	// may be used for the sole purpose of migration.
-	api, err := c.newAPIRoot(c.ClientStore(), c.targetController, "")
+	api, err := newAPIRoot(store, targetController, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "connecting to target controller")
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -74,7 +76,16 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.api = &fakeMigrateAPI{}
+	s.api = &fakeMigrateAPI{
+		rebuildProgress: []params.RebuildProgressResult{{
+			StatusMessage: "importing model into target controller",
+		}, {
+			StatusMessage: "activating model on target controller",
+		}, {
+			StatusMessage: "activating model on target controller",
+			Done:          true,
+		}},
+	}
 	s.modelAPI = &fakeModelAPI{
 		models: []base.UserModel{{
 			Name:  "model",
@@ -181,6 +192,100 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of model \"model\" to controller \"target\" would succeed\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.dryRunSeen, jc.IsTrue)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.dryRunProblems = []string{
+		"source: machine 0 not running (stopped)",
+		"target: model with same UUID already exists (" + modelUUID + ")",
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration of model "model" to controller "target" would fail: 2 problem\(s\) found`)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"source: machine 0 not running (stopped)\n"+
+		"target: model with same UUID already exists ("+modelUUID+")\n")
+}
+
+func (s *MigrateSuite) TestDryRunError(c *gc.C) {
+	s.api.dryRunErr = errors.NotSupportedf("migration dry run on this controller version")
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "migration dry run on this controller version not supported")
+}
+
+func (s *MigrateSuite) TestRebuild(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--rebuild", "--region", "west", "--credential", "cred", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Rebuilding model \"model\" on controller \"target\"\n"+
+		"importing model into target controller\n"+
+		"activating model on target controller\n"+
+		"Model \"model\" rebuilt on controller \"target\", where its machines are being provisioned.\n"+
+		"The model remains on this controller; destroy it once the rebuilt model is running.\n")
+	c.Check(s.api.rebuildSeen, jc.DeepEquals, []string{"west", "cred"})
+	c.Check(s.api.progressSeen, jc.DeepEquals, []string{"42", "42", "42"})
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestRebuildError(c *gc.C) {
+	s.api.rebuildErr = errors.New("rebuilding models with storage not supported")
+	_, err := s.makeAndRun(c, "--rebuild", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "rebuilding models with storage not supported")
+}
+
+func (s *MigrateSuite) TestRebuildFails(c *gc.C) {
+	s.api.rebuildProgress = []params.RebuildProgressResult{{
+		StatusMessage: "importing model into target controller",
+	}, {
+		StatusMessage: "importing model into target controller",
+		Done:          true,
+		Failure:       "importing model into target controller: boom",
+	}}
+	_, err := s.makeAndRun(c, "--rebuild", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `rebuilding model "model" failed: importing model into target controller: boom`)
+}
+
+func (s *MigrateSuite) TestRebuildProgressError(c *gc.C) {
+	s.api.rebuildProgress = []params.RebuildProgressResult{{
+		Error: &params.Error{Message: `rebuild "42" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.makeAndRun(c, "--rebuild", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `checking rebuild progress: rebuild "42" not found`)
+}
+
+func (s *MigrateSuite) TestRebuildInitErrors(c *gc.C) {
+	_, err := s.makeAndRun(c, "--rebuild", "--dry-run", "model", "target")
+	c.Check(err, gc.ErrorMatches, "cannot use --dry-run with --rebuild")
+	_, err = s.makeAndRun(c, "--region", "west", "model", "target")
+	c.Check(err, gc.ErrorMatches, "--region and --credential can only be used with --rebuild")
+	_, err = s.makeAndRun(c, "--credential", "cred", "model", "target")
+	c.Check(err, gc.ErrorMatches, "--region and --credential can only be used with --rebuild")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	_, err := cmdtesting.RunCommand(c, cmd, "wat", "target")
@@ -229,6 +334,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	cmd.SetModelAPI(s.modelAPI)
 	inner := modelcmd.InnerCommand(cmd).(*migrateCommand)
 	inner.api = s.api
+	inner.clock = instantClock{}
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
@@ -236,7 +342,14 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen        *controller.MigrationSpec
+	dryRunSeen      bool
+	dryRunProblems  []string
+	dryRunErr       error
+	rebuildSeen     []string
+	rebuildErr      error
+	rebuildProgress []params.RebuildProgressResult
+	progressSeen    []string
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +357,31 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	a.dryRunSeen = true
+	return a.dryRunProblems, a.dryRunErr
+}
+
+func (a *fakeMigrateAPI) RebuildModel(spec controller.MigrationSpec, region, credential string) (string, error) {
+	a.specSeen = &spec
+	a.rebuildSeen = []string{region, credential}
+	if a.rebuildErr != nil {
+		return "", a.rebuildErr
+	}
+	return "42", nil
+}
+
+func (a *fakeMigrateAPI) RebuildProgress(ids []string) ([]params.RebuildProgressResult, error) {
+	a.progressSeen = append(a.progressSeen, ids...)
+	progress := a.rebuildProgress[0]
+	if len(a.rebuildProgress) > 1 {
+		a.rebuildProgress = a.rebuildProgress[1:]
+	}
+	progress.RebuildId = ids[0]
+	return []params.RebuildProgressResult{progress}, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -0,0 +1,378 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+)
+
+// migrationPollInterval is how often the progress of running
+// migrations is checked.
+const migrationPollInterval = 5 * time.Second
+
+func newMigrateModelsCommand() modelcmd.ControllerCommand {
+	var cmd migrateModelsCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
+	return modelcmd.WrapController(&cmd)
+}
+
+// migrateModelsCommand migrates many models to another controller.
+type migrateModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	api        migrateModelsAPI
+	clock      clock.Clock
+
+	targetController string
+	models           []string
+	owner            string
+	parallel         int
+}
+
+type migrateModelsAPI interface {
+	BestAPIVersion() int
+	AllModels() ([]base.UserModel, error)
+	ModelConfig() (map[string]interface{}, error)
+	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationProgress(ids []string) ([]params.MigrationProgressResult, error)
+	Close() error
+}
+
+const migrateModelsDoc = `
+migrate-models migrates a number of hosted models from the current
+controller to another controller, and waits for the migrations to
+finish. The models are either named, or are all of the models owned
+by a user given with --owner. The controller model is never migrated.
+
+No more than --parallel migrations run at once; the next model's
+migration is started as soon as one finishes. The progress of each
+migration is reported as it passes through its phases, and once all
+of the migrations have finished a summary lists the models that could
+not be migrated, and why. A model whose migration fails stays on the
+current controller, as it would with "juju migrate".
+
+The target controller must be in the juju client's local configuration
+cache. See the juju "login" command for details of how to do this.
+
+Interrupting the command stops it from starting more migrations, but
+does not stop those already running: the command waits for them to
+finish, unless it is interrupted again. The summary then lists the
+migrations that were not started, and any that are still running.
+
+Examples:
+    juju migrate-models target-controller prod staging
+    juju migrate-models --owner bob --parallel 4 target-controller
+
+See also:
+    migrate
+    login
+    controllers
+`
+
+// Info implements cmd.Command.
+func (c *migrateModelsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "migrate-models",
+		Args:    "<target-controller-name> [<model-name> ...]",
+		Purpose: "Migrate many hosted models to another controller.",
+		Doc:     migrateModelsDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "Migrate all of the models owned by this user")
+	f.IntVar(&c.parallel, "parallel", 2, "The most migrations to run at once")
+}
+
+// Init implements cmd.Command.
+func (c *migrateModelsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target controller not specified")
+	}
+	c.targetController, c.models = args[0], args[1:]
+	switch {
+	case c.owner == "" && len(c.models) == 0:
+		return errors.New("no models specified: name the models to migrate, or use --owner")
+	case c.owner != "" && len(c.models) != 0:
+		return errors.New("cannot name models and use --owner")
+	case c.owner != "" && !names.IsValidUser(c.owner):
+		return errors.NotValidf("user %q", c.owner)
+	case c.parallel < 1:
+		return errors.New("--parallel must be at least 1")
+	}
+	return nil
+}
+
+// modelMigration tracks the migration of one model.
+type modelMigration struct {
+	name  string
+	uuid  string
+	id    string
+	phase string
+	err   error
+}
+
+// Run implements cmd.Command.
+func (c *migrateModelsCommand) Run(ctx *cmd.Context) error {
+	spec, err := getMigrationSpec(&c.CommandBase, c.ClientStore(), c.targetController, c.newAPIRoot)
+	if err != nil {
+		return err
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 8 {
+		return errors.New("migrating many models is not supported by this controller")
+	}
+
+	pending, err := c.selectModels(client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	return c.migrateModels(ctx, client, spec, pending, interrupted)
+}
+
+// migrateModels migrates the pending models, no more than c.parallel
+// at once, until they have all finished or the command is interrupted.
+func (c *migrateModelsCommand) migrateModels(
+	ctx *cmd.Context,
+	client migrateModelsAPI,
+	spec *controller.MigrationSpec,
+	pending []*modelMigration,
+	interrupted <-chan os.Signal,
+) error {
+	total := len(pending)
+	var stopping bool
+	var active, finished, notStarted []*modelMigration
+	for len(pending) > 0 || len(active) > 0 {
+		for len(active) < c.parallel && len(pending) > 0 {
+			mig := pending[0]
+			pending = pending[1:]
+			spec.ModelUUID = mig.uuid
+			mig.id, mig.err = client.InitiateMigration(*spec)
+			if mig.err != nil {
+				ctx.Infof("%s: migration not started: %v", mig.name, mig.err)
+				finished = append(finished, mig)
+				continue
+			}
+			ctx.Infof("%s: migration started with ID %q", mig.name, mig.id)
+			active = append(active, mig)
+		}
+		if len(active) == 0 {
+			continue
+		}
+
+		select {
+		case <-interrupted:
+			if stopping {
+				c.summarize(ctx, total, finished, active, notStarted)
+				return errors.New("interrupted while migrations are running")
+			}
+			stopping = true
+			ctx.Infof("interrupted: no more migrations will be started; " +
+				"waiting for running migrations to finish (interrupt again to stop waiting)")
+			notStarted, pending = pending, nil
+			continue
+		case <-c.clock.After(migrationPollInterval):
+		}
+		ids := make([]string, len(active))
+		for i, mig := range active {
+			ids[i] = mig.id
+		}
+		progress, err := client.MigrationProgress(ids)
+		if err == nil && len(progress) != len(ids) {
+			err = errors.Errorf("expected %d results, got %d", len(ids), len(progress))
+		}
+		if err != nil {
+			c.summarize(ctx, total, finished, active, append(notStarted, pending...))
+			return errors.Annotate(err, "checking migration progress")
+		}
+		var stillActive []*modelMigration
+		for i, mig := range active {
+			if c.updateProgress(ctx, mig, progress[i]) {
+				finished = append(finished, mig)
+			} else {
+				stillActive = append(stillActive, mig)
+			}
+		}
+		active = stillActive
+	}
+	failed := c.summarize(ctx, total, finished, nil, notStarted)
+	switch {
+	case failed > 0:
+		return errors.Errorf("%d of %d model migrations failed", failed, total)
+	case len(notStarted) > 0:
+		return errors.Errorf("interrupted: %d of %d model migrations not started", len(notStarted), total)
+	}
+	return nil
+}
+
+// updateProgress reports any change in the progress of a migration,
+// and returns whether it has finished.
+func (c *migrateModelsCommand) updateProgress(ctx *cmd.Context, mig *modelMigration, progress params.MigrationProgressResult) bool {
+	if progress.Error != nil {
+		mig.err = errors.Annotate(progress.Error, "checking migration progress")
+		ctx.Infof("%s: %v", mig.name, mig.err)
+		return true
+	}
+	if progress.Phase != mig.phase {
+		mig.phase = progress.Phase
+		if progress.StatusMessage != "" {
+			ctx.Infof("%s: %s (%s)", mig.name, progress.Phase, progress.StatusMessage)
+		} else {
+			ctx.Infof("%s: %s", mig.name, progress.Phase)
+		}
+	}
+	phase, ok := coremigration.ParsePhase(progress.Phase)
+	if !ok || !phase.IsTerminal() {
+		return false
+	}
+	if phase == coremigration.ABORTDONE {
+		reason := progress.StatusMessage
+		if reason == "" {
+			reason = "migration aborted"
+		}
+		mig.err = errors.New(reason)
+	}
+	return true
+}
+
+// summarize writes a summary of the migrations to stdout, and returns
+// the number of migrations that failed.
+func (c *migrateModelsCommand) summarize(ctx *cmd.Context, total int, finished, running, notStarted []*modelMigration) int {
+	var failed []*modelMigration
+	for _, mig := range finished {
+		if mig.err != nil {
+			failed = append(failed, mig)
+		}
+	}
+	migrated := len(finished) - len(failed)
+	fmt.Fprintf(ctx.Stdout, "Migrated %d of %d models to controller %q\n", migrated, total, c.targetController)
+	if len(failed) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Failed:")
+		for _, mig := range failed {
+			fmt.Fprintf(ctx.Stdout, "  %s: %v\n", mig.name, mig.err)
+		}
+	}
+	if len(running) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Still running:")
+		for _, mig := range running {
+			fmt.Fprintf(ctx.Stdout, "  %s: migration %q\n", mig.name, mig.id)
+		}
+	}
+	if len(notStarted) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Not started:")
+		for _, mig := range notStarted {
+			fmt.Fprintf(ctx.Stdout, "  %s\n", mig.name)
+		}
+	}
+	return len(failed)
+}
+
+// selectModels returns the models to migrate, in the order given, or
+// sorted by name if they are selected by owner. Naming the controller
+// model is an error.
+func (c *migrateModelsCommand) selectModels(client migrateModelsAPI) ([]*modelMigration, error) {
+	allModels, err := client.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string]base.UserModel)
+	for _, model := range allModels {
+		byName[jujuclient.JoinOwnerModelName(names.NewUserTag(model.Owner), model.Name)] = model
+	}
+
+	controllerModelUUID, err := controllerModelUUID(client)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var selected []*modelMigration
+	if c.owner != "" {
+		owner := names.NewUserTag(c.owner)
+		for _, model := range allModels {
+			if model.Owner != owner.Id() || model.UUID == controllerModelUUID {
+				continue
+			}
+			selected = append(selected, &modelMigration{
+				name: jujuclient.JoinOwnerModelName(owner, model.Name),
+				uuid: model.UUID,
+			})
+		}
+		if len(selected) == 0 {
+			return nil, errors.Errorf("user %q owns no models to migrate", c.owner)
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].name < selected[j].name
+		})
+		return selected, nil
+	}
+
+	accountDetails, err := c.CurrentAccountDetails()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	currentUser := names.NewUserTag(accountDetails.User)
+	for _, name := range c.models {
+		if !jujuclient.IsQualifiedModelName(name) {
+			name = jujuclient.JoinOwnerModelName(currentUser, name)
+		}
+		model, ok := byName[name]
+		if !ok {
+			return nil, errors.NotFoundf("model %q", name)
+		}
+		if model.UUID == controllerModelUUID {
+			return nil, errors.Errorf("cannot migrate the controller model %q", name)
+		}
+		selected = append(selected, &modelMigration{name: name, uuid: model.UUID})
+	}
+	return selected, nil
+}
+
+// controllerModelUUID returns the UUID of the controller model, which
+// is never migrated.
+func controllerModelUUID(client migrateModelsAPI) (string, error) {
+	cfg, err := client.ModelConfig()
+	if err != nil {
+		return "", errors.Annotate(err, "getting controller model config")
+	}
+	uuid, _ := cfg["uuid"].(string)
+	if uuid == "" {
+		return "", errors.New("controller model config has no uuid")
+	}
+	return uuid, nil
+}
+
+func (c *migrateModelsCommand) getAPI() (migrateModelsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
@@ -0,0 +1,407 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type MigrateModelsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeMigrateModelsAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&MigrateModelsSuite{})
+
+func (s *MigrateModelsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.AddController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", jujuclient.AccountDetails{
+		User: "sourceuser",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.AddController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", jujuclient.AccountDetails{
+		User:     "targetuser",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeMigrateModelsAPI{
+		version: 8,
+		models: []base.UserModel{
+			{Name: "controller", UUID: "controller-uuid", Type: model.IAAS, Owner: "admin"},
+			{Name: "prod", UUID: "prod-uuid", Type: model.IAAS, Owner: "sourceuser"},
+			{Name: "staging", UUID: "staging-uuid", Type: model.IAAS, Owner: "sourceuser"},
+			{Name: "web", UUID: "web-uuid", Type: model.IAAS, Owner: "bob"},
+			{Name: "db", UUID: "db-uuid", Type: model.IAAS, Owner: "bob"},
+			{Name: "cache", UUID: "cache-uuid", Type: model.IAAS, Owner: "bob"},
+		},
+		startErrs: make(map[string]error),
+		progress:  make(map[string][]params.MigrationProgressResult),
+	}
+}
+
+func (s *MigrateModelsSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := newMigrateModelsCommand()
+	command.SetClientStore(s.store)
+	inner := modelcmd.InnerCommand(command).(*migrateModelsCommand)
+	inner.api = s.api
+	inner.clock = instantClock{}
+	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
+		return nil, errors.New("unexpected connection to target controller")
+	}
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *MigrateModelsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "target controller not specified",
+	}, {
+		args: []string{"target"},
+		err:  "no models specified: name the models to migrate, or use --owner",
+	}, {
+		args: []string{"--owner", "bob", "target", "prod"},
+		err:  "cannot name models and use --owner",
+	}, {
+		args: []string{"--owner", "not/valid", "target"},
+		err:  `user "not/valid" not valid`,
+	}, {
+		args: []string{"--parallel", "0", "target", "prod"},
+		err:  "--parallel must be at least 1",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.makeAndRun(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MigrateModelsSuite) TestMigrateNamedModels(c *gc.C) {
+	s.api.progress["prod-uuid"] = []params.MigrationProgressResult{
+		{Phase: "IMPORT", StatusMessage: "uploading model binaries into target controller"},
+		{Phase: "DONE"},
+	}
+	s.api.progress["staging-uuid"] = []params.MigrationProgressResult{
+		{Phase: "DONE"},
+	}
+
+	ctx, err := s.makeAndRun(c, "--parallel", "1", "target", "prod", "sourceuser/staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-uuid", "staging-uuid"})
+	c.Check(s.api.maxActive, gc.Equals, 1)
+	c.Check(s.api.specs[0], jc.DeepEquals, controller.MigrationSpec{
+		ModelUUID:            "prod-uuid",
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"sourceuser/prod: migration started with ID \"prod-uuid:0\"\n"+
+		"sourceuser/prod: IMPORT (uploading model binaries into target controller)\n"+
+		"sourceuser/prod: DONE\n"+
+		"sourceuser/staging: migration started with ID \"staging-uuid:0\"\n"+
+		"sourceuser/staging: DONE\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "Migrated 2 of 2 models to controller \"target\"\n")
+}
+
+func (s *MigrateModelsSuite) TestMigrateByOwner(c *gc.C) {
+	s.api.startErrs["db-uuid"] = errors.New("source prechecks failed: model is dying")
+	s.api.progress["cache-uuid"] = []params.MigrationProgressResult{
+		{Phase: "QUIESCE"},
+		{Phase: "IMPORT"},
+		{Phase: "DONE"},
+	}
+	s.api.progress["web-uuid"] = []params.MigrationProgressResult{
+		{Phase: "QUIESCE"},
+		{Phase: "ABORTDONE", StatusMessage: "aborted: validation failed"},
+	}
+
+	ctx, err := s.makeAndRun(c, "--owner", "bob", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 3 model migrations failed")
+
+	// The models are migrated in name order, and the controller model
+	// is left alone.
+	c.Check(s.api.attempted, jc.DeepEquals, []string{"cache-uuid", "db-uuid", "web-uuid"})
+	c.Check(s.api.maxActive, gc.Equals, 2)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migrated 1 of 3 models to controller \"target\"\n"+
+		"Failed:\n"+
+		"  bob/db: source prechecks failed: model is dying\n"+
+		"  bob/web: aborted: validation failed\n")
+}
+
+func (s *MigrateModelsSuite) TestMigrateByOwnerSkipsOnlyControllerModel(c *gc.C) {
+	// Only the controller model is left alone, not every
+	// model that happens to be named "controller".
+	s.api.models = append(s.api.models, base.UserModel{
+		Name: "controller", UUID: "bob-controller-uuid", Type: model.IAAS, Owner: "bob",
+	})
+
+	_, err := s.makeAndRun(c, "--owner", "bob", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.attempted, jc.DeepEquals, []string{"cache-uuid", "bob-controller-uuid", "db-uuid", "web-uuid"})
+
+	_, err = s.makeAndRun(c, "--owner", "admin", "target")
+	c.Assert(err, gc.ErrorMatches, `user "admin" owns no models to migrate`)
+}
+
+func (s *MigrateModelsSuite) TestNamedControllerModel(c *gc.C) {
+	_, err := s.makeAndRun(c, "target", "prod", "admin/controller")
+	c.Assert(err, gc.ErrorMatches, `cannot migrate the controller model "admin/controller"`)
+	c.Check(s.api.attempted, gc.HasLen, 0)
+}
+
+func (s *MigrateModelsSuite) TestModelNotFound(c *gc.C) {
+	_, err := s.makeAndRun(c, "target", "prod", "nope")
+	c.Assert(err, gc.ErrorMatches, `model "sourceuser/nope" not found`)
+	c.Check(s.api.attempted, gc.HasLen, 0)
+}
+
+func (s *MigrateModelsSuite) TestOwnerHasNoModels(c *gc.C) {
+	_, err := s.makeAndRun(c, "--owner", "alice", "target")
+	c.Assert(err, gc.ErrorMatches, `user "alice" owns no models to migrate`)
+}
+
+func (s *MigrateModelsSuite) TestOlderController(c *gc.C) {
+	s.api.version = 7
+	_, err := s.makeAndRun(c, "target", "prod")
+	c.Assert(err, gc.ErrorMatches, "migrating many models is not supported by this controller")
+	c.Check(s.api.attempted, gc.HasLen, 0)
+}
+
+func (s *MigrateModelsSuite) TestProgressError(c *gc.C) {
+	s.api.startErrs["staging-uuid"] = errors.New("model is dying")
+	s.api.progressErr = errors.New("boom")
+	ctx, err := s.makeAndRun(c, "--parallel", "1", "target", "staging", "prod", "bob/web")
+	c.Assert(err, gc.ErrorMatches, "checking migration progress: boom")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migrated 0 of 3 models to controller \"target\"\n"+
+		"Failed:\n"+
+		"  sourceuser/staging: model is dying\n"+
+		"Still running:\n"+
+		"  sourceuser/prod: migration \"prod-uuid:0\"\n"+
+		"Not started:\n"+
+		"  bob/web\n")
+}
+
+func (s *MigrateModelsSuite) TestProgressResultsMismatch(c *gc.C) {
+	s.api.progressShort = true
+	ctx, err := s.makeAndRun(c, "target", "prod")
+	c.Assert(err, gc.ErrorMatches, "checking migration progress: expected 1 results, got 0")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migrated 0 of 1 models to controller \"target\"\n"+
+		"Still running:\n"+
+		"  sourceuser/prod: migration \"prod-uuid:0\"\n")
+}
+
+// runInterrupted migrates the named models as the command does,
+// calling interrupt before each check of the migrations' progress.
+func (s *MigrateModelsSuite) runInterrupted(c *gc.C, interrupt func(call int) bool, names ...string) (*cmd.Context, error) {
+	interrupted := make(chan os.Signal, 1)
+	s.api.onProgress = func(call int) {
+		if interrupt(call) {
+			interrupted <- os.Interrupt
+		}
+	}
+	command := &migrateModelsCommand{
+		api:              s.api,
+		clock:            interruptClock{interrupted: interrupted},
+		targetController: "target",
+		parallel:         1,
+	}
+	var pending []*modelMigration
+	for _, name := range names {
+		pending = append(pending, &modelMigration{name: "sourceuser/" + name, uuid: name + "-uuid"})
+	}
+	ctx := cmdtesting.Context(c)
+	err := command.migrateModels(ctx, s.api, &controller.MigrationSpec{}, pending, interrupted)
+	return ctx, err
+}
+
+func (s *MigrateModelsSuite) TestInterruptWaitsForRunningMigrations(c *gc.C) {
+	s.api.progress["prod-uuid"] = []params.MigrationProgressResult{
+		{Phase: "QUIESCE"},
+		{Phase: "IMPORT"},
+	}
+	ctx, err := s.runInterrupted(c, func(call int) bool { return call == 0 }, "prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"sourceuser/prod: migration started with ID \"prod-uuid:0\"\n"+
+		"sourceuser/prod: QUIESCE\n"+
+		"interrupted: no more migrations will be started; waiting for running migrations to finish (interrupt again to stop waiting)\n"+
+		"sourceuser/prod: IMPORT\n"+
+		"sourceuser/prod: DONE\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "Migrated 1 of 1 models to controller \"target\"\n")
+}
+
+func (s *MigrateModelsSuite) TestInterruptStopsStartingMigrations(c *gc.C) {
+	s.api.progress["prod-uuid"] = []params.MigrationProgressResult{
+		{Phase: "QUIESCE"},
+	}
+	ctx, err := s.runInterrupted(c, func(call int) bool { return call == 0 }, "prod", "staging")
+	c.Assert(err, gc.ErrorMatches, "interrupted: 1 of 2 model migrations not started")
+	c.Check(s.api.attempted, jc.DeepEquals, []string{"prod-uuid"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migrated 1 of 2 models to controller \"target\"\n"+
+		"Not started:\n"+
+		"  sourceuser/staging\n")
+}
+
+func (s *MigrateModelsSuite) TestInterruptTwiceStopsWaiting(c *gc.C) {
+	s.api.progress["prod-uuid"] = []params.MigrationProgressResult{
+		{Phase: "QUIESCE"},
+		{Phase: "IMPORT"},
+	}
+	ctx, err := s.runInterrupted(c, func(call int) bool { return call < 2 }, "prod")
+	c.Assert(err, gc.ErrorMatches, "interrupted while migrations are running")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migrated 0 of 1 models to controller \"target\"\n"+
+		"Still running:\n"+
+		"  sourceuser/prod: migration \"prod-uuid:0\"\n")
+}
+
+type fakeMigrateModelsAPI struct {
+	version     int
+	models      []base.UserModel
+	startErrs   map[string]error
+	progress    map[string][]params.MigrationProgressResult
+	progressErr error
+	// progressShort makes MigrationProgress return no results.
+	progressShort bool
+	// onProgress is called with the number of earlier calls
+	// at the start of each call to MigrationProgress.
+	onProgress    func(call int)
+	progressCalls int
+
+	specs     []controller.MigrationSpec
+	attempted []string
+	started   []string
+	active    int
+	maxActive int
+}
+
+func (a *fakeMigrateModelsAPI) BestAPIVersion() int {
+	return a.version
+}
+
+func (a *fakeMigrateModelsAPI) AllModels() ([]base.UserModel, error) {
+	return a.models, nil
+}
+
+func (a *fakeMigrateModelsAPI) ModelConfig() (map[string]interface{}, error) {
+	return map[string]interface{}{"uuid": "controller-uuid"}, nil
+}
+
+func (a *fakeMigrateModelsAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
+	a.specs = append(a.specs, spec)
+	a.attempted = append(a.attempted, spec.ModelUUID)
+	if err := a.startErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
+	a.started = append(a.started, spec.ModelUUID)
+	a.active++
+	if a.active > a.maxActive {
+		a.maxActive = a.active
+	}
+	return spec.ModelUUID + ":0", nil
+}
+
+// MigrationProgress reports the next progress queued for each
+// migration, finishing it with DONE once its queue is empty.
+func (a *fakeMigrateModelsAPI) MigrationProgress(ids []string) ([]params.MigrationProgressResult, error) {
+	if a.onProgress != nil {
+		a.onProgress(a.progressCalls)
+	}
+	a.progressCalls++
+	if a.progressErr != nil {
+		return nil, a.progressErr
+	}
+	if a.progressShort {
+		return nil, nil
+	}
+	results := make([]params.MigrationProgressResult, len(ids))
+	for i, id := range ids {
+		uuid := strings.TrimSuffix(id, ":0")
+		result := params.MigrationProgressResult{Phase: "DONE"}
+		if queue := a.progress[uuid]; len(queue) > 0 {
+			result, a.progress[uuid] = queue[0], queue[1:]
+		}
+		result.MigrationId = id
+		if result.Phase == "DONE" || result.Phase == "ABORTDONE" {
+			a.active--
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (a *fakeMigrateModelsAPI) Close() error {
+	return nil
+}
+
+// instantClock is a clock.Clock whose After fires immediately.
+type instantClock struct {
+	clock.Clock
+}
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+// interruptClock is a clock.Clock whose After fires immediately,
+// unless an interrupt is waiting to be handled.
+type interruptClock struct {
+	clock.Clock
+	interrupted chan os.Signal
+}
+
+func (c interruptClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	if len(c.interrupted) == 0 {
+		ch <- time.Now()
+	}
+	return ch
+}
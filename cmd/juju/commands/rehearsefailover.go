@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func newRehearseFailoverCommand() cmd.Command {
+	command := &rehearseFailoverCommand{}
+	command.newClientFunc = func() (RehearseFailoverClient, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return highavailability.NewClient(root), nil
+	}
+	return modelcmd.WrapController(command)
+}
+
+// rehearseFailoverCommand demotes the controller's mongo primary and
+// raft leader, and reports how long the controllers take to recover.
+type rehearseFailoverCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	// newClientFunc returns the client used by the command.
+	newClientFunc func() (RehearseFailoverClient, error)
+
+	mongo   bool
+	raft    bool
+	timeout time.Duration
+}
+
+const rehearseFailoverDoc = `
+Rehearses the failure of the controller machine currently leading a
+highly available controller, to validate that the controller recovers.
+
+The mongo primary is asked to step down, so that the other members of
+the replica set elect a new primary. The raft leader is asked to step
+down in the same way, so that the other controllers elect a new leader.
+The command waits for each to recover, and reports the new leaders and
+how long the election took.
+
+Failover is only rehearsed when there are at least 3 voting members and
+all of them are healthy, so that the controller can't lose quorum: each
+mongo voter must be healthy, and each raft voter must be following the
+current leader. Clients connected to the controllers may see errors
+while the election is held.
+
+By default both mongo and raft failover are rehearsed; use --mongo or
+--raft to rehearse only one of them.
+
+Examples:
+    juju rehearse-failover
+    juju rehearse-failover --raft --timeout 2m
+
+See also:
+    enable-ha
+`
+
+// Info implements Command.
+func (c *rehearseFailoverCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rehearse-failover",
+		Purpose: "Demotes the controller's leaders to rehearse failover.",
+		Doc:     rehearseFailoverDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *rehearseFailoverCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.mongo, "mongo", false, "Rehearse failover of the mongo primary")
+	f.BoolVar(&c.raft, "raft", false, "Rehearse failover of the raft leader")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait for each service to recover")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFailoverTabular,
+	})
+}
+
+// Init implements Command.
+func (c *rehearseFailoverCommand) Init(args []string) error {
+	if c.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if !c.mongo && !c.raft {
+		c.mongo = true
+		c.raft = true
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// RehearseFailoverClient defines the methods on the high
+// availability API that the rehearse-failover command calls.
+type RehearseFailoverClient interface {
+	Close() error
+	RehearseFailover(stepDownMongo, stepDownRaft bool, timeout time.Duration) ([]params.FailoverResult, error)
+}
+
+type failoverInfo struct {
+	Service      string `yaml:"service" json:"service"`
+	OldLeader    string `yaml:"old-leader,omitempty" json:"old-leader,omitempty"`
+	NewLeader    string `yaml:"new-leader,omitempty" json:"new-leader,omitempty"`
+	RecoveryTime string `yaml:"recovery-time,omitempty" json:"recovery-time,omitempty"`
+	Error        string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatFailoverTabular(writer io.Writer, value interface{}) error {
+	infos, ok := value.([]failoverInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Service\tOld leader\tNew leader\tRecovery time")
+	for _, info := range infos {
+		recovery := info.RecoveryTime
+		if info.Error != "" {
+			recovery = "failed: " + info.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Service, info.OldLeader, info.NewLeader, recovery)
+	}
+	return tw.Flush()
+}
+
+// Run implements Command.
+func (c *rehearseFailoverCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClientFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx.Infof("Rehearsing controller failover, this may take up to %v per service", c.timeout)
+	results, err := client.RehearseFailover(c.mongo, c.raft, c.timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed []string
+	infos := make([]failoverInfo, len(results))
+	for i, result := range results {
+		infos[i] = failoverInfo{
+			Service:   result.Service,
+			OldLeader: result.OldLeader,
+			NewLeader: result.NewLeader,
+		}
+		if result.Error != nil {
+			infos[i].Error = result.Error.Error()
+			failed = append(failed, result.Service)
+			continue
+		}
+		infos[i].RecoveryTime = result.RecoveryTime.String()
+	}
+	if err := c.out.Write(ctx, infos); err != nil {
+		return errors.Trace(err)
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failover rehearsal failed for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
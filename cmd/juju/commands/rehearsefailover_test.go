@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RehearseFailoverSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake  *fakeFailoverClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RehearseFailoverSuite{})
+
+func (s *RehearseFailoverSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeFailoverClient{
+		results: []params.FailoverResult{{
+			Service:      "mongo",
+			OldLeader:    "0",
+			NewLeader:    "1",
+			RecoveryTime: 12 * time.Second,
+		}, {
+			Service:      "raft",
+			OldLeader:    "1",
+			NewLeader:    "2",
+			RecoveryTime: 1500 * time.Millisecond,
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *RehearseFailoverSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &rehearseFailoverCommand{
+		newClientFunc: func() (RehearseFailoverClient, error) { return s.fake, nil },
+	}
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *RehearseFailoverSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, "foo")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+	_, err = s.run(c, "--timeout", "0s")
+	c.Check(err, gc.ErrorMatches, "timeout must be positive")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *RehearseFailoverSuite) TestRehearseFailover(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"RehearseFailover", []interface{}{true, true, 5 * time.Minute}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Service  Old leader  New leader  Recovery time\n"+
+		"mongo    0           1           12s\n"+
+		"raft     1           2           1.5s\n")
+}
+
+func (s *RehearseFailoverSuite) TestRehearseRaftFailover(c *gc.C) {
+	_, err := s.run(c, "--raft", "--timeout", "2m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "RehearseFailover", false, true, 2*time.Minute)
+}
+
+func (s *RehearseFailoverSuite) TestRehearseFailoverYAML(c *gc.C) {
+	ctx, err := s.run(c, "--mongo", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- service: mongo\n"+
+		"  old-leader: \"0\"\n"+
+		"  new-leader: \"1\"\n"+
+		"  recovery-time: 12s\n"+
+		"- service: raft\n"+
+		"  old-leader: \"1\"\n"+
+		"  new-leader: \"2\"\n"+
+		"  recovery-time: 1.5s\n")
+}
+
+func (s *RehearseFailoverSuite) TestRehearseFailoverServiceFails(c *gc.C) {
+	s.fake.results[0] = params.FailoverResult{
+		Service: "mongo",
+		Error:   &params.Error{Message: "mongo replica set has 1 voting members, need at least 3"},
+	}
+	ctx, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "failover rehearsal failed for mongo")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Service  Old leader  New leader  Recovery time\n"+
+		"mongo                            failed: mongo replica set has 1 voting members, need at least 3\n"+
+		"raft     1           2           1.5s\n")
+}
+
+func (s *RehearseFailoverSuite) TestRehearseFailoverNotSupported(c *gc.C) {
+	s.fake.SetErrors(errors.NotSupportedf("rehearsing failover"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "rehearsing failover not supported")
+}
+
+type fakeFailoverClient struct {
+	jujutesting.Stub
+	results []params.FailoverResult
+}
+
+func (f *fakeFailoverClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeFailoverClient) RehearseFailover(stepDownMongo, stepDownRaft bool, timeout time.Duration) ([]params.FailoverResult, error) {
+	f.MethodCall(f, "RehearseFailover", stepDownMongo, stepDownRaft, timeout)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newRemoveControllerMachineCommand() cmd.Command {
+	command := &removeControllerMachineCommand{}
+	command.newClientFunc = func() (RemoveControllerClient, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return highavailability.NewClient(root), nil
+	}
+	return modelcmd.WrapController(command)
+}
+
+// removeControllerMachineCommand decommissions a controller machine.
+type removeControllerMachineCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	// newClientFunc returns the client used by the command.
+	newClientFunc func() (RemoveControllerClient, error)
+
+	machineId     string
+	noReplacement bool
+}
+
+const removeControllerMachineDoc = `
+Removes a specific machine from a highly available controller, such as
+one that has failed or is to be replaced.
+
+If the machine is a voting member of the controller, another controller
+machine is given its vote: a non-voting controller machine is promoted
+if there is one, otherwise a new controller machine is added with the
+same series and constraints. The machine is then removed from the
+controller's mongo replica set and raft cluster, and finally the
+machine itself is removed.
+
+To shrink the controller instead, use --no-replacement: no controller
+machine takes the removed machine's vote. At least one other controller
+machine must be voting, and if an even number of them would be left,
+one is demoted so that the number of voters stays odd.
+
+The machine must not host any units or containers, and must not be the
+only controller machine.
+
+Examples:
+    juju remove-controller-machine 1
+    juju remove-controller-machine --no-replacement 2
+
+See also:
+    enable-ha
+    remove-machine
+`
+
+// Info implements Command.
+func (c *removeControllerMachineCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-controller-machine",
+		Args:    "<machine id>",
+		Purpose: "Removes a machine from a highly available controller.",
+		Doc:     removeControllerMachineDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *removeControllerMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
+		"yaml":   cmd.FormatYaml,
+		"json":   cmd.FormatJson,
+		"simple": formatSimple,
+	})
+	f.BoolVar(&c.noReplacement, "no-replacement", false, "Shrink the controller rather than replace the machine's vote")
+}
+
+// Init implements Command.
+func (c *removeControllerMachineCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine specified")
+	}
+	if !names.IsValidMachine(args[0]) {
+		return errors.Errorf("invalid machine id %q", args[0])
+	}
+	if names.IsContainerMachine(args[0]) {
+		return errors.Errorf("machine %s is a container, not a controller machine", args[0])
+	}
+	c.machineId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// RemoveControllerClient defines the methods on the high availability
+// API that the remove-controller-machine command calls.
+type RemoveControllerClient interface {
+	Close() error
+	RemoveController(machineId string, replace bool) (params.ControllersChanges, error)
+}
+
+// Run implements Command.
+func (c *removeControllerMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClientFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	changes, err := client.RemoveController(c.machineId, !c.noReplacement)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	result := availabilityInfo{
+		Added:    machineTagsToIds(changes.Added...),
+		Removed:  machineTagsToIds(changes.Removed...),
+		Promoted: machineTagsToIds(changes.Promoted...),
+	}
+	return c.out.Write(ctx, result)
+}
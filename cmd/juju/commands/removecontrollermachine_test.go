@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoveControllerMachineSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake  *fakeRemoveControllerClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RemoveControllerMachineSuite{})
+
+func (s *RemoveControllerMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRemoveControllerClient{
+		changes: params.ControllersChanges{
+			Removed: []string{"machine-1"},
+			Added:   []string{"machine-3"},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *RemoveControllerMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &removeControllerMachineCommand{
+		newClientFunc: func() (RemoveControllerClient, error) { return s.fake, nil },
+	}
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *RemoveControllerMachineSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine specified",
+	}, {
+		args: []string{"foo"},
+		err:  `invalid machine id "foo"`,
+	}, {
+		args: []string{"1/lxd/0"},
+		err:  "machine 1/lxd/0 is a container, not a controller machine",
+	}, {
+		args: []string{"1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachine(c *gc.C) {
+	ctx, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveController", []interface{}{"1", true}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"adding machines: 3\n"+
+		"removing machines: 1\n")
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachineNoReplacement(c *gc.C) {
+	s.fake.changes = params.ControllersChanges{
+		Removed: []string{"machine-1"},
+		Demoted: []string{"machine-2"},
+	}
+	ctx, err := s.run(c, "--no-replacement", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveController", []interface{}{"1", false}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"removing machines: 1\n"+
+		"demoting machines: 2\n")
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachineYAML(c *gc.C) {
+	s.fake.changes = params.ControllersChanges{
+		Removed:  []string{"machine-1"},
+		Promoted: []string{"machine-2"},
+	}
+	ctx, err := s.run(c, "1", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"removed: [\"1\"]\n"+
+		"promoted: [\"2\"]\n")
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachineFails(c *gc.C) {
+	s.fake.SetErrors(errors.New("machine 1 is the only controller machine"))
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, "machine 1 is the only controller machine")
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachineBlocked(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestRemoveControllerMachineBlocked"))
+	_, err := s.run(c, "1")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestRemoveControllerMachineBlocked.*")
+}
+
+type fakeRemoveControllerClient struct {
+	jujutesting.Stub
+	changes params.ControllersChanges
+}
+
+func (f *fakeRemoveControllerClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeRemoveControllerClient) RemoveController(machineId string, replace bool) (params.ControllersChanges, error) {
+	f.MethodCall(f, "RemoveController", machineId, replace)
+	if err := f.NextErr(); err != nil {
+		return params.ControllersChanges{}, err
+	}
+	return f.changes, nil
+}
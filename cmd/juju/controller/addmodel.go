@@ -93,6 +93,13 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+When adding a model to a Kubernetes cloud, the model's resources are
+created in a namespace with the same name as the model. An existing
+namespace can be adopted by setting "adopt-namespace=true"; this fails
+if the namespace does not exist or already holds resources managed by
+Juju. Setting "preserve-namespace=true" leaves the namespace intact when
+the model is destroyed, removing only the resources created by Juju.
+
 Examples:
 
     juju add-model mymodel
@@ -100,6 +107,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model team-a k8s-cloud --config adopt-namespace=true --config preserve-namespace=true
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+)
+
+const controllerStatusDoc = `
+Lists the units and machines, across all of the models on the controller,
+that need attention: workloads that are in error or blocked, unit and
+machine agents that are in error, and machines that failed to provision.
+
+Controller admins see every model on the controller. Other users see the
+models they have access to.
+
+Units whose agents have lost contact with the controller are not listed;
+use 'juju status' on their model to see those.
+
+Examples:
+
+    juju show-controller-status
+    juju show-controller-status --format yaml
+
+See also:
+    models
+    show-status
+`
+
+// NewControllerStatusCommand returns a command that lists the
+// units and machines that need attention across a controller.
+func NewControllerStatusCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerStatusCommand{})
+}
+
+// ControllerStatusAPI defines the methods on the model manager API
+// that the show-controller-status command calls.
+type ControllerStatusAPI interface {
+	Close() error
+	ListUnhealthyEntities(user string, all bool) ([]params.UnhealthyEntity, error)
+}
+
+type controllerStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out       output.Output
+	exactTime bool
+	api       ControllerStatusAPI
+}
+
+// Info implements Command.Info.
+func (c *controllerStatusCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-controller-status",
+		Purpose: "Lists the units and machines that need attention across a controller.",
+		Doc:     controllerStatusDoc,
+		Aliases: []string{"controller-status"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.exactTime, "exact-time", false, "Use full timestamps")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerStatusTabular,
+	})
+}
+
+func (c *controllerStatusCommand) getAPI() (ControllerStatusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// UnhealthyEntity describes a unit or machine status that needs attention.
+type UnhealthyEntity struct {
+	Model   string `yaml:"model" json:"model"`
+	Unit    string `yaml:"unit,omitempty" json:"unit,omitempty"`
+	Machine string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Kind    string `yaml:"kind" json:"kind"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *controllerStatusCommand) Run(ctx *cmd.Context) error {
+	accountDetails, err := c.CurrentAccountDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.ListUnhealthyEntities(accountDetails.User, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	entities := make([]UnhealthyEntity, 0, len(results))
+	for _, result := range results {
+		entity, err := c.unhealthyEntityFromParams(result, now)
+		if err != nil {
+			ctx.Infof(err.Error())
+			continue
+		}
+		entities = append(entities, entity)
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].Model < entities[j].Model
+	})
+	if len(entities) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No units or machines need attention.")
+		return nil
+	}
+	return c.out.Write(ctx, entities)
+}
+
+func (c *controllerStatusCommand) unhealthyEntityFromParams(result params.UnhealthyEntity, now time.Time) (UnhealthyEntity, error) {
+	owner, err := names.ParseUserTag(result.ModelOwnerTag)
+	if err != nil {
+		return UnhealthyEntity{}, errors.Annotate(err, "while parsing model owner tag")
+	}
+	tag, err := names.ParseTag(result.Tag)
+	if err != nil {
+		return UnhealthyEntity{}, errors.Annotate(err, "while parsing entity tag")
+	}
+	entity := UnhealthyEntity{
+		Model:   jujuclient.JoinOwnerModelName(owner, result.ModelName),
+		Kind:    entityStatusKind(status.HistoryKind(result.Kind)),
+		Status:  string(result.Status.Status),
+		Message: result.Status.Info,
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		entity.Unit = tag.Id()
+	case names.MachineTag:
+		entity.Machine = tag.Id()
+	default:
+		return UnhealthyEntity{}, errors.NotValidf("entity %q", result.Tag)
+	}
+	if result.Status.Since != nil {
+		if c.exactTime {
+			entity.Since = result.Status.Since.String()
+		} else {
+			entity.Since = common.UserFriendlyDuration(*result.Status.Since, now)
+		}
+	}
+	return entity, nil
+}
+
+// entityStatusKind returns a user facing description of the
+// kind of status.
+func entityStatusKind(kind status.HistoryKind) string {
+	switch kind {
+	case status.KindWorkload:
+		return "workload"
+	case status.KindUnitAgent, status.KindMachine, status.KindContainer:
+		return "agent"
+	case status.KindMachineInstance, status.KindContainerInstance:
+		return "instance"
+	}
+	return string(kind)
+}
+
+func formatControllerStatusTabular(writer io.Writer, value interface{}) error {
+	entities, ok := value.([]UnhealthyEntity)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entities, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Unit/Machine", "Kind", "Status", "Since", "Message")
+	for _, entity := range entities {
+		name := entity.Unit
+		if name == "" {
+			name = entity.Machine
+		}
+		w.Print(entity.Model, name, entity.Kind)
+		w.PrintStatus(status.Status(entity.Status))
+		w.Println(entity.Since, entity.Message)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ControllerStatusSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeControllerStatusAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ControllerStatusSuite{})
+
+type fakeControllerStatusAPI struct {
+	gitjujutesting.Stub
+	entities []params.UnhealthyEntity
+}
+
+func (f *fakeControllerStatusAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeControllerStatusAPI) ListUnhealthyEntities(user string, all bool) ([]params.UnhealthyEntity, error) {
+	f.MethodCall(f, "ListUnhealthyEntities", user, all)
+	return f.entities, f.NextErr()
+}
+
+func (s *ControllerStatusSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{
+		User:     "admin",
+		Password: "password",
+	}
+
+	since := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeControllerStatusAPI{
+		entities: []params.UnhealthyEntity{{
+			ModelUUID:     "model-2-uuid",
+			ModelName:     "staging",
+			ModelOwnerTag: "user-bob",
+			Tag:           "machine-3",
+			Kind:          string(status.KindMachineInstance),
+			Status: params.EntityStatus{
+				Status: status.ProvisioningError,
+				Info:   "no capacity",
+				Since:  &since,
+			},
+		}, {
+			ModelUUID:     "model-1-uuid",
+			ModelName:     "prod",
+			ModelOwnerTag: "user-admin",
+			Tag:           "unit-mysql-0",
+			Kind:          string(status.KindWorkload),
+			Status: params.EntityStatus{
+				Status: status.Blocked,
+				Info:   "missing relation",
+				Since:  &since,
+			},
+		}},
+	}
+}
+
+func (s *ControllerStatusSuite) run(c *gc.C, args ...string) (string, string, error) {
+	command := controller.NewControllerStatusCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, args...)
+	if err != nil {
+		return "", "", err
+	}
+	return cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), nil
+}
+
+func (s *ControllerStatusSuite) TestControllerStatusYaml(c *gc.C) {
+	stdout, _, err := s.run(c, "--format", "yaml", "--exact-time")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, ""+
+		"- model: admin/prod\n"+
+		"  unit: mysql/0\n"+
+		"  kind: workload\n"+
+		"  status: blocked\n"+
+		"  message: missing relation\n"+
+		"  since: 2018-05-01 10:00:00 +0000 UTC\n"+
+		"- model: bob/staging\n"+
+		"  machine: \"3\"\n"+
+		"  kind: instance\n"+
+		"  status: provisioning error\n"+
+		"  message: no capacity\n"+
+		"  since: 2018-05-01 10:00:00 +0000 UTC\n")
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ListUnhealthyEntities", []interface{}{"admin", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *ControllerStatusSuite) TestControllerStatusTabular(c *gc.C) {
+	stdout, _, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Matches, `(?s)Model +Unit/Machine +Kind +Status +Since +Message
+admin/prod +mysql/0 +workload +blocked +.* +missing relation
+bob/staging +3 +instance +provisioning error +.* +no capacity
+`)
+}
+
+func (s *ControllerStatusSuite) TestControllerStatusNothingUnhealthy(c *gc.C) {
+	s.api.entities = nil
+	stdout, stderr, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, "")
+	c.Assert(stderr, gc.Equals, "No units or machines need attention.\n")
+}
+
+func (s *ControllerStatusSuite) TestControllerStatusError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, _, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -189,3 +189,11 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewControllerStatusCommandForTest returns a show-controller-status
+// command with the API and client store provided as specified.
+func NewControllerStatusCommandForTest(api ControllerStatusAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerStatusCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/jujuclient"
@@ -202,7 +203,7 @@ func ControllerMachineCounts(controllerModelUUID string, modelStatusResults []ba
 type listControllersCommand struct {
 	modelcmd.CommandBase
 
-	out     cmd.Output
+	out     output.Output
 	store   jujuclient.ClientStore
 	api     func(controllerName string) ControllerAccessAPI
 	refresh bool
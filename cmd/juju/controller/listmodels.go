@@ -43,7 +43,7 @@ type ModelManagerAPI interface {
 // current user can access on the current controller.
 type modelsCommand struct {
 	modelcmd.ControllerCommandBase
-	out          cmd.Output
+	out          output.Output
 	all          bool
 	loggedInUser string
 	user         string
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/bootstrap"
@@ -88,6 +89,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	BackupStatus() (params.ControllerBackupStatus, error)
 	Close() error
 }
 
@@ -164,6 +166,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		}
 
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatusResults)
+		c.convertBackupStatusForShow(client, &details)
 		controllers[controllerName] = details
 		machineCount := 0
 		for _, r := range modelStatusResults {
@@ -234,6 +237,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// LastBackup is when the most recent successful backup of the controller finished.
+	LastBackup string `yaml:"last-backup,omitempty" json:"last-backup,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -372,6 +378,22 @@ func (c *showControllerCommand) convertAccountsForShow(controllerName string, co
 	controller.Account = details
 }
 
+func (c *showControllerCommand) convertBackupStatusForShow(client ControllerAccessAPI, controller *ShowControllerDetails) {
+	backupStatus, err := client.BackupStatus()
+	if errors.IsNotSupported(err) || params.IsCodeUnauthorized(err) {
+		// Older controllers don't report backups, and
+		// only controller admins can see them.
+		return
+	}
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	if backupStatus.LastSuccessful != nil {
+		controller.LastBackup = common.FormatTime(backupStatus.LastSuccessful, true)
+	}
+}
+
 func (c *showControllerCommand) convertModelsForShow(
 	controllerName string,
 	controller *ShowControllerDetails,
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -365,6 +367,27 @@ func (s *ShowControllerSuite) TestShowControllerRefreshesStoreModels(c *gc.C) {
 	})
 }
 
+func (s *ShowControllerSuite) TestShowControllerLastBackup(c *gc.C) {
+	s.createTestClientStore(c)
+	finished := time.Date(2018, time.June, 13, 2, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = params.ControllerBackupStatus{
+		LastSuccessful:   &finished,
+		LastSuccessfulID: "20180613-020000.abc",
+	}
+	context, err := s.runShowController(c, "mallards")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), jc.Contains, "  last-backup: 2018-06-13 02:00:00Z\n")
+}
+
+func (s *ShowControllerSuite) TestShowControllerBackupStatusNotSupported(c *gc.C) {
+	s.createTestClientStore(c)
+	s.fakeController.backupErr = errors.NotSupportedf("backup status on this controller version")
+	context, err := s.runShowController(c, "mallards")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Not(jc.Contains), "last-backup")
+	c.Assert(cmdtesting.Stdout(context), gc.Not(jc.Contains), "errors")
+}
+
 func (s *ShowControllerSuite) runShowController(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, controller.NewShowControllerCommandForTest(s.store, s.api), args...)
 }
@@ -383,6 +406,8 @@ func (s *ShowControllerSuite) assertShowController(c *gc.C, args ...string) {
 type fakeController struct {
 	controllerName string
 	machines       map[string][]base.Machine
+	backupStatus   params.ControllerBackupStatus
+	backupErr      error
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -422,6 +447,10 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 	return all, nil
 }
 
+func (c *fakeController) BackupStatus() (params.ControllerBackupStatus, error) {
+	return c.backupStatus, c.backupErr
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
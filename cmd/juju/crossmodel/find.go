@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
 )
 
@@ -40,7 +41,7 @@ type findCommand struct {
 	offerName      string
 	interfaceName  string
 
-	out        cmd.Output
+	out        output.Output
 	newAPIFunc func(string) (FindAPI, error)
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/applicationoffers"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/jujuclient"
 )
@@ -57,7 +58,7 @@ See also:
 type listCommand struct {
 	modelcmd.ModelCommandBase
 
-	out cmd.Output
+	out output.Output
 
 	newAPIFunc    func() (ListAPI, error)
 	refreshModels func(jujuclient.ClientStore, string) error
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/firewallrules"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 var listRulesHelpSummary = `
@@ -44,7 +45,7 @@ func NewListFirewallRulesCommand() cmd.Command {
 type listFirewallRulesCommand struct {
 	modelcmd.ModelCommandBase
 	modelcmd.IAASOnlyCommand
-	out cmd.Output
+	out output.Output
 
 	newAPIFunc func() (ListFirewallRulesAPI, error)
 }
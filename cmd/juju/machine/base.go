@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // statusAPI defines the API methods for the machines and show-machine commands.
@@ -30,7 +31,7 @@ type baseMachinesCommand struct {
 // baseMachineCommand provides access to information about machines in a model.
 type baselistMachinesCommand struct {
 	baseMachinesCommand
-	out           cmd.Output
+	out           output.Output
 	isoTime       bool
 	api           statusAPI
 	machineIds    []string
@@ -6,6 +6,7 @@ package model
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/api"
@@ -76,6 +77,24 @@ func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientS
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportModelCommandForTest returns an exportModelCommand with the api provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{newAPIFunc: func() (ExportModelAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportModelCommandForTest returns an importModelCommand with the api provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importModelCommand{newAPIFunc: func() (ImportModelAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the api provided as specified.
 func NewDestroyCommandForTest(
 	api DestroyModelAPI,
@@ -140,3 +159,10 @@ func NewModelGetConstraintsCommandForTest() cmd.Command {
 }
 
 var GetBudgetAPIClient = &getBudgetAPIClient
+
+// NewWaitCommandForTest returns a WaitCommand with the api and clock provided as specified.
+func NewWaitCommandForTest(api WaitAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &waitCommand{api: api, clock: clock}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
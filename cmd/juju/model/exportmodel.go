@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+// NewExportModelCommand returns a fully constructed export-model
+// command.
+func NewExportModelCommand() cmd.Command {
+	cmd := &exportModelCommand{}
+	cmd.newAPIFunc = func() (ExportModelAPI, error) {
+		return cmd.getAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (ExportModelAPI, error)
+	filename   string
+}
+
+const exportModelHelpDoc = `
+Writes the model, along with the charms, agent binaries and resources
+it uses, to a single archive file. The archive holds everything needed
+to recreate the model, so it can be carried to a controller that has
+no network route to this one and imported there with "juju
+import-model".
+
+The model on this controller is not changed. The archive is not
+encrypted, and holds the model's config and any secrets in it.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m prod prod.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<filename>",
+		Purpose: "Exports a model to a portable archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("archive filename must be specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ExportModelAPI specifies the API calls export-model uses to read
+// a model and its binaries from the controller.
+type ExportModelAPI interface {
+	Close() error
+	DumpModel(names.ModelTag, bool) (map[string]interface{}, error)
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	modelManager, err := c.NewModelManagerAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		modelManager.Close()
+		return nil, err
+	}
+	return &exportModelAPI{Client: modelManager, client: client}, nil
+}
+
+// exportModelAPI combines the controller's ModelManager facade, used
+// to export the model, with the model's API client, used to download
+// its binaries.
+type exportModelAPI struct {
+	*modelmanager.Client
+	client *api.Client
+}
+
+// OpenCharm is part of ExportModelAPI.
+func (a *exportModelAPI) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.client.OpenCharm(curl)
+}
+
+// OpenURI is part of ExportModelAPI.
+func (a *exportModelAPI) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return a.client.OpenURI(uri, query)
+}
+
+// Close is part of ExportModelAPI.
+func (a *exportModelAPI) Close() error {
+	a.client.Close()
+	return a.Client.Close()
+}
+
+// resourceDownloader downloads application resources from the
+// model's resources HTTP endpoint.
+type resourceDownloader struct {
+	api ExportModelAPI
+}
+
+// OpenResource is part of migration.ResourceDownloader.
+func (d resourceDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	return d.api.OpenURI(fmt.Sprintf("/applications/%s/resources/%s", application, name), nil)
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) (err error) {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	dumped, err := client.DumpModel(names.NewModelTag(modelDetails.ModelUUID), false)
+	if err != nil {
+		return err
+	}
+	bytes, err := yaml.Marshal(dumped)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Annotate(err, "reading exported model")
+	}
+
+	filename := ctx.AbsPath(c.filename)
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Annotate(err, "while creating archive file")
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errors.Annotate(closeErr, "while writing archive file")
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+
+	ctx.Infof("Exporting model %q to %s", modelName, c.filename)
+	err = migration.WriteArchive(file, migration.ArchiveConfig{
+		Model:              model,
+		CharmDownloader:    client,
+		ToolsDownloader:    client,
+		ResourceDownloader: resourceDownloader{client},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	ctx.Infof("Model %q exported to %s", modelName, c.filename)
+	return nil
+}
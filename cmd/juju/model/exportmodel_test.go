@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportModelClient
+	store *jujuclient.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportModelClient{
+		model: description.NewModel(description.ModelArgs{
+			Owner: names.NewUserTag("admin"),
+			Config: map[string]interface{}{
+				"name": "mymodel",
+				"uuid": testing.ModelTag.Id(),
+			},
+		}),
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.dir = c.MkDir()
+}
+
+func (s *ExportModelCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommandInDir(c, model.NewExportModelCommandForTest(s.fake, s.store), args, s.dir)
+}
+
+func (s *ExportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Check(err, gc.ErrorMatches, "archive filename must be specified")
+	_, err = s.run(c, "a.tar.gz", "b.tar.gz")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["b.tar.gz"\]`)
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	ctx, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"DumpModel", []interface{}{testing.ModelTag, false}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Exporting model \"admin/mymodel\" to mymodel.tar.gz\n"+
+		"Model \"admin/mymodel\" exported to mymodel.tar.gz\n")
+
+	f, err := os.Open(filepath.Join(s.dir, "mymodel.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.OpenArchive(f)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Model.Tag(), gc.Equals, testing.ModelTag)
+	c.Check(archive.Model.Owner(), gc.Equals, names.NewUserTag("admin"))
+}
+
+func (s *ExportModelCommandSuite) TestExportFileExists(c *gc.C) {
+	path := filepath.Join(s.dir, "mymodel.tar.gz")
+	err := ioutil.WriteFile(path, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "while creating archive file: .* file exists")
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "precious")
+}
+
+func (s *ExportModelCommandSuite) TestExportDumpFails(c *gc.C) {
+	s.fake.SetErrors(errors.New("permission denied"))
+
+	_, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = os.Stat(filepath.Join(s.dir, "mymodel.tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+type fakeExportModelClient struct {
+	jujutesting.Stub
+	model description.Model
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) DumpModel(model names.ModelTag, simplified bool) (map[string]interface{}, error) {
+	f.MethodCall(f, "DumpModel", model, simplified)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	bytes, err := description.Serialize(f.model)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (f *fakeExportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return nil, errors.NotImplementedf("OpenCharm")
+}
+
+func (f *fakeExportModelClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri, query)
+	return nil, errors.NotImplementedf("OpenURI")
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"os"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+)
+
+// NewImportModelCommand returns a fully constructed import-model
+// command.
+func NewImportModelCommand() cmd.Command {
+	cmd := &importModelCommand{}
+	cmd.newAPIFunc = func() (ImportModelAPI, error) {
+		return cmd.getAPI()
+	}
+	return modelcmd.WrapController(cmd)
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (ImportModelAPI, error)
+	filename   string
+	region     string
+	credential string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive file written by "juju export-model".
+The controller needs no connection to the controller the model was
+exported from.
+
+The model is rebuilt on this controller's cloud: new machines are
+provisioned, in the --region given or the controller's own region,
+using the model owner's --credential on this controller. The model's
+charms are deployed to them again with the same config, relations,
+leadership settings and resources. Models using storage can not be
+imported.
+
+The model keeps its name and owner, so the owner must not already
+have a model of that name on this controller. It is given a new UUID,
+so the imported model never claims the exported model's machines,
+even on the same cloud. Only controller administrators can import
+models.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c air-gapped --region us-east-1 --credential aws prod.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<filename>",
+		Purpose: "Imports a model from a portable archive file.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.region, "region", "", "The region to rebuild the model in")
+	f.StringVar(&c.credential, "credential", "", "The credential to rebuild the model with")
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("archive filename must be specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ImportModelAPI specifies the MigrationTarget API calls import-model
+// uses to rebuild a model on the controller.
+type ImportModelAPI interface {
+	migration.RebuildTarget
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelAPI{migrationtarget.NewClient(root), root}, nil
+}
+
+// importModelAPI closes the controller connection used by the
+// MigrationTarget client.
+type importModelAPI struct {
+	*migrationtarget.Client
+	conn api.Connection
+}
+
+// Close is part of ImportModelAPI.
+func (a *importModelAPI) Close() error {
+	return a.conn.Close()
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	file, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "while opening archive file")
+	}
+	defer file.Close()
+	archive, err := migration.OpenArchive(file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	modelInfo, err := archive.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// The imported model is given a new UUID, as the exported model
+	// may still be running on the same cloud.
+	modelInfo.UUID = utils.MustNewUUID().String()
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "controller prechecks failed")
+	}
+	ctx.Infof("Importing model %q", modelInfo.Name)
+	err = migration.RebuildModel(migration.RebuildConfig{
+		Model:              archive.Model,
+		ModelUUID:          modelInfo.UUID,
+		CloudRegion:        c.region,
+		CloudCredential:    c.credential,
+		Target:             client,
+		CharmDownloader:    archive,
+		ToolsDownloader:    archive,
+		ResourceDownloader: archive,
+		Clock:              clock.WallClock,
+		Timeout:            migration.DefaultRebuildTimeout,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+	ctx.Infof("Model %q imported, and its machines are being provisioned.", modelInfo.Name)
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeImportModelClient
+	store *jujuclient.MemStore
+	model description.Model
+	dir   string
+}
+
+var _ = gc.Suite(&ImportModelCommandSuite{})
+
+func (s *ImportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeImportModelClient{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.model = description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.4.1",
+		},
+	})
+	s.dir = c.MkDir()
+
+	f, err := os.Create(filepath.Join(s.dir, "mymodel.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = migration.WriteArchive(f, migration.ArchiveConfig{
+		Model:              s.model,
+		CharmDownloader:    &fakeImportModelClient{},
+		ToolsDownloader:    &fakeImportModelClient{},
+		ResourceDownloader: &fakeImportModelClient{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommandInDir(c, model.NewImportModelCommandForTest(s.fake, s.store), args, s.dir)
+}
+
+func (s *ImportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Check(err, gc.ErrorMatches, "archive filename must be specified")
+	_, err = s.run(c, "a.tar.gz", "b.tar.gz")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["b.tar.gz"\]`)
+}
+
+func (s *ImportModelCommandSuite) TestImport(c *gc.C) {
+	ctx, err := s.run(c, "--region", "west", "--credential", "cred", "mymodel.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.fake.CheckCallNames(c, "Prechecks", "ImportRebuild", "Activate", "Close")
+	// The imported model is given a new UUID.
+	uuid := s.fake.Calls()[0].Args[0].(coremigration.ModelInfo).UUID
+	c.Check(uuid, gc.Not(gc.Equals), testing.ModelTag.Id())
+	c.Check(utils.IsValidUUIDString(uuid), jc.IsTrue)
+	s.fake.CheckCall(c, 0, "Prechecks", coremigration.ModelInfo{
+		UUID:                   uuid,
+		Owner:                  names.NewUserTag("bob"),
+		Name:                   "mymodel",
+		AgentVersion:           version.MustParse("2.4.1"),
+		ControllerAgentVersion: version.MustParse("2.4.1"),
+	})
+	imported, err := description.Deserialize(s.fake.Calls()[1].Args[0].([]byte))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Tag().Id(), gc.Equals, uuid)
+	c.Check(s.fake.Calls()[1].Args[1:], jc.DeepEquals, []interface{}{"west", "cred"})
+	s.fake.CheckCall(c, 2, "Activate", uuid)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Importing model \"mymodel\"\n"+
+		"Model \"mymodel\" imported, and its machines are being provisioned.\n")
+}
+
+func (s *ImportModelCommandSuite) TestImportPrechecksFail(c *gc.C) {
+	s.fake.SetErrors(errors.New("model with same UUID already exists"))
+
+	_, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "controller prechecks failed: model with same UUID already exists")
+	s.fake.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportFails(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("rebuilding models with storage not supported"))
+
+	_, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "cannot import model: importing model into target controller: rebuilding models with storage not supported")
+	s.fake.CheckCallNames(c, "Prechecks", "ImportRebuild", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportMissingFile(c *gc.C) {
+	_, err := s.run(c, "missing.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "while opening archive file: .* no such file or directory")
+	s.fake.CheckNoCalls(c)
+}
+
+type fakeImportModelClient struct {
+	jujutesting.Stub
+}
+
+func (f *fakeImportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) ImportRebuild(bytes []byte, region, credential string) error {
+	f.MethodCall(f, "ImportRebuild", bytes, region, credential)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl)
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return nil, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadResource(modelUUID string, res resource.Resource, content io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetUnitResource(modelUUID, unitName string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unitName, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenCharm")
+}
+
+func (f *fakeImportModelClient) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenURI")
+}
+
+func (f *fakeImportModelClient) OpenResource(application, name string) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenResource")
+}
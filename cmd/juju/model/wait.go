@@ -0,0 +1,337 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const waitHelpDoc = `
+Blocks until the model, or the specified applications and units, have
+settled. By default this means that every unit has an "active" workload
+status and an idle agent, and that every machine has been provisioned and
+its agent has started.
+
+The command exits with a non-zero status as soon as a unit or machine
+enters an error state, or if the model has not settled before the
+timeout expires. A timeout of 0 waits indefinitely.
+
+When applications or units are specified, only those units, and the
+machines hosting them, are considered. An application that has not been
+deployed yet is waited for.
+
+Examples:
+
+    juju wait
+    juju wait --timeout 20m mysql wordpress/0
+    juju wait --workload-status active,blocked --skip-idle
+
+See also:
+    status
+`
+
+// NewWaitCommand returns a command that blocks until a model
+// has settled.
+func NewWaitCommand() cmd.Command {
+	return modelcmd.Wrap(&waitCommand{clock: clock.WallClock})
+}
+
+// WaitAPI defines the API methods used by the wait command.
+type WaitAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// AllWatcher defines the methods of the model's all watcher
+// used by the wait command.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+type waitCommand struct {
+	modelcmd.ModelCommandBase
+	api   WaitAPI
+	clock clock.Clock
+
+	timeout        time.Duration
+	workloadStatus string
+	skipIdle       bool
+
+	targetStatuses []status.Status
+	applications   []string
+	units          []string
+}
+
+// Info implements Command.
+func (c *waitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait",
+		Args:    "[<application>|<unit> ...]",
+		Purpose: "Waits for a model, or some of its applications and units, to settle.",
+		Doc:     waitHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *waitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 30*time.Minute, "How long to wait before giving up")
+	f.StringVar(&c.workloadStatus, "workload-status", string(status.Active),
+		"Comma separated workload statuses that units may settle in")
+	f.BoolVar(&c.skipIdle, "skip-idle", false, "Do not wait for unit agents to be idle")
+}
+
+// Init implements Command.
+func (c *waitCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.NotValidf("negative timeout")
+	}
+	for _, s := range strings.Split(c.workloadStatus, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		workloadStatus := status.Status(s)
+		if !workloadStatus.KnownWorkloadStatus() {
+			return errors.NotValidf("workload status %q", s)
+		}
+		c.targetStatuses = append(c.targetStatuses, workloadStatus)
+	}
+	if len(c.targetStatuses) == 0 {
+		return errors.New("no workload status specified")
+	}
+	for _, arg := range args {
+		switch {
+		case names.IsValidUnit(arg):
+			c.units = append(c.units, arg)
+		case names.IsValidApplication(arg):
+			c.applications = append(c.applications, arg)
+		default:
+			return errors.NotValidf("application or unit name %q", arg)
+		}
+	}
+	return nil
+}
+
+type waitAPIAdaptor struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitAPI interface.
+func (a waitAPIAdaptor) WatchAll() (AllWatcher, error) {
+	return a.Client.WatchAll()
+}
+
+func (c *waitCommand) getAPI() (WaitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitAPIAdaptor{client}, nil
+}
+
+// Run implements Command.
+func (c *waitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timeout = c.clock.After(c.timeout)
+	}
+
+	type nextResult struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	results := make(chan nextResult, 1)
+	next := func() {
+		deltas, err := watcher.Next()
+		results <- nextResult{deltas, err}
+	}
+
+	model := newWaitModel(c.applications, c.units)
+	var lastPending string
+	go next()
+	for {
+		select {
+		case <-timeout:
+			if lastPending == "" {
+				return errors.Errorf("timed out after %v", c.timeout)
+			}
+			return errors.Errorf("timed out after %v waiting for %s", c.timeout, lastPending)
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			model.update(result.deltas)
+			pending, err := model.check(c.targetStatuses, !c.skipIdle)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(pending) == 0 {
+				ctx.Infof("model settled")
+				return nil
+			}
+			if summary := strings.Join(pending, ", "); summary != lastPending {
+				ctx.Verbosef("waiting for %s", summary)
+				lastPending = summary
+			}
+			go next()
+		}
+	}
+}
+
+// waitModel holds the parts of the model state relevant to the
+// wait command, as reported by the all watcher.
+type waitModel struct {
+	applications map[string]bool
+	units        map[string]bool
+
+	machineInfo map[string]*multiwatcher.MachineInfo
+	unitInfo    map[string]*multiwatcher.UnitInfo
+}
+
+func newWaitModel(applications, units []string) *waitModel {
+	m := &waitModel{
+		applications: make(map[string]bool),
+		units:        make(map[string]bool),
+		machineInfo:  make(map[string]*multiwatcher.MachineInfo),
+		unitInfo:     make(map[string]*multiwatcher.UnitInfo),
+	}
+	for _, name := range applications {
+		m.applications[name] = true
+	}
+	for _, name := range units {
+		m.units[name] = true
+	}
+	return m
+}
+
+func (m *waitModel) update(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(m.machineInfo, info.Id)
+			} else {
+				m.machineInfo[info.Id] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.unitInfo, info.Name)
+			} else {
+				m.unitInfo[info.Name] = info
+			}
+		}
+	}
+}
+
+// selected reports whether the specified unit was asked for.
+func (m *waitModel) selected(unit *multiwatcher.UnitInfo) bool {
+	if len(m.applications) == 0 && len(m.units) == 0 {
+		return true
+	}
+	return m.applications[unit.Application] || m.units[unit.Name]
+}
+
+// check returns a description of each entity that has not yet
+// settled, sorted for stable output. An error is returned if any
+// of the considered entities is in an error state.
+func (m *waitModel) check(workloadStatuses []status.Status, agentIdle bool) ([]string, error) {
+	var pending []string
+	machines := make(map[string]bool)
+	seenApplications := make(map[string]bool)
+	seenUnits := make(map[string]bool)
+	for name, unit := range m.unitInfo {
+		if !m.selected(unit) {
+			continue
+		}
+		seenApplications[unit.Application] = true
+		seenUnits[name] = true
+		if unit.MachineId != "" {
+			machines[unit.MachineId] = true
+		}
+		workload, agent := unit.WorkloadStatus.Current, unit.AgentStatus.Current
+		if workload == status.Error || agent == status.Error || agent == status.Failed {
+			return nil, errors.Errorf("unit %q is in error: %s", name, unitErrorMessage(unit))
+		}
+		if !containsStatus(workloadStatuses, workload) {
+			pending = append(pending, fmt.Sprintf("unit %q (workload %s)", name, workload))
+		} else if agentIdle && agent != status.Idle {
+			pending = append(pending, fmt.Sprintf("unit %q (agent %s)", name, agent))
+		}
+	}
+	for name := range m.applications {
+		if !seenApplications[name] {
+			pending = append(pending, fmt.Sprintf("application %q", name))
+		}
+	}
+	for name := range m.units {
+		if !seenUnits[name] {
+			pending = append(pending, fmt.Sprintf("unit %q", name))
+		}
+	}
+
+	allMachines := len(m.applications) == 0 && len(m.units) == 0
+	for id, machine := range m.machineInfo {
+		if !allMachines && !machines[id] {
+			continue
+		}
+		if machine.InstanceStatus.Current == status.ProvisioningError {
+			return nil, errors.Errorf("machine %q failed to provision: %s", id, machine.InstanceStatus.Message)
+		}
+		if machine.AgentStatus.Current == status.Error {
+			return nil, errors.Errorf("machine %q is in error: %s", id, machine.AgentStatus.Message)
+		}
+		if machine.InstanceId == "" || machine.AgentStatus.Current != status.Started {
+			pending = append(pending, fmt.Sprintf("machine %q", id))
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+func unitErrorMessage(unit *multiwatcher.UnitInfo) string {
+	if unit.WorkloadStatus.Current == status.Error {
+		return unit.WorkloadStatus.Message
+	}
+	return unit.AgentStatus.Message
+}
+
+func containsStatus(statuses []status.Status, s status.Status) bool {
+	for _, candidate := range statuses {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
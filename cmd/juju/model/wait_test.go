@@ -0,0 +1,240 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type WaitCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeWaitAPI
+	clock *testclock.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&WaitCommandSuite{})
+
+type fakeWaitAPI struct {
+	gitjujutesting.Stub
+	deltas chan []multiwatcher.Delta
+	nexts  chan struct{}
+	stop   chan struct{}
+}
+
+func (f *fakeWaitAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeWaitAPI) WatchAll() (model.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fakeWaitAPI) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case f.nexts <- struct{}{}:
+	default:
+	}
+	select {
+	case deltas := <-f.deltas:
+		return deltas, nil
+	case <-f.stop:
+		return nil, errors.New("watcher stopped")
+	}
+}
+
+func (f *fakeWaitAPI) Stop() error {
+	f.MethodCall(f, "Stop")
+	close(f.stop)
+	return nil
+}
+
+func (s *WaitCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeWaitAPI{
+		deltas: make(chan []multiwatcher.Delta, 10),
+		nexts:  make(chan struct{}, 10),
+		stop:   make(chan struct{}),
+	}
+	s.clock = testclock.NewClock(time.Now())
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *WaitCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewWaitCommandForTest(s.api, s.clock, s.store), args...)
+}
+
+func unitDelta(name, machine string, workload, agent status.Status, message string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    name[:len(name)-2],
+		MachineId:      machine,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func machineDelta(id, instanceId string, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:          id,
+		InstanceId:  instanceId,
+		AgentStatus: multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *WaitCommandSuite) TestSettled(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		machineDelta("0", "inst-0", status.Started),
+		unitDelta("mysql/0", "0", status.Active, status.Idle, ""),
+	}
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "WatchAll", "Stop", "Close")
+}
+
+func (s *WaitCommandSuite) TestWaitsForChanges(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		machineDelta("0", "", status.Pending),
+		unitDelta("mysql/0", "0", status.Waiting, status.Allocating, "waiting for machine"),
+	}
+	s.api.deltas <- []multiwatcher.Delta{
+		machineDelta("0", "inst-0", status.Started),
+		unitDelta("mysql/0", "0", status.Active, status.Executing, ""),
+	}
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "0", status.Active, status.Idle, ""),
+	}
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitCommandSuite) TestSkipIdle(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "", status.Blocked, status.Executing, ""),
+	}
+	_, err := s.run(c, "--workload-status", "active,blocked", "--skip-idle")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitCommandSuite) TestUnitError(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "", status.Error, status.Idle, `hook failed: "install"`),
+	}
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" is in error: hook failed: "install"`)
+}
+
+func (s *WaitCommandSuite) TestMachineProvisioningError(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{{Entity: &multiwatcher.MachineInfo{
+		Id:             "0",
+		InstanceStatus: multiwatcher.StatusInfo{Current: status.ProvisioningError, Message: "no capacity"},
+	}}}
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `machine "0" failed to provision: no capacity`)
+}
+
+func (s *WaitCommandSuite) TestScoped(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		machineDelta("0", "inst-0", status.Started),
+		machineDelta("1", "", status.Pending),
+		unitDelta("mysql/0", "0", status.Active, status.Idle, ""),
+		unitDelta("wordpress/0", "1", status.Maintenance, status.Executing, ""),
+	}
+	_, err := s.run(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitCommandSuite) TestScopedWaitsForApplication(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "", status.Active, status.Idle, ""),
+	}
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("wordpress/0", "", status.Active, status.Idle, ""),
+	}
+	_, err := s.run(c, "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitCommandSuite) TestTimeout(c *gc.C) {
+	s.api.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "", status.Maintenance, status.Executing, ""),
+	}
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.run(c, "--timeout", "1m")
+		errc <- err
+	}()
+	// Wait for the initial deltas to be processed
+	// before the timeout fires.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-s.api.nexts:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for watcher to be read")
+		}
+	}
+	err := s.clock.WaitAdvance(time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `timed out after 1m0s waiting for unit "mysql/0" \(workload maintenance\)`)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+}
+
+func (s *WaitCommandSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--timeout", "-1s"},
+		err:  "negative timeout not valid",
+	}, {
+		args: []string{"--workload-status", "happy"},
+		err:  `workload status "happy" not valid`,
+	}, {
+		args: []string{"--workload-status", ","},
+		err:  "no workload status specified",
+	}, {
+		args: []string{"not/valid/0"},
+		err:  `application or unit name "not/valid/0" not valid`,
+	}} {
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
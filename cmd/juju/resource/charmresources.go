@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // CharmResourcesCommand implements the "juju charm-resources" command.
@@ -65,7 +66,7 @@ type baseCharmResourcesCommand struct {
 	// uses juju/juju/charmstore.Client.
 	resourceLister ResourceLister
 
-	out     cmd.Output
+	out     output.Output
 	channel string
 	charm   string
 }
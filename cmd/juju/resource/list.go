@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/resource"
 )
 
@@ -36,7 +37,7 @@ type ListCommand struct {
 
 	details bool
 	deps    ListDeps
-	out     cmd.Output
+	out     output.Output
 	target  string
 }
 
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 var (
@@ -60,7 +61,7 @@ var _ cmd.Command = (*listAgreementsCommand)(nil)
 // Terms and Conditions document.
 type listAgreementsCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out output.Output
 }
 
 // SetFlags implements Command.SetFlags.
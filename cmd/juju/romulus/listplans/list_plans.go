@@ -47,7 +47,7 @@ Examples:
 type ListPlansCommand struct {
 	modelcmd.ControllerCommandBase
 
-	out      cmd.Output
+	out      output.Output
 	CharmURL string
 }
 
@@ -18,6 +18,7 @@ import (
 
 	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewListWalletsCommand returns a new command that is used
@@ -29,7 +30,7 @@ func NewListWalletsCommand() modelcmd.ControllerCommand {
 type listWalletsCommand struct {
 	modelcmd.ControllerCommandBase
 
-	out cmd.Output
+	out output.Output
 }
 
 const listWalletsDoc = `
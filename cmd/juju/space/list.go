@@ -28,7 +28,7 @@ func NewListCommand() modelcmd.ModelCommand {
 type ListCommand struct {
 	SpaceCommandBase
 	Short bool
-	out   cmd.Output
+	out   output.Output
 }
 
 const listCommandDoc = `
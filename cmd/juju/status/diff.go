@@ -0,0 +1,317 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewDiffStatusCommand returns a command that reports the
+// differences between two status snapshots.
+func NewDiffStatusCommand() cmd.Command {
+	return modelcmd.Wrap(&diffStatusCommand{})
+}
+
+type diffStatusCommand struct {
+	modelcmd.ModelCommandBase
+	api    statusAPI
+	out    output.Output
+	before string
+	after  string
+}
+
+const diffStatusDoc = `
+Reports what changed in a model between two status snapshots, or between
+a snapshot and the current status of the model when only one snapshot is
+given. Snapshots are saved with save-status-snapshot; the YAML and JSON
+output of show-status may also be used.
+
+The following changes are reported:
+    - machines, applications and units that were added or removed
+    - changes of charm, and of charm revision
+    - changes of the model's agent version
+    - status transitions of the model, machines, applications and units
+    - changes of the addresses of machines, applications and units
+
+Examples:
+    juju save-status-snapshot before.yaml
+    juju upgrade-charm mysql
+    juju diff-status before.yaml
+
+    juju diff-status before.yaml after.yaml --format yaml
+
+See also:
+    save-status-snapshot
+    show-status
+`
+
+// Info implements Command.Info.
+func (c *diffStatusCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-status",
+		Args:    "<snapshot> [<snapshot>]",
+		Purpose: "Reports the differences between two status snapshots.",
+		Doc:     diffStatusDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *diffStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatStatusChangesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *diffStatusCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no status snapshot specified")
+	case 1:
+		c.before = args[0]
+	case 2:
+		c.before, c.after = args[0], args[1]
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *diffStatusCommand) Run(ctx *cmd.Context) error {
+	before, err := readStatusSnapshot(ctx.AbsPath(c.before))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var after formattedStatus
+	if c.after != "" {
+		after, err = readStatusSnapshot(ctx.AbsPath(c.after))
+	} else {
+		after, err = c.liveStatus()
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	changes := diffStatus(before, after)
+	if len(changes) == 0 {
+		ctx.Infof("No changes to display.")
+		return nil
+	}
+	return c.out.Write(ctx, changes)
+}
+
+func (c *diffStatusCommand) liveStatus() (formattedStatus, error) {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return formattedStatus{}, errors.Trace(err)
+	}
+	defer apiclient.Close()
+	return fetchStatusSnapshot(&c.ModelCommandBase, apiclient)
+}
+
+func (c *diffStatusCommand) getAPI() (statusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// StatusChange describes a difference between two status snapshots.
+type StatusChange struct {
+	Type   string `yaml:"type" json:"type"`
+	Entity string `yaml:"entity" json:"entity"`
+	Change string `yaml:"change" json:"change"`
+	Before string `yaml:"before,omitempty" json:"before,omitempty"`
+	After  string `yaml:"after,omitempty" json:"after,omitempty"`
+}
+
+// statusDiffer accumulates the changes between two status snapshots.
+type statusDiffer struct {
+	changes []StatusChange
+}
+
+func (d *statusDiffer) add(entityType, entity, change, before, after string) {
+	d.changes = append(d.changes, StatusChange{
+		Type:   entityType,
+		Entity: entity,
+		Change: change,
+		Before: before,
+		After:  after,
+	})
+}
+
+func (d *statusDiffer) compare(entityType, entity, change, before, after string) {
+	if before != after {
+		d.add(entityType, entity, change, before, after)
+	}
+}
+
+// diffStatus returns the changes between the before and after
+// snapshots, ordered by model, machines, applications and units.
+func diffStatus(before, after formattedStatus) []StatusChange {
+	var d statusDiffer
+
+	model := before.Model.Name
+	if model == "" {
+		model = after.Model.Name
+	}
+	d.compare("model", model, "version", before.Model.Version, after.Model.Version)
+	d.compare("model", model, "model-status", statusText(before.Model.Status), statusText(after.Model.Status))
+
+	beforeMachines := flattenMachines(before.Machines)
+	afterMachines := flattenMachines(after.Machines)
+	for _, id := range unionKeys(beforeMachines, afterMachines) {
+		b, inBefore := beforeMachines[id]
+		a, inAfter := afterMachines[id]
+		if !d.addedOrRemoved("machine", id, inBefore, inAfter) {
+			d.diffMachine(id, b, a)
+		}
+	}
+
+	for _, name := range unionKeys(before.Applications, after.Applications) {
+		b, inBefore := before.Applications[name]
+		a, inAfter := after.Applications[name]
+		if !d.addedOrRemoved("application", name, inBefore, inAfter) {
+			d.diffApplication(name, b, a)
+		}
+	}
+
+	beforeUnits := flattenUnits(before.Applications)
+	afterUnits := flattenUnits(after.Applications)
+	for _, name := range unionKeys(beforeUnits, afterUnits) {
+		b, inBefore := beforeUnits[name]
+		a, inAfter := afterUnits[name]
+		if !d.addedOrRemoved("unit", name, inBefore, inAfter) {
+			d.diffUnit(name, b, a)
+		}
+	}
+	return d.changes
+}
+
+// addedOrRemoved records the addition or removal of an entity,
+// and reports whether the entity is missing from either snapshot.
+func (d *statusDiffer) addedOrRemoved(entityType, entity string, inBefore, inAfter bool) bool {
+	switch {
+	case inBefore && inAfter:
+		return false
+	case inAfter:
+		d.add(entityType, entity, "added", "", "")
+	default:
+		d.add(entityType, entity, "removed", "", "")
+	}
+	return true
+}
+
+func (d *statusDiffer) diffMachine(id string, before, after machineStatus) {
+	d.compare("machine", id, "juju-status", statusText(before.JujuStatus), statusText(after.JujuStatus))
+	d.compare("machine", id, "machine-status", statusText(before.MachineStatus), statusText(after.MachineStatus))
+	d.compare("machine", id, "instance-id", string(before.InstanceId), string(after.InstanceId))
+	d.compare("machine", id, "dns-name", before.DNSName, after.DNSName)
+	d.compare("machine", id, "ip-addresses", strings.Join(before.IPAddresses, ", "), strings.Join(after.IPAddresses, ", "))
+}
+
+func (d *statusDiffer) diffApplication(name string, before, after applicationStatus) {
+	if before.CharmName == after.CharmName && before.CharmOrigin == after.CharmOrigin && before.CharmRev != after.CharmRev {
+		d.add("application", name, "charm-rev", strconv.Itoa(before.CharmRev), strconv.Itoa(after.CharmRev))
+	} else {
+		d.compare("application", name, "charm", before.Charm, after.Charm)
+	}
+	d.compare("application", name, "version", before.Version, after.Version)
+	d.compare("application", name, "application-status", statusText(before.StatusInfo), statusText(after.StatusInfo))
+	d.compare("application", name, "address", before.Address, after.Address)
+}
+
+func (d *statusDiffer) diffUnit(name string, before, after unitStatus) {
+	d.compare("unit", name, "workload-status", statusText(before.WorkloadStatusInfo), statusText(after.WorkloadStatusInfo))
+	d.compare("unit", name, "juju-status", statusText(before.JujuStatusInfo), statusText(after.JujuStatusInfo))
+	d.compare("unit", name, "public-address", before.PublicAddress, after.PublicAddress)
+	d.compare("unit", name, "address", before.Address, after.Address)
+}
+
+// statusText describes a status and its message,
+// ignoring the time at which it was set.
+func statusText(info statusInfoContents) string {
+	if info.Err != nil {
+		return info.Err.Error()
+	}
+	if info.Message == "" {
+		return string(info.Current)
+	}
+	return fmt.Sprintf("%s: %s", info.Current, info.Message)
+}
+
+// flattenMachines returns the machines and
+// their containers, keyed by machine id.
+func flattenMachines(machines map[string]machineStatus) map[string]machineStatus {
+	out := make(map[string]machineStatus)
+	var add func(map[string]machineStatus)
+	add = func(machines map[string]machineStatus) {
+		for id, m := range machines {
+			out[id] = m
+			add(m.Containers)
+		}
+	}
+	add(machines)
+	return out
+}
+
+// flattenUnits returns the units of the applications,
+// and their subordinates, keyed by unit name.
+func flattenUnits(applications map[string]applicationStatus) map[string]unitStatus {
+	out := make(map[string]unitStatus)
+	for _, app := range applications {
+		for name, u := range app.Units {
+			out[name] = u
+			recurseUnits(u, 0, func(subName string, sub unitStatus, _ int) {
+				out[subName] = sub
+			})
+		}
+	}
+	return out
+}
+
+// unionKeys returns the keys of both maps, in natural order.
+func unionKeys(a, b interface{}) []string {
+	keys := stringKeysFromMap(a)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		seen[key] = true
+	}
+	for _, key := range stringKeysFromMap(b) {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	return naturalsort.Sort(keys)
+}
+
+func formatStatusChangesTabular(writer io.Writer, value interface{}) error {
+	changes, ok := value.([]StatusChange)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", changes, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Type", "Entity", "Change", "Before", "After")
+	for _, change := range changes {
+		w.Println(change.Type, change.Entity, change.Change, change.Before, change.After)
+	}
+	tw.Flush()
+	return nil
+}
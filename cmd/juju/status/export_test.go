@@ -3,8 +3,29 @@
 
 package status
 
-import "github.com/juju/cmd"
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
 
 func NewTestStatusHistoryCommand(api HistoryAPI) cmd.Command {
 	return &statusHistoryCommand{api: api}
 }
+
+func NewTestStatusTimelineCommand(api TimelineAPI) cmd.Command {
+	return &statusTimelineCommand{api: api}
+}
+
+func NewTestSaveStatusSnapshotCommand(api statusAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &saveStatusSnapshotCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewTestDiffStatusCommand(api statusAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &diffStatusCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
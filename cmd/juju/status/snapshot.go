@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSaveStatusSnapshotCommand returns a command that saves the
+// status of a model to a file, to be compared later with diff-status.
+func NewSaveStatusSnapshotCommand() cmd.Command {
+	return modelcmd.Wrap(&saveStatusSnapshotCommand{})
+}
+
+type saveStatusSnapshotCommand struct {
+	modelcmd.ModelCommandBase
+	api  statusAPI
+	path string
+}
+
+const saveStatusSnapshotDoc = `
+Saves the full status of the model to a file, in the YAML format used by
+"juju show-status --format yaml". The snapshot can be compared with a later
+snapshot, or with the live status of the model, using diff-status.
+
+Examples:
+    juju save-status-snapshot before-upgrade.yaml
+
+See also:
+    diff-status
+    show-status
+`
+
+// Info implements Command.Info.
+func (c *saveStatusSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "save-status-snapshot",
+		Args:    "<file>",
+		Purpose: "Saves the status of a model to a file.",
+		Doc:     saveStatusSnapshotDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *saveStatusSnapshotCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements Command.Init.
+func (c *saveStatusSnapshotCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no snapshot file specified")
+	case 1:
+		c.path = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run implements Command.Run.
+func (c *saveStatusSnapshotCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	snapshot, err := fetchStatusSnapshot(&c.ModelCommandBase, apiclient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := goyaml.Marshal(snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.path), data, 0644); err != nil {
+		return errors.Annotate(err, "cannot write status snapshot")
+	}
+	ctx.Infof("Status of model %q saved to %s.", snapshot.Model.Name, c.path)
+	return nil
+}
+
+func (c *saveStatusSnapshotCommand) getAPI() (statusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// fetchStatusSnapshot returns the unfiltered status of the
+// command's model, formatted as for the YAML output of status.
+func fetchStatusSnapshot(c *modelcmd.ModelCommandBase, apiclient statusAPI) (formattedStatus, error) {
+	fullStatus, err := apiclient.FilteredStatus(params.StatusParams{})
+	if err != nil {
+		return formattedStatus{}, errors.Trace(err)
+	}
+	if fullStatus == nil {
+		return formattedStatus{}, errors.Errorf("unable to obtain the current status")
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return formattedStatus{}, errors.Trace(err)
+	}
+	// Times are recorded in UTC, so that snapshots taken
+	// in different time zones can be compared.
+	formatter := newStatusFormatter(fullStatus, controllerName, true, false)
+	return formatter.format()
+}
+
+// readStatusSnapshot reads a status snapshot saved by
+// save-status-snapshot, or by status in YAML or JSON format.
+func readStatusSnapshot(path string) (formattedStatus, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return formattedStatus{}, errors.Annotate(err, "cannot read status snapshot")
+	}
+	// JSON is a subset of YAML, so snapshots in
+	// either format can be read as YAML.
+	var snapshot formattedStatus
+	if err := goyaml.Unmarshal(data, &snapshot); err != nil {
+		return formattedStatus{}, errors.Annotatef(err, "cannot parse status snapshot %q", path)
+	}
+	return snapshot, nil
+}
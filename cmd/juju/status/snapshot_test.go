@@ -0,0 +1,236 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StatusSnapshotSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeAPIClient
+	store *jujuclient.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&StatusSnapshotSuite{})
+
+func (s *StatusSnapshotSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclienttesting.MinimalStore()
+	s.dir = c.MkDir()
+	s.api = &fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:     "sword",
+				CloudTag: "cloud-dummy",
+				Version:  "2.4.1",
+			},
+			Machines: map[string]params.MachineStatus{
+				"0": {
+					Id:          "0",
+					AgentStatus: params.DetailedStatus{Status: "started"},
+					DNSName:     "10.0.0.2",
+				},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:mysql-58",
+					Status: params.DetailedStatus{Status: "active"},
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							AgentStatus:    params.DetailedStatus{Status: "idle"},
+							WorkloadStatus: params.DetailedStatus{Status: "active"},
+							PublicAddress:  "10.0.0.2",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *StatusSnapshotSuite) writeSnapshot(c *gc.C, name string, snapshot formattedStatus) string {
+	data, err := goyaml.Marshal(snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(s.dir, name)
+	err = ioutil.WriteFile(path, data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *StatusSnapshotSuite) snapshot() formattedStatus {
+	return formattedStatus{
+		Model: modelStatus{
+			Name:    "sword",
+			Version: "2.4.0",
+		},
+		Machines: map[string]machineStatus{
+			"0": {
+				JujuStatus: statusInfoContents{Current: status.Started},
+				DNSName:    "10.0.0.1",
+			},
+		},
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				Charm:       "cs:mysql-57",
+				CharmOrigin: "jujucharms",
+				CharmName:   "mysql",
+				CharmRev:    57,
+				StatusInfo:  statusInfoContents{Current: status.Active},
+				Units: map[string]unitStatus{
+					"mysql/0": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Maintenance, Message: "upgrading"},
+						JujuStatusInfo:     statusInfoContents{Current: status.Executing},
+						PublicAddress:      "10.0.0.1",
+					},
+					"mysql/1": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Active},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *StatusSnapshotSuite) TestDiffStatus(c *gc.C) {
+	before := s.snapshot()
+	after := s.snapshot()
+	after.Model.Version = "2.4.1"
+	after.Machines["0/lxd/0"] = machineStatus{}
+	mysql := after.Applications["mysql"]
+	mysql.Charm = "cs:mysql-58"
+	mysql.CharmRev = 58
+	mysql.Units = map[string]unitStatus{
+		"mysql/0": {
+			WorkloadStatusInfo: statusInfoContents{Current: status.Active},
+			JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+			PublicAddress:      "10.0.0.2",
+			Subordinates: map[string]unitStatus{
+				"logging/0": {},
+			},
+		},
+	}
+	after.Applications["mysql"] = mysql
+	after.Applications["logging"] = applicationStatus{Charm: "cs:logging-1"}
+
+	c.Assert(diffStatus(before, after), jc.DeepEquals, []StatusChange{
+		{Type: "model", Entity: "sword", Change: "version", Before: "2.4.0", After: "2.4.1"},
+		{Type: "machine", Entity: "0/lxd/0", Change: "added"},
+		{Type: "application", Entity: "logging", Change: "added"},
+		{Type: "application", Entity: "mysql", Change: "charm-rev", Before: "57", After: "58"},
+		{Type: "unit", Entity: "logging/0", Change: "added"},
+		{Type: "unit", Entity: "mysql/0", Change: "workload-status", Before: "maintenance: upgrading", After: "active"},
+		{Type: "unit", Entity: "mysql/0", Change: "juju-status", Before: "executing", After: "idle"},
+		{Type: "unit", Entity: "mysql/0", Change: "public-address", Before: "10.0.0.1", After: "10.0.0.2"},
+		{Type: "unit", Entity: "mysql/1", Change: "removed"},
+	})
+}
+
+func (s *StatusSnapshotSuite) TestDiffStatusCharmChanged(c *gc.C) {
+	before := s.snapshot()
+	after := s.snapshot()
+	mysql := after.Applications["mysql"]
+	mysql.Charm = "cs:percona-cluster-12"
+	mysql.CharmName = "percona-cluster"
+	mysql.CharmRev = 12
+	after.Applications["mysql"] = mysql
+
+	c.Assert(diffStatus(before, after), jc.DeepEquals, []StatusChange{{
+		Type:   "application",
+		Entity: "mysql",
+		Change: "charm",
+		Before: "cs:mysql-57",
+		After:  "cs:percona-cluster-12",
+	}})
+}
+
+func (s *StatusSnapshotSuite) TestDiffStatusIgnoresSince(c *gc.C) {
+	before := s.snapshot()
+	after := s.snapshot()
+	after.Model.Status.Since = "2018-06-01T14:30:00Z"
+	c.Assert(diffStatus(before, after), gc.HasLen, 0)
+}
+
+func (s *StatusSnapshotSuite) TestSaveSnapshot(c *gc.C) {
+	path := filepath.Join(s.dir, "snapshot.yaml")
+	ctx, err := cmdtesting.RunCommand(c, NewTestSaveStatusSnapshotCommand(s.api, s.store), path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `Status of model "sword" saved to `+path+".\n")
+	c.Check(s.api.argsUsed, jc.DeepEquals, params.StatusParams{})
+
+	snapshot, err := readStatusSnapshot(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Model.Controller, gc.Equals, "arthur")
+	c.Check(snapshot.Model.Version, gc.Equals, "2.4.1")
+	c.Check(snapshot.Applications["mysql"].CharmRev, gc.Equals, 58)
+	c.Check(snapshot.Applications["mysql"].Units["mysql/0"].PublicAddress, gc.Equals, "10.0.0.2")
+}
+
+func (s *StatusSnapshotSuite) TestDiffSnapshots(c *gc.C) {
+	before := s.writeSnapshot(c, "before.yaml", s.snapshot())
+	after := s.snapshot()
+	after.Machines["0"] = machineStatus{
+		JujuStatus: statusInfoContents{Current: status.Down},
+		DNSName:    "10.0.0.1",
+	}
+	afterPath := s.writeSnapshot(c, "after.yaml", after)
+
+	ctx, err := cmdtesting.RunCommand(c, NewTestDiffStatusCommand(s.api, s.store), before, afterPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Type     Entity  Change       Before   After\n"+
+		"machine  0       juju-status  started  down\n")
+	c.Check(s.api.closeCalled, jc.IsFalse)
+}
+
+func (s *StatusSnapshotSuite) TestDiffSnapshotAgainstLiveStatus(c *gc.C) {
+	before := s.writeSnapshot(c, "before.yaml", s.snapshot())
+	ctx, err := cmdtesting.RunCommand(c, NewTestDiffStatusCommand(s.api, s.store), before, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.closeCalled, jc.IsTrue)
+
+	var changes []StatusChange
+	err = goyaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &changes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, []StatusChange{
+		{Type: "model", Entity: "sword", Change: "version", Before: "2.4.0", After: "2.4.1"},
+		{Type: "machine", Entity: "0", Change: "dns-name", Before: "10.0.0.1", After: "10.0.0.2"},
+		{Type: "application", Entity: "mysql", Change: "charm-rev", Before: "57", After: "58"},
+		{Type: "unit", Entity: "mysql/0", Change: "workload-status", Before: "maintenance: upgrading", After: "active"},
+		{Type: "unit", Entity: "mysql/0", Change: "juju-status", Before: "executing", After: "idle"},
+		{Type: "unit", Entity: "mysql/0", Change: "public-address", Before: "10.0.0.1", After: "10.0.0.2"},
+		{Type: "unit", Entity: "mysql/1", Change: "removed"},
+	})
+}
+
+func (s *StatusSnapshotSuite) TestDiffNoChanges(c *gc.C) {
+	before := s.writeSnapshot(c, "before.yaml", s.snapshot())
+	ctx, err := cmdtesting.RunCommand(c, NewTestDiffStatusCommand(s.api, s.store), before, before)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No changes to display.\n")
+}
+
+func (s *StatusSnapshotSuite) TestInitErrors(c *gc.C) {
+	err := cmdtesting.InitCommand(NewTestDiffStatusCommand(s.api, s.store), nil)
+	c.Check(err, gc.ErrorMatches, "no status snapshot specified")
+	err = cmdtesting.InitCommand(NewTestDiffStatusCommand(s.api, s.store), []string{"a", "b", "c"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["c"\]`)
+	err = cmdtesting.InitCommand(NewTestSaveStatusSnapshotCommand(s.api, s.store), nil)
+	c.Check(err, gc.ErrorMatches, "no snapshot file specified")
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -16,13 +17,15 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/juju/osenv"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
-	Status(patterns []string) (*params.FullStatus, error)
+	FilteredStatus(args params.StatusParams) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -36,7 +39,7 @@ func NewStatusCommand() cmd.Command {
 
 type statusCommand struct {
 	modelcmd.ModelCommandBase
-	out      cmd.Output
+	out      output.Output
 	patterns []string
 	isoTime  bool
 	api      statusAPI
@@ -48,6 +51,19 @@ type statusCommand struct {
 
 	// relationsFlagProvidedF indicates whether 'relations' option was provided by the user.
 	relationsFlagProvidedF func() bool
+
+	// watch indicates that the status should be kept up
+	// to date until the command is interrupted.
+	watch bool
+
+	// workloadStatus, agentStatus and message hold the
+	// status filters as provided by the user.
+	workloadStatus string
+	agentStatus    string
+	message        string
+
+	workloadStatuses []string
+	agentStatuses    []string
 }
 
 var usageSummary = `
@@ -67,6 +83,13 @@ Machine numbers may also be used as output filters. This will only display data
 in each section relevant to the specified machines. For example, application 
 section will only contain the applications that have units on these machines, etc.
 
+Units may also be filtered by their workload status with --status, by their
+agent status with --agent-status, and by their status message with --message.
+Statuses are given as comma separated lists, and the message matches any unit
+whose workload or agent status message contains it, ignoring case. When more
+than one of these options is given, units must match all of them. Only the
+machines and applications hosting the matching units are displayed.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -84,16 +107,31 @@ The available output formats are:
       in structured YAML format.
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
+- template=<go template>: Formats the information displayed in the yaml and
+      json formats with a Go text/template. Fields are named as they are in the
+      Go source, for example .Applications and .StatusInfo.Current. The json,
+      join, lower and upper functions are available to the template.
       
 In tabular format, 'Relations' section is not displayed by default. 
 Use --relations option to see this section. This option is ignored in all other 
 formats.
 
+With --watch, the status is displayed and then updated as the model changes,
+until the command is interrupted. Changes are followed without polling the
+controller. When filters are specified, entities added after the command
+starts are displayed if they match the filters, and units that stop matching
+the status filters are no longer displayed.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
+    juju show-status --status error,blocked
+    juju show-status --agent-status executing,lost mysql
+    juju show-status --message "hook failed"
+    juju show-status --watch
+    juju show-status --format 'template={{range $name, $app := .Applications}}{{$name}} {{$app.StatusInfo.Current}}{{"\n"}}{{end}}'
 
 See also:
     machines
@@ -118,6 +156,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
+	f.BoolVar(&c.watch, "watch", false, "Keep the status up to date until interrupted")
+	f.StringVar(&c.workloadStatus, "status", "", "Only show units with one of these comma separated workload statuses")
+	f.StringVar(&c.agentStatus, "agent-status", "", "Only show units with one of these comma separated agent statuses")
+	f.StringVar(&c.message, "message", "", "Only show units with a status message containing this text")
 
 	c.relationsFlagProvidedF = func() bool {
 		provided := false
@@ -144,6 +186,18 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	for _, s := range splitStatuses(c.workloadStatus) {
+		if !status.Status(s).KnownWorkloadStatus() {
+			return errors.NotValidf("workload status %q", s)
+		}
+		c.workloadStatuses = append(c.workloadStatuses, s)
+	}
+	for _, s := range splitStatuses(c.agentStatus) {
+		if !status.Status(s).KnownAgentStatus() && status.Status(s) != status.Lost {
+			return errors.NotValidf("agent status %q", s)
+		}
+		c.agentStatuses = append(c.agentStatuses, s)
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -158,6 +212,32 @@ func (c *statusCommand) Init(args []string) error {
 	return nil
 }
 
+func splitStatuses(value string) []string {
+	var statuses []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// statusFiltered reports whether units are filtered by status.
+func (c *statusCommand) statusFiltered() bool {
+	return len(c.workloadStatuses) > 0 || len(c.agentStatuses) > 0 || c.message != ""
+}
+
+// fetchStatus returns the status of the model, restricted
+// to the entities matching the command's filters.
+func (c *statusCommand) fetchStatus(apiclient statusAPI) (*params.FullStatus, error) {
+	return apiclient.FilteredStatus(params.StatusParams{
+		Patterns:         c.patterns,
+		WorkloadStatuses: c.workloadStatuses,
+		AgentStatuses:    c.agentStatuses,
+		Message:          c.message,
+	})
+}
+
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	return c.NewAPIClient()
 }
@@ -169,7 +249,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	status, err := c.fetchStatus(apiclient)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -181,11 +261,6 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("unable to obtain the current status")
 	}
 
-	controllerName, err := c.ControllerName()
-	if err != nil {
-		return errors.Trace(err)
-	}
-
 	showRelations := true
 	if c.out.Name() != "tabular" {
 		if c.relationsFlagProvidedF() {
@@ -195,20 +270,17 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	} else {
 		showRelations = c.relations
 	}
-	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
-	formatted, err := formatter.format()
-	if err != nil {
-		return errors.Trace(err)
+	if c.watch {
+		return c.runWatch(ctx, apiclient, status, showRelations)
 	}
-	err = c.out.Write(ctx, formatted)
-	if err != nil {
+	if err := c.render(ctx, status, showRelations); err != nil {
 		return err
 	}
 
 	if !status.IsEmpty() {
 		return nil
 	}
-	if len(c.patterns) == 0 {
+	if len(c.patterns) == 0 && !c.statusFiltered() {
 		modelName, err := c.ModelName()
 		if err != nil {
 			return err
@@ -216,7 +288,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		ctx.Infof("Model %q is empty.", modelName)
 	} else {
 		plural := func() string {
-			if len(c.patterns) == 1 {
+			if len(c.patterns) <= 1 {
 				return ""
 			}
 			return "s"
@@ -226,6 +298,20 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// render formats and writes the given status.
+func (c *statusCommand) render(ctx *cmd.Context, status *params.FullStatus, showRelations bool) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
+	formatted, err := formatter.format()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
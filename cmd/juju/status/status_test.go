@@ -4447,12 +4447,12 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
-	patternsUsed []string
+	argsUsed     params.StatusParams
 	closeCalled  bool
 }
 
-func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
-	a.patternsUsed = patterns
+func (a *fakeAPIClient) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	a.argsUsed = args
 	return a.statusReturn, nil
 }
 
@@ -4608,6 +4608,48 @@ func assertOneLineStatus(c *gc.C, expected string) {
 	c.Assert(string(stdout), gc.Equals, expected)
 }
 
+func (s *StatusSuite) TestStatusWithFormatTemplate(c *gc.C) {
+	ctx := s.newContext(c)
+	defer s.resetContext(c, ctx)
+	steps := []stepper{
+		addMachine{machineId: "0", job: state.JobManageModel},
+		setAddresses{"0", network.NewAddresses("10.0.0.1")},
+		startAliveMachine{"0"},
+		setMachineStatus{"0", status.Started, ""},
+		addCharm{"wordpress"},
+		addCharm{"mysql"},
+
+		addApplication{name: "wordpress", charm: "wordpress"},
+		addMachine{machineId: "1", job: state.JobHostUnits},
+		setAddresses{"1", network.NewAddresses("10.0.1.1")},
+		startAliveMachine{"1"},
+		setMachineStatus{"1", status.Started, ""},
+		addAliveUnit{"wordpress", "1"},
+		setAgentStatus{"wordpress/0", status.Idle, "", nil},
+		setUnitStatus{"wordpress/0", status.Active, "", nil},
+
+		addApplication{name: "mysql", charm: "mysql"},
+		addMachine{machineId: "2", job: state.JobHostUnits},
+		setAddresses{"2", network.NewAddresses("10.0.2.1")},
+		startAliveMachine{"2"},
+		setMachineStatus{"2", status.Started, ""},
+		addAliveUnit{"mysql", "2"},
+		setAgentStatus{"mysql/0", status.Idle, "", nil},
+		setUnitStatus{"mysql/0", status.Blocked, "missing relation", nil},
+	}
+	ctx.run(c, steps)
+
+	code, stdout, stderr := runStatus(c, "--format",
+		`template={{range $app := .Applications}}{{range $name, $unit := $app.Units}}`+
+			`{{$name}} {{$unit.WorkloadStatusInfo.Current}} {{$unit.PublicAddress}}{{"\n"}}{{end}}{{end}}`,
+	)
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "")
+	c.Assert(string(stdout), gc.Equals, ""+
+		"mysql/0 blocked 10.0.2.1\n"+
+		"wordpress/0 active 10.0.1.1\n")
+}
+
 func (s *StatusSuite) prepareTabularData(c *gc.C) *context {
 	ctx := s.newContext(c)
 	steps := []stepper{
@@ -4877,8 +4919,8 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	}
 
 	client := fakeAPIClient{}
-	var status = client.Status
-	s.PatchValue(&status, func(_ []string) (*params.FullStatus, error) {
+	var status = client.FilteredStatus
+	s.PatchValue(&status, func(_ params.StatusParams) (*params.FullStatus, error) {
 		return nil, nil
 	})
 	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
@@ -5647,3 +5689,39 @@ controller  kontroll    dummy/dummy-region  1.2.3    unsupported  15:04:05+07:00
 	_, _, stderr = runStatus(c, "cannot", "match", "me")
 	c.Check(string(stderr), gc.Equals, "Nothing matched specified filters.\n")
 }
+
+func (s *StatusSuite) TestStatusFormatTabularForUnmatchedStatusFilter(c *gc.C) {
+	code, _, stderr := runStatus(c, "--status", "error")
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "Nothing matched specified filter.\n")
+}
+
+func (s *StatusSuite) TestStatusFilterArgs(c *gc.C) {
+	client := fakeAPIClient{statusReturn: &params.FullStatus{}}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	code, _, stderr := runStatus(c,
+		"--status", "error, blocked",
+		"--agent-status", "lost",
+		"--message", "hook failed",
+		"mysql",
+	)
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Assert(client.argsUsed, jc.DeepEquals, params.StatusParams{
+		Patterns:         []string{"mysql"},
+		WorkloadStatuses: []string{"error", "blocked"},
+		AgentStatuses:    []string{"lost"},
+		Message:          "hook failed",
+	})
+}
+
+func (s *StatusSuite) TestStatusFilterInvalid(c *gc.C) {
+	code, _, stderr := runStatus(c, "--status", "happy")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR workload status \"happy\" not valid\n")
+
+	code, _, stderr = runStatus(c, "--agent-status", "active")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR agent status \"active\" not valid\n")
+}
@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewStatusTimelineCommand returns a command that reports the status
+// changes of all the applications, machines and units in a model.
+func NewStatusTimelineCommand() cmd.Command {
+	return modelcmd.Wrap(&statusTimelineCommand{})
+}
+
+// TimelineAPI is the API surface for the show-status-timeline command.
+type TimelineAPI interface {
+	StatusTimeline(params.StatusTimelineRequest) ([]params.StatusTimelineEntry, error)
+	Close() error
+}
+
+type statusTimelineCommand struct {
+	modelcmd.ModelCommandBase
+	api     TimelineAPI
+	out     output.Output
+	size    int
+	since   time.Duration
+	fromArg string
+	toArg   string
+	types   string
+	isoTime bool
+
+	from  *time.Time
+	to    *time.Time
+	kinds []string
+}
+
+const defaultTimelineSize = 50
+
+var statusTimelineDoc = fmt.Sprintf(`
+Reports the status changes of all the applications, machines and units
+in the model, merged into a single timeline ordered by the time at which
+they occurred.
+
+The timeline can be restricted to a time window with --from and --to,
+or to a period of time before now with --since. Times are given as dates
+(YYYY-MM-DD) or in RFC3339 format (for example 2018-06-01T14:30:00Z).
+When neither --from nor --since is given, the last %d changes are
+displayed.
+
+The --type option restricts the timeline to the given comma separated
+types of status:
+    application:  statuses of applications
+%v
+Examples:
+    juju show-status-timeline --since 2h
+    juju show-status-timeline --from 2018-06-01T14:00:00Z --to 2018-06-01T15:00:00Z
+    juju show-status-timeline --type workload,juju-unit -n 100
+
+See also:
+    show-status
+    show-status-log
+`, defaultTimelineSize, supportedHistoryKindDescs())
+
+// Info implements Command.Info.
+func (c *statusTimelineCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-status-timeline",
+		Purpose: "Output past statuses for all the entities in a model.",
+		Doc:     statusTimelineDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *statusTimelineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.size, "n", 0, "Returns at most the last N changes")
+	f.DurationVar(&c.since, "since", 0, "Returns the changes for the past period of time, e.g. 90m or 2h (cannot be combined with --from)")
+	f.StringVar(&c.fromArg, "from", "", "Returns the changes after the given time")
+	f.StringVar(&c.toArg, "to", "", "Returns the changes before the given time")
+	f.StringVar(&c.types, "type", "", fmt.Sprintf("Comma separated types of statuses to be displayed [application|%v]", supportedHistoryKindTypes()))
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTimelineTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *statusTimelineCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	if c.size < 0 {
+		return errors.Errorf("-n must be a positive number")
+	}
+	if c.since < 0 {
+		return errors.Errorf("--since must be a positive duration")
+	}
+	if c.since != 0 && c.fromArg != "" {
+		return errors.Errorf("--since and --from cannot be specified together")
+	}
+	var err error
+	if c.from, err = parseTimelineTime(c.fromArg); err != nil {
+		return errors.Annotate(err, "parsing --from")
+	}
+	if c.to, err = parseTimelineTime(c.toArg); err != nil {
+		return errors.Annotate(err, "parsing --to")
+	}
+	if c.from != nil && c.to != nil && c.to.Before(*c.from) {
+		return errors.Errorf("--to must not be before --from")
+	}
+	if c.from == nil && c.since == 0 && c.size == 0 {
+		c.size = defaultTimelineSize
+	}
+
+	c.kinds = nil
+	for _, kind := range strings.Split(c.types, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		if !status.HistoryKind(kind).Valid() && status.HistoryKind(kind) != status.KindApplication {
+			return errors.Errorf("unexpected status type %q", kind)
+		}
+		c.kinds = append(c.kinds, kind)
+	}
+	return nil
+}
+
+func parseTimelineTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, errors.Errorf("expected a date (YYYY-MM-DD) or RFC3339 time, got %q", value)
+}
+
+func (c *statusTimelineCommand) getAPI() (TimelineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// TimelineEntry describes a status change of an application,
+// machine or unit.
+type TimelineEntry struct {
+	Time    string `yaml:"time" json:"time"`
+	Entity  string `yaml:"entity" json:"entity"`
+	Type    string `yaml:"type" json:"type"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *statusTimelineCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	args := params.StatusTimelineRequest{
+		From:  c.from,
+		To:    c.to,
+		Kinds: c.kinds,
+		Size:  c.size,
+	}
+	if c.since != 0 {
+		from := time.Now().Add(-c.since)
+		args.From = &from
+	}
+	results, err := apiclient.StatusTimeline(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		ctx.Infof("No status changes to display.")
+		return nil
+	}
+
+	entries := make([]TimelineEntry, len(results))
+	for i, result := range results {
+		entity := result.Tag
+		if tag, err := names.ParseTag(result.Tag); err == nil {
+			entity = tag.Id()
+		}
+		entries[i] = TimelineEntry{
+			Entity:  entity,
+			Type:    result.Status.Kind,
+			Status:  result.Status.Status,
+			Message: result.Status.Info,
+		}
+		if result.Status.Since != nil {
+			entries[i].Time = common.FormatTime(result.Status.Since, c.isoTime)
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func formatTimelineTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]TimelineEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Entity", "Type", "Status", "Message")
+	for _, entry := range entries {
+		w.Print(entry.Time, entry.Entity, entry.Type)
+		w.PrintStatus(status.Status(entry.Status))
+		w.Println(entry.Message)
+	}
+	tw.Flush()
+	return nil
+}
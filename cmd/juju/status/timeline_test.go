@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type StatusTimelineSuite struct {
+	testing.IsolationSuite
+	api *fakeTimelineAPI
+	now time.Time
+}
+
+var _ = gc.Suite(&StatusTimelineSuite{})
+
+func (s *StatusTimelineSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeTimelineAPI{}
+	s.now = time.Date(2018, 6, 1, 14, 30, 0, 0, time.UTC)
+}
+
+func (s *StatusTimelineSuite) newCommand() cmd.Command {
+	return statuscmd.NewTestStatusTimelineCommand(s.api)
+}
+
+func (s *StatusTimelineSuite) next() *time.Time {
+	value := s.now
+	s.now = s.now.Add(time.Minute)
+	return &value
+}
+
+func (s *StatusTimelineSuite) TestResults(c *gc.C) {
+	s.api.entries = []params.StatusTimelineEntry{{
+		Tag:    "machine-0",
+		Status: params.DetailedStatus{Status: "started", Kind: "juju-machine", Since: s.next()},
+	}, {
+		Tag:    "unit-mysql-0",
+		Status: params.DetailedStatus{Status: "maintenance", Info: "installing", Kind: "workload", Since: s.next()},
+	}, {
+		Tag:    "application-mysql",
+		Status: params.DetailedStatus{Status: "blocked", Info: "missing relation", Kind: "application", Since: s.next()},
+	}}
+	expected := "" +
+		"Time                  Entity   Type          Status       Message\n" +
+		"2018-06-01 14:30:00Z  0        juju-machine  started      \n" +
+		"2018-06-01 14:31:00Z  mysql/0  workload      maintenance  installing\n" +
+		"2018-06-01 14:32:00Z  mysql    application   blocked      missing relation\n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Check(s.api.args, jc.DeepEquals, params.StatusTimelineRequest{Size: 50})
+}
+
+func (s *StatusTimelineSuite) TestResultsYaml(c *gc.C) {
+	s.api.entries = []params.StatusTimelineEntry{{
+		Tag:    "unit-mysql-0",
+		Status: params.DetailedStatus{Status: "maintenance", Info: "installing", Kind: "workload", Since: s.next()},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var entries []statuscmd.TimelineEntry
+	err = goyaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &entries)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []statuscmd.TimelineEntry{{
+		Time:    "2018-06-01 14:30:00Z",
+		Entity:  "mysql/0",
+		Type:    "workload",
+		Status:  "maintenance",
+		Message: "installing",
+	}})
+}
+
+func (s *StatusTimelineSuite) TestWindowAndTypes(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--from", "2018-06-01",
+		"--to", "2018-06-01T15:00:00Z",
+		"--type", "workload, application",
+		"-n", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 6, 1, 15, 0, 0, 0, time.UTC)
+	c.Check(s.api.args, jc.DeepEquals, params.StatusTimelineRequest{
+		From:  &from,
+		To:    &to,
+		Kinds: []string{"workload", "application"},
+		Size:  10,
+	})
+}
+
+func (s *StatusTimelineSuite) TestSince(c *gc.C) {
+	before := time.Now()
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--since", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args.From, gc.NotNil)
+	c.Check(s.api.args.From.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(s.api.args.From.After(time.Now().Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(s.api.args.Size, gc.Equals, 0)
+}
+
+func (s *StatusTimelineSuite) TestNoResults(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No status changes to display.\n")
+}
+
+func (s *StatusTimelineSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *StatusTimelineSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/0"},
+		err:  `unrecognized args: \["mysql/0"\]`,
+	}, {
+		args: []string{"--since", "1h", "--from", "2018-06-01"},
+		err:  "--since and --from cannot be specified together",
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `parsing --from: expected a date \(YYYY-MM-DD\) or RFC3339 time, got "yesterday"`,
+	}, {
+		args: []string{"--from", "2018-06-02", "--to", "2018-06-01"},
+		err:  "--to must not be before --from",
+	}, {
+		args: []string{"--type", "volume"},
+		err:  `unexpected status type "volume"`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  "-n must be a positive number",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(s.newCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeTimelineAPI struct {
+	args    params.StatusTimelineRequest
+	entries []params.StatusTimelineEntry
+	err     error
+}
+
+func (*fakeTimelineAPI) Close() error {
+	return nil
+}
+
+func (f *fakeTimelineAPI) StatusTimeline(args params.StatusTimelineRequest) ([]params.StatusTimelineEntry, error) {
+	f.args = args
+	return f.entries, f.err
+}
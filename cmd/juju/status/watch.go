@@ -0,0 +1,392 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// clearScreen moves the cursor to the top left corner
+// and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var watchAllForStatus = func(client statusAPI) (allWatcher, error) {
+	apiClient, ok := client.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status with %T", client)
+	}
+	return apiClient.WatchAll()
+}
+
+// runWatch renders the initial status, and then keeps it up to date
+// from the deltas reported by the model's all watcher until the
+// command is interrupted. The deltas are applied to the status in
+// place, and the full status is only fetched again when that isn't
+// possible: when a relation changes, when an entity that isn't being
+// displayed may now be, or when a unit being displayed no longer
+// matches the status filters.
+func (c *statusCommand) runWatch(ctx *cmd.Context, client statusAPI, status *params.FullStatus, showRelations bool) error {
+	watcher, err := watchAllForStatus(client)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	type nextResult struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	results := make(chan nextResult, 1)
+	next := func() {
+		deltas, err := watcher.Next()
+		results <- nextResult{deltas, err}
+	}
+
+	// ignored records the entities that were left out of the status
+	// when it was last fetched, so that further changes to them don't
+	// cause it to be fetched again.
+	ignored := make(map[string]bool)
+	filter := newWatchFilter(c)
+
+	if err := c.writeFrame(ctx, status, showRelations); err != nil {
+		return errors.Trace(err)
+	}
+	go next()
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			missing, ok := applyStatusDeltas(status, result.deltas)
+			if !ok || filter.needsFetch(status, missing, ignored) {
+				logger.Debugf("fetching full status")
+				refreshed, err := c.fetchStatus(client)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				status = refreshed
+				for _, delta := range missing {
+					if !delta.Removed && !statusHasEntity(status, delta.Entity) {
+						ignored[filter.entityKey(delta.Entity)] = true
+					}
+				}
+			}
+			if err := c.writeFrame(ctx, status, showRelations); err != nil {
+				return errors.Trace(err)
+			}
+			go next()
+		}
+	}
+}
+
+// writeFrame renders the status, replacing the previous rendering
+// if the output is a terminal.
+func (c *statusCommand) writeFrame(ctx *cmd.Context, status *params.FullStatus, showRelations bool) error {
+	if f, ok := ctx.Stdout.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(ctx.Stdout, clearScreen)
+	}
+	return c.render(ctx, status, showRelations)
+}
+
+// watchFilter matches units against the status filters of the
+// command, as the controller does, so that changes to units can be
+// checked without fetching the full status.
+type watchFilter struct {
+	workloadStatuses set.Strings
+	agentStatuses    set.Strings
+	message          string
+}
+
+// newWatchFilter returns a filter for the command's status filters,
+// or nil if there are none.
+func newWatchFilter(c *statusCommand) *watchFilter {
+	if !c.statusFiltered() {
+		return nil
+	}
+	return &watchFilter{
+		workloadStatuses: set.NewStrings(c.workloadStatuses...),
+		agentStatuses:    set.NewStrings(c.agentStatuses...),
+		message:          strings.ToLower(c.message),
+	}
+}
+
+// match reports whether a unit with the given workload and agent
+// statuses matches the filter.
+func (f *watchFilter) match(workload, agent params.DetailedStatus) bool {
+	if !f.workloadStatuses.IsEmpty() && !f.workloadStatuses.Contains(workload.Status) {
+		return false
+	}
+	if !f.agentStatuses.IsEmpty() && !f.agentStatuses.Contains(agent.Status) {
+		return false
+	}
+	if f.message != "" &&
+		!strings.Contains(strings.ToLower(workload.Info), f.message) &&
+		!strings.Contains(strings.ToLower(agent.Info), f.message) {
+		return false
+	}
+	return true
+}
+
+// unitShown reports whether the unit would still be displayed: it
+// must match the filter itself, or have only matching subordinates.
+func (f *watchFilter) unitShown(unit params.UnitStatus) bool {
+	if f.match(unit.WorkloadStatus, unit.AgentStatus) {
+		return true
+	}
+	if len(unit.Subordinates) == 0 {
+		return false
+	}
+	for _, sub := range unit.Subordinates {
+		if !f.unitShown(sub) {
+			return false
+		}
+	}
+	return true
+}
+
+// needsFetch reports whether the full status must be fetched again
+// after the deltas have been applied, given the deltas for entities
+// that aren't in the status.
+func (f *watchFilter) needsFetch(status *params.FullStatus, missing []multiwatcher.Delta, ignored map[string]bool) bool {
+	if f != nil {
+		for _, app := range status.Applications {
+			for _, unit := range app.Units {
+				if !f.unitShown(unit) {
+					return true
+				}
+			}
+		}
+	}
+	for _, delta := range missing {
+		if delta.Removed || ignored[f.entityKey(delta.Entity)] {
+			continue
+		}
+		if f == nil {
+			return true
+		}
+		// When filtering by status, other entities are only
+		// displayed along with matching units.
+		if unit, ok := delta.Entity.(*multiwatcher.UnitInfo); ok {
+			if f.match(
+				detailedStatus(params.DetailedStatus{}, unit.WorkloadStatus),
+				detailedStatus(params.DetailedStatus{}, unit.AgentStatus),
+			) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// entityKey identifies an entity that isn't displayed. A unit's key
+// includes its machine, since a unit may match a machine pattern once
+// it's placed; and when filtering by status, its statuses too.
+func (f *watchFilter) entityKey(entity multiwatcher.EntityInfo) string {
+	switch info := entity.(type) {
+	case *multiwatcher.UnitInfo:
+		key := fmt.Sprintf("unit %s on %q", info.Name, info.MachineId)
+		if f != nil {
+			key += fmt.Sprintf(" %s %s %q %q",
+				info.WorkloadStatus.Current, info.AgentStatus.Current,
+				info.WorkloadStatus.Message, info.AgentStatus.Message,
+			)
+		}
+		return key
+	default:
+		id := entity.EntityId()
+		return fmt.Sprintf("%s %s", id.Kind, id.Id)
+	}
+}
+
+// statusHasEntity reports whether the entity is in the status.
+func statusHasEntity(status *params.FullStatus, entity multiwatcher.EntityInfo) bool {
+	switch info := entity.(type) {
+	case *multiwatcher.MachineInfo:
+		return hasMachine(status.Machines, info.Id)
+	case *multiwatcher.ApplicationInfo:
+		_, ok := status.Applications[info.Name]
+		return ok
+	case *multiwatcher.UnitInfo:
+		for _, app := range status.Applications {
+			if hasUnit(app.Units, info.Name) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func hasMachine(machines map[string]params.MachineStatus, id string) bool {
+	for machineId, machine := range machines {
+		if machineId == id || hasMachine(machine.Containers, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUnit(units map[string]params.UnitStatus, name string) bool {
+	for unitName, unit := range units {
+		if unitName == name || hasUnit(unit.Subordinates, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyStatusDeltas updates the status in place with the changes
+// reported by the all watcher. It returns the deltas for entities
+// that aren't in the status; they may be new, or may now match the
+// filters the status was fetched with. It returns false if a
+// relation has changed, since relations aren't updated in place.
+func applyStatusDeltas(status *params.FullStatus, deltas []multiwatcher.Delta) ([]multiwatcher.Delta, bool) {
+	var missing []multiwatcher.Delta
+	for _, delta := range deltas {
+		var found bool
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			status.Model.ModelStatus = detailedStatus(status.Model.ModelStatus, info.Status)
+			found = true
+		case *multiwatcher.MachineInfo:
+			found = applyMachineDelta(status.Machines, info, delta.Removed)
+		case *multiwatcher.ApplicationInfo:
+			found = applyApplicationDelta(status.Applications, info, delta.Removed)
+		case *multiwatcher.UnitInfo:
+			found = applyUnitDelta(status.Applications, info, delta.Removed)
+		case *multiwatcher.RelationInfo:
+			return nil, false
+		default:
+			found = true
+		}
+		if !found {
+			missing = append(missing, delta)
+		}
+	}
+	now := time.Now()
+	status.ControllerTimestamp = &now
+	return missing, true
+}
+
+func applyMachineDelta(machines map[string]params.MachineStatus, info *multiwatcher.MachineInfo, removed bool) bool {
+	for id, machine := range machines {
+		if id != info.Id {
+			if applyMachineDelta(machine.Containers, info, removed) {
+				return true
+			}
+			continue
+		}
+		if removed {
+			delete(machines, id)
+			return true
+		}
+		machine.AgentStatus = detailedStatus(machine.AgentStatus, info.AgentStatus)
+		machine.InstanceStatus = detailedStatus(machine.InstanceStatus, info.InstanceStatus)
+		machine.InstanceId = info.InstanceId
+		machine.Series = info.Series
+		machine.HasVote = info.HasVote
+		machine.WantsVote = info.WantsVote
+		machines[id] = machine
+		return true
+	}
+	return false
+}
+
+func applyApplicationDelta(applications map[string]params.ApplicationStatus, info *multiwatcher.ApplicationInfo, removed bool) bool {
+	app, ok := applications[info.Name]
+	if !ok {
+		return false
+	}
+	if removed {
+		delete(applications, info.Name)
+		return true
+	}
+	app.Exposed = info.Exposed
+	app.Life = string(info.Life)
+	app.Status = detailedStatus(app.Status, info.Status)
+	app.WorkloadVersion = info.WorkloadVersion
+	applications[info.Name] = app
+	return true
+}
+
+func applyUnitDelta(applications map[string]params.ApplicationStatus, info *multiwatcher.UnitInfo, removed bool) bool {
+	for _, app := range applications {
+		if applyUnitDeltaTo(app.Units, info, removed) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyUnitDeltaTo(units map[string]params.UnitStatus, info *multiwatcher.UnitInfo, removed bool) bool {
+	for name, unit := range units {
+		if name != info.Name {
+			if applyUnitDeltaTo(unit.Subordinates, info, removed) {
+				return true
+			}
+			continue
+		}
+		if removed {
+			delete(units, name)
+			return true
+		}
+		unit.WorkloadStatus = detailedStatus(unit.WorkloadStatus, info.WorkloadStatus)
+		unit.AgentStatus = detailedStatus(unit.AgentStatus, info.AgentStatus)
+		unit.PublicAddress = info.PublicAddress
+		unit.Machine = info.MachineId
+		unit.OpenedPorts = openedPorts(info.PortRanges)
+		units[name] = unit
+		return true
+	}
+	return false
+}
+
+func openedPorts(portRanges []multiwatcher.PortRange) []string {
+	var ports []string
+	for _, r := range portRanges {
+		if r.FromPort == r.ToPort {
+			ports = append(ports, fmt.Sprintf("%d/%s", r.FromPort, r.Protocol))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d-%d/%s", r.FromPort, r.ToPort, r.Protocol))
+		}
+	}
+	return ports
+}
+
+// detailedStatus returns the existing status updated with
+// the status reported by the all watcher.
+func detailedStatus(existing params.DetailedStatus, info multiwatcher.StatusInfo) params.DetailedStatus {
+	existing.Status = string(info.Current)
+	existing.Info = info.Message
+	existing.Since = info.Since
+	existing.Data = info.Data
+	if info.Version != "" {
+		existing.Version = info.Version
+	}
+	return existing
+}
@@ -0,0 +1,345 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type WatchSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func watchTestStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				AgentStatus: params.DetailedStatus{Status: "pending"},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:          "0/lxd/0",
+						AgentStatus: params.DetailedStatus{Status: "pending"},
+					},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Status: params.DetailedStatus{Status: "waiting"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+						AgentStatus:    params.DetailedStatus{Status: "allocating"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *WatchSuite) TestApplyUpdates(c *gc.C) {
+	fullStatus := watchTestStatus()
+	missing, ok := applyStatusDeltas(fullStatus, []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0/lxd/0",
+			InstanceId:  "juju-0-lxd-0",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			PortRanges:     []multiwatcher.PortRange{{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "logging/0",
+			Application:    "logging",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(missing, gc.HasLen, 0)
+
+	container := fullStatus.Machines["0"].Containers["0/lxd/0"]
+	c.Assert(string(container.InstanceId), gc.Equals, "juju-0-lxd-0")
+	c.Assert(container.AgentStatus.Status, gc.Equals, "started")
+
+	unit := fullStatus.Applications["mysql"].Units["mysql/0"]
+	c.Assert(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Assert(unit.WorkloadStatus.Info, gc.Equals, "ready")
+	c.Assert(unit.AgentStatus.Status, gc.Equals, "idle")
+	c.Assert(unit.OpenedPorts, jc.DeepEquals, []string{"3306/tcp"})
+	c.Assert(unit.Subordinates["logging/0"].WorkloadStatus.Status, gc.Equals, "active")
+	c.Assert(fullStatus.ControllerTimestamp, gc.NotNil)
+}
+
+func (s *WatchSuite) TestApplyRemoval(c *gc.C) {
+	fullStatus := watchTestStatus()
+	missing, ok := applyStatusDeltas(fullStatus, []multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "logging/0"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(missing, gc.HasLen, 0)
+	c.Assert(fullStatus.Machines["0"].Containers, gc.HasLen, 0)
+	c.Assert(fullStatus.Applications["mysql"].Units["mysql/0"].Subordinates, gc.HasLen, 0)
+}
+
+func (s *WatchSuite) TestApplyMissing(c *gc.C) {
+	for i, delta := range []multiwatcher.Delta{
+		{Entity: &multiwatcher.UnitInfo{Name: "mysql/1", Application: "mysql"}},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+		{Entity: &multiwatcher.MachineInfo{Id: "1"}},
+	} {
+		c.Logf("test %d", i)
+		missing, ok := applyStatusDeltas(watchTestStatus(), []multiwatcher.Delta{delta})
+		c.Check(ok, jc.IsTrue)
+		c.Check(missing, jc.DeepEquals, []multiwatcher.Delta{delta})
+	}
+}
+
+func (s *WatchSuite) TestApplyRelationNeedsRefresh(c *gc.C) {
+	_, ok := applyStatusDeltas(watchTestStatus(), []multiwatcher.Delta{{
+		Entity: &multiwatcher.RelationInfo{Key: "wordpress:db mysql:server"},
+	}})
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *WatchSuite) TestFilterNeedsFetch(c *gc.C) {
+	filter := newWatchFilter(&statusCommand{workloadStatuses: []string{"waiting", "active"}})
+	ignored := make(map[string]bool)
+	fullStatus := watchTestStatus()
+	c.Assert(filter.needsFetch(fullStatus, nil, ignored), jc.IsFalse)
+
+	// A unit that doesn't match the filter isn't fetched.
+	blocked := multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           "mysql/1",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: status.Blocked},
+	}}
+	c.Assert(filter.needsFetch(fullStatus, []multiwatcher.Delta{blocked}, ignored), jc.IsFalse)
+
+	// A unit that now matches the filter is.
+	active := multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           "mysql/1",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active},
+	}}
+	c.Assert(filter.needsFetch(fullStatus, []multiwatcher.Delta{active}, ignored), jc.IsTrue)
+
+	// Unless it was left out the last time the status was fetched.
+	ignored[filter.entityKey(active.Entity)] = true
+	c.Assert(filter.needsFetch(fullStatus, []multiwatcher.Delta{active}, ignored), jc.IsFalse)
+
+	// Other entities are only displayed along with matching units.
+	machine := multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "1"}}
+	c.Assert(filter.needsFetch(fullStatus, []multiwatcher.Delta{machine}, ignored), jc.IsFalse)
+
+	// A displayed unit that no longer matches the filter is removed
+	// by fetching the status again.
+	unit := fullStatus.Applications["mysql"].Units["mysql/0"]
+	unit.WorkloadStatus.Status = "blocked"
+	fullStatus.Applications["mysql"].Units["mysql/0"] = unit
+	c.Assert(filter.needsFetch(fullStatus, nil, ignored), jc.IsTrue)
+}
+
+func (s *WatchSuite) TestUnfilteredNeedsFetch(c *gc.C) {
+	var filter *watchFilter
+	ignored := make(map[string]bool)
+	delta := multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}}
+	c.Assert(filter.needsFetch(watchTestStatus(), []multiwatcher.Delta{delta}, ignored), jc.IsTrue)
+	ignored[filter.entityKey(delta.Entity)] = true
+	c.Assert(filter.needsFetch(watchTestStatus(), []multiwatcher.Delta{delta}, ignored), jc.IsFalse)
+	delta.Removed = true
+	delete(ignored, filter.entityKey(delta.Entity))
+	c.Assert(filter.needsFetch(watchTestStatus(), []multiwatcher.Delta{delta}, ignored), jc.IsFalse)
+}
+
+type fakeStatusWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeStatusWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher stopped")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeStatusWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	client := fakeAPIClient{statusReturn: watchTestStatus()}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:           "mysql/0",
+				Application:    "mysql",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+				AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			},
+		}}},
+	}
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--watch", "--format", "oneline")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(string(stderr), gc.Equals, "ERROR watching model: watcher stopped\n")
+	c.Assert(watcher.stopped, jc.IsTrue)
+
+	frames := strings.Split(strings.TrimSpace(string(stdout)), "\n\n")
+	c.Assert(frames, gc.HasLen, 2)
+	c.Assert(frames[0], jc.Contains, "mysql/0")
+	c.Assert(frames[0], jc.Contains, "allocating")
+	c.Assert(frames[1], jc.Contains, "idle")
+}
+
+// sequenceStatusClient returns each of its statuses in turn.
+type sequenceStatusClient struct {
+	statuses []*params.FullStatus
+	argsUsed []params.StatusParams
+}
+
+func (a *sequenceStatusClient) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	a.argsUsed = append(a.argsUsed, args)
+	status := a.statuses[0]
+	if len(a.statuses) > 1 {
+		a.statuses = a.statuses[1:]
+	}
+	return status, nil
+}
+
+func (a *sequenceStatusClient) Close() error {
+	return nil
+}
+
+func (s *StatusSuite) TestStatusWatchFilteredFetchesNewEntities(c *gc.C) {
+	refreshed := watchTestStatus()
+	refreshed.Applications["mysql"].Units["mysql/1"] = params.UnitStatus{
+		WorkloadStatus: params.DetailedStatus{Status: "maintenance"},
+		AgentStatus:    params.DetailedStatus{Status: "executing"},
+	}
+	client := &sequenceStatusClient{
+		statuses: []*params.FullStatus{watchTestStatus(), refreshed},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:        "mysql/1",
+				Application: "mysql",
+			},
+		}}},
+	}
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, _ := runStatus(c, "--watch", "--format", "oneline", "mysql/*")
+	c.Assert(code, gc.Equals, 1)
+
+	// The new unit may match the pattern, so the full
+	// status is fetched again and the unit is shown.
+	frames := strings.Split(strings.TrimSpace(string(stdout)), "\n\n")
+	c.Assert(frames, gc.HasLen, 2)
+	c.Assert(frames[0], gc.Not(jc.Contains), "mysql/1")
+	c.Assert(frames[1], jc.Contains, "mysql/1")
+	c.Assert(client.argsUsed, gc.HasLen, 2)
+	c.Assert(client.argsUsed[1].Patterns, jc.DeepEquals, []string{"mysql/*"})
+}
+
+func (s *StatusSuite) TestStatusWatchFilteredAppliesDeltas(c *gc.C) {
+	client := &sequenceStatusClient{
+		statuses: []*params.FullStatus{watchTestStatus()},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:           "mysql/0",
+				Application:    "mysql",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+				AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			},
+		}}},
+	}
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, _ := runStatus(c, "--watch", "--format", "oneline", "--status", "waiting,active")
+	c.Assert(code, gc.Equals, 1)
+
+	// The unit still matches the filter, so the change is applied
+	// without fetching the status again.
+	frames := strings.Split(strings.TrimSpace(string(stdout)), "\n\n")
+	c.Assert(frames, gc.HasLen, 2)
+	c.Assert(frames[1], jc.Contains, "idle")
+	c.Assert(client.argsUsed, gc.HasLen, 1)
+}
+
+func (s *StatusSuite) TestStatusWatchFilteredIgnoresUnmatchedEntities(c *gc.C) {
+	client := &sequenceStatusClient{
+		statuses: []*params.FullStatus{watchTestStatus()},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	delta := multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "wordpress/0",
+			Application: "wordpress",
+		},
+	}
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{delta}, {delta}, {delta}},
+	}
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, _, _ := runStatus(c, "--watch", "--format", "oneline", "mysql/*")
+	c.Assert(code, gc.Equals, 1)
+
+	// The unit doesn't match the pattern, so the status is only
+	// fetched again the first time it changes.
+	c.Assert(client.argsUsed, gc.HasLen, 2)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewListCommand returns a command for listing storage instances.
@@ -31,7 +32,7 @@ List information about storage.
 // listCommand returns storage instances.
 type listCommand struct {
 	StorageCommandBase
-	out        cmd.Output
+	out        output.Output
 	ids        []string
 	filesystem bool
 	volume     bool
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // PoolCommandBase is a helper base structure for pool commands.
@@ -61,7 +62,7 @@ type poolListCommand struct {
 	newAPIFunc func() (PoolListAPI, error)
 	Providers  []string
 	Names      []string
-	out        cmd.Output
+	out        output.Output
 }
 
 // Init implements Command.Init.
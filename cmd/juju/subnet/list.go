@@ -32,7 +32,7 @@ type ListCommand struct {
 
 	spaceTag *names.SpaceTag
 
-	Out cmd.Output
+	Out output.Output
 }
 
 const listCommandDoc = `
@@ -42,8 +42,8 @@ subnets associated with a given network space and/or availability zone.
 
 Like with other Juju commands, the output and its format can be changed
 using the --format and --output (or -o) optional arguments. Supported
-output formats include "yaml" (default) and "json". To redirect the
-output to a file, use --output.
+output formats include "yaml" (default), "json" and "template=<go template>".
+To redirect the output to a file, use --output.
 `
 
 // Info is defined on the cmd.Command interface.
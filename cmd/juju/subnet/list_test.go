@@ -146,6 +146,11 @@ subnets:
 		`"space":"dmz",` +
 		`"zones":["zone2"]}}}
 `
+	expectedTemplate := `
+10.10.0.0/16 vlan-42
+10.20.0.0/24 public
+2001:db8::/32 dmz
+`[1:]
 
 	assertAPICalls := func() {
 		// Verify the API calls and reset the recorded calls.
@@ -209,6 +214,7 @@ subnets:
 		{"", expectedYAML}, // default format is YAML
 		{"yaml", expectedYAML},
 		{"json", expectedJSON},
+		{"template={{range $cidr, $subnet := .Subnets}}{{$cidr}} {{$subnet.Space}}\n{{end}}", expectedTemplate},
 	} {
 		c.Logf("test #%d: format %q", i, test.format)
 		assertOutput(test.format, test.expected)
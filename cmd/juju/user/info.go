@@ -48,7 +48,7 @@ type infoCommandBase struct {
 	api       UserInfoAPI
 	clock     clock.Clock
 	exactTime bool
-	out       cmd.Output
+	out       output.Output
 }
 
 func (c *infoCommandBase) SetFlags(f *gnuflag.FlagSet) {
@@ -575,6 +575,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			},
 			ControllerLeaseDuration:           time.Minute,
 			LogPruneInterval:                  5 * time.Minute,
+			BackupRetryDelay:                  10 * time.Minute,
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.set,
@@ -43,6 +43,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -206,6 +207,10 @@ type ManifoldsConfig struct {
 	// the database.
 	LogPruneInterval time.Duration
 
+	// BackupRetryDelay defines how long to wait before trying again
+	// when a scheduled controller backup fails.
+	BackupRetryDelay time.Duration
+
 	// TransactionPruneInterval defines how frequently mgo/txn transactions
 	// are pruned from the database.
 	TransactionPruneInterval time.Duration
@@ -693,6 +698,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				RetryDelay: config.BackupRetryDelay,
+				NewBackups: backupscheduler.NewBackups,
+				NewWorker:  backupscheduler.NewWorker,
+			},
+		))),
+
 		txnPrunerName: ifNotMigrating(ifPrimaryController(txnpruner.Manifold(
 			txnpruner.ManifoldConfig{
 				ClockName:     clockName,
@@ -785,12 +801,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		}))),
 
 		raftName: raft.Manifold(raft.ManifoldConfig{
-			ClockName:     clockName,
-			AgentName:     agentName,
-			TransportName: raftTransportName,
-			FSM:           leaseFSM,
-			Logger:        loggo.GetLogger("juju.worker.raft"),
-			NewWorker:     raft.NewWorker,
+			ClockName:      clockName,
+			AgentName:      agentName,
+			TransportName:  raftTransportName,
+			CentralHubName: centralHubName,
+			FSM:            leaseFSM,
+			Logger:         loggo.GetLogger("juju.worker.raft"),
+			NewWorker:      raft.NewWorker,
 		}),
 
 		raftFlagName: raftflag.Manifold(raftflag.ManifoldConfig{
@@ -959,6 +976,7 @@ const (
 	isPrimaryControllerFlagName   = "is-primary-controller-flag"
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	txnPrunerName                 = "transaction-pruner"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
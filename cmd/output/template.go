@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+const (
+	// TemplateFormat is the name of the format that renders
+	// output with a Go template.
+	TemplateFormat = "template"
+
+	templatePrefix = TemplateFormat + "="
+)
+
+// templateFuncs holds the functions available to output templates,
+// in addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// Output extends cmd.Output with a format that renders the value
+// written with a Go text/template, specified on the command line
+// with --format template=<template>. The template is applied to the
+// same value that the yaml and json formats marshal, so the fields
+// are named as in the Go structs being formatted.
+type Output struct {
+	cmd.Output
+	template *template.Template
+}
+
+// AddFlags injects the --format and --output command line flags
+// into f, as for cmd.Output, accepting the template format along
+// with the given formatters.
+func (c *Output) AddFlags(f *gnuflag.FlagSet, defaultFormatter string, formatters map[string]cmd.Formatter) {
+	all := make(map[string]cmd.Formatter, len(formatters)+1)
+	for name, formatter := range formatters {
+		all[name] = formatter
+	}
+	all[TemplateFormat] = c.formatTemplate
+	c.Output.AddFlags(f, defaultFormatter, all)
+
+	flag := f.Lookup("format")
+	flag.Value = &templateFormatValue{Value: flag.Value, output: c}
+	flag.Usage += "; use template=<go template> to format with a template"
+}
+
+func (c *Output) formatTemplate(writer io.Writer, value interface{}) error {
+	if c.template == nil {
+		return errors.New("no output template specified")
+	}
+	var buf bytes.Buffer
+	if err := c.template.Execute(&buf, value); err != nil {
+		return errors.Annotate(err, "executing output template")
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	_, err := writer.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// templateFormatValue wraps the value of the --format flag to parse
+// the template given with the template format.
+type templateFormatValue struct {
+	gnuflag.Value
+	output *Output
+}
+
+// Set implements gnuflag.Value.
+func (v *templateFormatValue) Set(value string) error {
+	if value == TemplateFormat {
+		return errors.Errorf("%s format requires a template, for example %s{{.Name}}", TemplateFormat, templatePrefix)
+	}
+	if !strings.HasPrefix(value, templatePrefix) {
+		v.output.template = nil
+		return v.Value.Set(value)
+	}
+	t, err := template.New(TemplateFormat).Funcs(templateFuncs).Parse(value[len(templatePrefix):])
+	if err != nil {
+		return errors.Annotate(err, "parsing output template")
+	}
+	v.output.template = t
+	return v.Value.Set(TemplateFormat)
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/output"
+)
+
+type TemplateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TemplateSuite{})
+
+type templateValue struct {
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags"`
+	Count int      `json:"count"`
+}
+
+func (s *TemplateSuite) write(c *gc.C, args ...string) (string, error) {
+	var out output.Output
+	f := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	out.AddFlags(f, "yaml", output.DefaultFormatters)
+	if err := f.Parse(true, args); err != nil {
+		return "", err
+	}
+	ctx := cmdtesting.Context(c)
+	err := out.Write(ctx, templateValue{
+		Name:  "mysql",
+		Tags:  []string{"db", "sql"},
+		Count: 3,
+	})
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *TemplateSuite) TestTemplate(c *gc.C) {
+	stdout, err := s.write(c, "--format", "template={{.Name}} has {{.Count}} units")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, "mysql has 3 units\n")
+}
+
+func (s *TemplateSuite) TestTemplateFuncs(c *gc.C) {
+	stdout, err := s.write(c, "--format", `template={{upper .Name}} {{join .Tags ","}} {{json .Tags}}{{"\n"}}`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, `MYSQL db,sql ["db","sql"]`+"\n")
+}
+
+func (s *TemplateSuite) TestOtherFormatters(c *gc.C) {
+	stdout, err := s.write(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, `{"name":"mysql","tags":["db","sql"],"count":3}`+"\n")
+}
+
+func (s *TemplateSuite) TestTemplateMissing(c *gc.C) {
+	_, err := s.write(c, "--format", "template")
+	c.Assert(err, gc.ErrorMatches, `.*template format requires a template, for example template=\{\{\.Name\}\}`)
+}
+
+func (s *TemplateSuite) TestTemplateInvalid(c *gc.C) {
+	_, err := s.write(c, "--format", "template={{.Name")
+	c.Assert(err, gc.ErrorMatches, `.*parsing output template: .*`)
+}
+
+func (s *TemplateSuite) TestTemplateExecuteError(c *gc.C) {
+	_, err := s.write(c, "--format", "template={{.Missing}}")
+	c.Assert(err, gc.ErrorMatches, `executing output template: .*`)
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"time"
 
@@ -128,6 +129,37 @@ const (
 	// default value of 1M BatchSize and 100 passes will be used instead.
 	MaxPruneTxnPasses = "max-prune-txn-passes"
 
+	// BackupInterval is the interval at which the controller creates
+	// backups of itself, eg "24h". Scheduled backups are disabled if
+	// it is not set, or is zero.
+	BackupInterval = "backup-interval"
+
+	// BackupRetainDaily is the number of days for which the most
+	// recent scheduled backup of each day is kept.
+	BackupRetainDaily = "backup-retain-daily"
+
+	// BackupRetainWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept.
+	BackupRetainWeekly = "backup-retain-weekly"
+
+	// BackupStorage is where the archives of new backups are stored.
+	// It is empty, or "controller", to store them in the controller's
+	// database, "file:///<dir>" to store them in a directory shared by
+	// all the controller machines, such as an NFS mount, or
+	// "s3://<bucket>[/<prefix>][?endpoint=<url>&region=<region>]"
+	// to store them in an S3 compatible object store.
+	BackupStorage = "backup-storage"
+
+	// BackupS3AccessKey is the access key used to store
+	// backup archives in an S3 compatible object store.
+	// It is not sent to clients or agents.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to store
+	// backup archives in an S3 compatible object store.
+	// It is not sent to clients or agents.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -175,6 +207,14 @@ const (
 	// DefaultMaxPruneTxnPasses is the default number of batches we will process
 	DefaultMaxPruneTxnPasses = 100
 
+	// DefaultBackupRetainDaily is the default number of daily
+	// scheduled backups to keep.
+	DefaultBackupRetainDaily = 7
+
+	// DefaultBackupRetainWeekly is the default number of weekly
+	// scheduled backups to keep.
+	DefaultBackupRetainWeekly = 4
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -225,6 +265,12 @@ var (
 		CAASOperatorImagePath,
 		Features,
 		MeteringURL,
+		BackupInterval,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupStorage,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -240,6 +286,12 @@ var (
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		Features,
+		BackupInterval,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupStorage,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -515,6 +567,88 @@ func (c Config) MaxPruneTxnPasses() int {
 	return c.intOrDefault(MaxPruneTxnPasses, DefaultMaxPruneTxnPasses)
 }
 
+// BackupInterval is the interval at which the controller creates
+// scheduled backups. Zero means scheduled backups are disabled.
+func (c Config) BackupInterval() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupInterval))
+	return val
+}
+
+// BackupRetainDaily is the number of days for which the most recent
+// scheduled backup of each day is kept.
+func (c Config) BackupRetainDaily() int {
+	return c.backupRetention(BackupRetainDaily, DefaultBackupRetainDaily)
+}
+
+// BackupRetainWeekly is the number of weeks for which the most recent
+// scheduled backup of each week is kept.
+func (c Config) BackupRetainWeekly() int {
+	return c.backupRetention(BackupRetainWeekly, DefaultBackupRetainWeekly)
+}
+
+// backupRetention returns the named backup retention count. Unlike
+// other integer attributes, zero is a valid value.
+func (c Config) backupRetention(name string, defaultVal int) int {
+	// Values obtained over the api are encoded as float64.
+	switch value := c[name].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return defaultVal
+}
+
+// BackupStorage is where the archives of new backups are stored.
+// An empty value means the controller's database.
+func (c Config) BackupStorage() string {
+	if target := c.asString(BackupStorage); target != BackupStorageController {
+		return target
+	}
+	return ""
+}
+
+// BackupS3Credentials returns the access and secret keys used to
+// store backup archives in an S3 compatible object store.
+func (c Config) BackupS3Credentials() (accessKey, secretKey string) {
+	return c.asString(BackupS3AccessKey), c.asString(BackupS3SecretKey)
+}
+
+// BackupStorageController is the backup storage target
+// naming the controller's own database.
+const BackupStorageController = "controller"
+
+// ValidateBackupStorage returns an error if the given
+// backup storage target is not valid.
+func ValidateBackupStorage(target string) error {
+	if target == "" || target == BackupStorageController {
+		return nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return errors.Annotate(err, "invalid backup storage")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Host != "" || !path.IsAbs(u.Path) {
+			return errors.Errorf(`invalid backup storage %q: expected "file:///<absolute path>"`, target)
+		}
+	case "s3":
+		if u.Host == "" {
+			return errors.Errorf(`invalid backup storage %q: missing bucket`, target)
+		}
+		if endpoint := u.Query().Get("endpoint"); endpoint != "" {
+			if e, err := url.Parse(endpoint); err != nil || e.Host == "" {
+				return errors.Errorf(`invalid backup storage %q: invalid endpoint %q`, target, endpoint)
+			}
+		}
+	default:
+		return errors.Errorf(`invalid backup storage %q: expected "controller", "file" or "s3" storage`, target)
+	}
+	return nil
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -636,6 +770,26 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupInterval].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid backup interval in configuration")
+		} else if d < 0 {
+			return errors.Errorf("invalid backup interval: should not be negative, got %q", v)
+		}
+	}
+
+	for _, key := range []string{BackupRetainDaily, BackupRetainWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("invalid %s: should be a number of backups, got %d", key, v)
+		}
+	}
+
+	if v, ok := c[BackupStorage].(string); ok {
+		if err := ValidateBackupStorage(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if v, ok := c[AuditLogExcludeMethods].([]interface{}); ok {
 		for i, name := range v {
 			name := name.(string)
@@ -729,6 +883,12 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
 	MeteringURL:             schema.String(),
+	BackupInterval:          schema.String(),
+	BackupRetainDaily:       schema.ForceInt(),
+	BackupRetainWeekly:      schema.ForceInt(),
+	BackupStorage:           schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -755,4 +915,10 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
 	MeteringURL:             romulus.DefaultAPIRoot,
+	BackupInterval:          schema.Omit,
+	BackupRetainDaily:       schema.Omit,
+	BackupRetainWeekly:      schema.Omit,
+	BackupStorage:           schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
 })
@@ -230,6 +230,55 @@ var validateTests = []struct {
 		controller.CAASOperatorImagePath: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "invalid backup interval",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupInterval: "daily",
+	},
+	expectError: `invalid backup interval in configuration: time: invalid duration daily`,
+}, {
+	about: "negative backup interval",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupInterval: "-1h",
+	},
+	expectError: `invalid backup interval: should not be negative, got "-1h"`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetainWeekly: -1,
+	},
+	expectError: `invalid backup-retain-weekly: should be a number of backups, got -1`,
+}, {
+	about: "unknown backup storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "ftp://backups.example.com/juju",
+	},
+	expectError: `invalid backup storage "ftp://backups.example.com/juju": expected "controller", "file" or "s3" storage`,
+}, {
+	about: "relative backup storage directory",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "file:backups",
+	},
+	expectError: `invalid backup storage "file:backups": expected "file:///<absolute path>"`,
+}, {
+	about: "backup storage without bucket",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "s3:///juju",
+	},
+	expectError: `invalid backup storage "s3:///juju": missing bucket`,
+}, {
+	about: "invalid backup storage endpoint",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "s3://backups/juju?endpoint=minio",
+	},
+	expectError: `invalid backup storage "s3://backups/juju\?endpoint=minio": invalid endpoint "minio"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -304,6 +353,57 @@ func (s *ConfigSuite) TestMaxPruneTxnConfigValue(c *gc.C) {
 	c.Check(cfg.MaxPruneTxnPasses(), gc.Equals, 10)
 }
 
+func (s *ConfigSuite) TestBackupConfigDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupInterval(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 7)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 4)
+}
+
+func (s *ConfigSuite) TestBackupConfigValue(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-interval":      "12h",
+			"backup-retain-daily":  "3",
+			"backup-retain-weekly": 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupInterval(), gc.Equals, 12*time.Hour)
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 3)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestBackupStorage(c *gc.C) {
+	for _, target := range []string{"", "controller"} {
+		cfg, err := controller.NewConfig(
+			testing.ControllerTag.Id(),
+			testing.CACert,
+			map[string]interface{}{"backup-storage": target},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cfg.BackupStorage(), gc.Equals, "")
+	}
+
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":       "s3://backups/juju?endpoint=https://minio.example.com:9000",
+			"backup-s3-access-key": "access",
+			"backup-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, "s3://backups/juju?endpoint=https://minio.example.com:9000")
+	accessKey, secretKey := cfg.BackupS3Credentials()
+	c.Check(accessKey, gc.Equals, "access")
+	c.Check(secretKey, gc.Equals, "secret")
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
@@ -38,6 +38,37 @@ type Claimer interface {
 	BlockUntilLeadershipReleased(applicationId string, cancel <-chan struct{}) (err error)
 }
 
+// ErrPinned is returned when an application's leadership can't be
+// changed because it is pinned.
+var ErrPinned = errors.New("leadership pinned")
+
+// Pinner exposes the ability to stop an application's leadership
+// changing hands, for example while an operator carries out
+// maintenance.
+type Pinner interface {
+
+	// PinLeadership ensures that the current leader of the named
+	// application, if any, keeps leadership even if it stops
+	// extending its claim, until every entity that pinned it has
+	// unpinned it.
+	PinLeadership(applicationId string, entity string) error
+
+	// UnpinLeadership removes the named entity's pin from the named
+	// application's leadership.
+	UnpinLeadership(applicationId string, entity string) error
+}
+
+// Transferrer exposes the ability to hand an application's leadership
+// to a chosen unit.
+type Transferrer interface {
+
+	// TransferLeadership makes the named unit the leader of the named
+	// application for at least the given duration, once the current
+	// leader's lease runs out. It returns ErrPinned if the
+	// application's leadership is pinned.
+	TransferLeadership(applicationId, unitId string, duration time.Duration) error
+}
+
 // Token represents a unit's leadership of its application.
 type Token interface {
 
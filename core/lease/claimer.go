@@ -25,6 +25,10 @@ var ErrClaimDenied = errors.New("lease claim denied")
 // ErrNotHeld indicates that some holder does not hold some lease.
 var ErrNotHeld = errors.New("lease not held")
 
+// ErrPinned indicates that an operation was refused because the lease
+// is pinned.
+var ErrPinned = errors.New("lease pinned")
+
 // ErrWaitCancelled is returned by Claimer.WaitUntilExpired if the
 // cancel channel is closed.
 var ErrWaitCancelled = errors.New("waiting for lease cancelled by client")
@@ -70,9 +74,42 @@ type Token interface {
 	Check(trapdoorKey interface{}) error
 }
 
-// Manager represents somewhere you can get Checkers and Claimers for
-// different models.
+// Pinner exposes the ability to stop leases expiring, so that they
+// can't change hands while an operator carries out maintenance.
+type Pinner interface {
+
+	// Pin ensures that the named lease will not expire, and so will be
+	// kept by its current holder, until the named entity unpins it. If
+	// other entities have pinned the lease it stays pinned until they
+	// have unpinned it too.
+	Pin(leaseName, entity string) error
+
+	// Unpin removes the named entity's pin from the named lease.
+	Unpin(leaseName, entity string) error
+
+	// Pinned returns the names of the pinned leases, and the entities
+	// that pinned each of them.
+	Pinned() map[string][]string
+}
+
+// Transferrer exposes the ability to hand a lease to a chosen holder.
+type Transferrer interface {
+
+	// Transfer ensures that the named holder holds the named lease for
+	// at least the supplied duration from when it gets it. If the lease
+	// is held by anyone else it is not taken from them: they can no
+	// longer extend it, and it is handed to the new holder when it
+	// expires, so that nobody else can claim it in between. Transfer
+	// returns once the transfer is recorded, without waiting for the
+	// lease to expire. It returns ErrPinned if the lease is pinned.
+	Transfer(leaseName, holderName string, duration time.Duration) error
+}
+
+// Manager represents somewhere you can get Checkers, Claimers, Pinners
+// and Transferrers for different models.
 type Manager interface {
 	Checker(namespace string, modelUUID string) (Checker, error)
 	Claimer(namespace string, modelUUID string) (Claimer, error)
+	Pinner(namespace string, modelUUID string) (Pinner, error)
+	Transferrer(namespace string, modelUUID string) (Transferrer, error)
 }
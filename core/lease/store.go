@@ -24,7 +24,8 @@ type Store interface {
 	// ExtendLease records the supplied holder's continued claim to the supplied
 	// lease, if necessary. If it succeeds, the claim is guaranteed until at
 	// least the supplied duration after the call to ExtendLease was initiated.
-	// If it returns ErrInvalid, check Leases() for updated state.
+	// If it returns ErrInvalid, check Leases() for updated state. It returns
+	// ErrClaimDenied if the lease is being transferred to someone else.
 	ExtendLease(lease Key, request Request) error
 
 	// ExpireLease records the vacation of the supplied lease. It will fail if
@@ -32,15 +33,41 @@ type Store interface {
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
 	ExpireLease(lease Key) error
 
+	// TransferLease records that the supplied lease is to pass from the
+	// supplied holder to the requested holder. The lease isn't taken from
+	// the holder before it expires, but the holder can no longer extend
+	// it; when it expires, ExpireLease claims it for the requested holder
+	// instead of vacating it. Pinning the lease cancels the transfer. If
+	// it returns ErrInvalid, check Leases() for updated state; it returns
+	// ErrPinned if the lease is pinned.
+	TransferLease(lease Key, holder string, request Request) error
+
+	// PinLease ensures that the supplied lease will not be expired
+	// until every entity that pinned it has unpinned it. Pinning a
+	// lease that is already pinned by the entity has no effect.
+	PinLease(lease Key, entity string) error
+
+	// UnpinLease removes the supplied entity's pin from the supplied
+	// lease. Unpinning a lease the entity hasn't pinned has no effect.
+	UnpinLease(lease Key, entity string) error
+
+	// Pinned returns a snapshot of the pinned leases, and the entities
+	// that pinned each of them.
+	Pinned() map[Key][]string
+
 	// Leases returns a recent snapshot of lease state. Expiry times are
 	// expressed according to the Clock the store was configured with.
 	Leases() map[Key]Info
 
-	// TODO (jam) 2017-10-31: Many callers of Leases() actually only tant
-	// exactly 1 lease, we should have a way to do a query to return exactly
-	// that lease, instead of having to read all of them to pull one out of the
-	// map. (Worst case it is implemented as exactly this, best case avoids
-	// reading lots of unused data.)
+	// LeaseGroup returns a recent snapshot of the state of the leases
+	// in the supplied namespace and model, as Leases would. Callers
+	// that only care about one group should prefer it to Leases, which
+	// copies every lease in the controller.
+	LeaseGroup(namespace, modelUUID string) map[Key]Info
+
+	// Lease returns a recent snapshot of the supplied lease's state,
+	// as Leases would, and whether the lease is held at all.
+	Lease(lease Key) (Info, bool)
 
 	// Refresh reads all lease state from the database.
 	Refresh() error
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+const (
+	benchModels          = 100
+	benchLeasesPerModel  = 50
+	benchNamespace       = "application-leadership"
+	benchModelUUIDFormat = "model-%d"
+)
+
+// FSMBenchSuite compares the cost of reading every lease with the
+// cost of the targeted queries, on a controller with thousands of
+// leases.
+type FSMBenchSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FSMBenchSuite{})
+
+func (s *FSMBenchSuite) newFSM(c *gc.C) *raftlease.FSM {
+	fsm := raftlease.NewFSM()
+	for m := 0; m < benchModels; m++ {
+		for l := 0; l < benchLeasesPerModel; l++ {
+			data, err := raftlease.Command{
+				Version:   1,
+				Operation: raftlease.OperationClaim,
+				Namespace: benchNamespace,
+				ModelUUID: fmt.Sprintf(benchModelUUIDFormat, m),
+				Lease:     fmt.Sprintf("app-%d", l),
+				Holder:    fmt.Sprintf("app-%d/0", l),
+				Duration:  time.Minute,
+			}.Marshal()
+			c.Assert(err, jc.ErrorIsNil)
+			err, _ = fsm.Apply(&raft.Log{Data: data}).(error)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+	return fsm
+}
+
+func (s *FSMBenchSuite) BenchmarkLeasesLookup(c *gc.C) {
+	fsm := s.newFSM(c)
+	key := lease.Key{benchNamespace, fmt.Sprintf(benchModelUUIDFormat, 42), "app-7"}
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		if _, found := fsm.Leases(zero)[key]; !found {
+			c.Fatalf("lease %v not found", key)
+		}
+	}
+}
+
+func (s *FSMBenchSuite) BenchmarkLease(c *gc.C) {
+	fsm := s.newFSM(c)
+	key := lease.Key{benchNamespace, fmt.Sprintf(benchModelUUIDFormat, 42), "app-7"}
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		if _, found := fsm.Lease(zero, key); !found {
+			c.Fatalf("lease %v not found", key)
+		}
+	}
+}
+
+func (s *FSMBenchSuite) BenchmarkLeases(c *gc.C) {
+	fsm := s.newFSM(c)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		fsm.Leases(zero)
+	}
+}
+
+func (s *FSMBenchSuite) BenchmarkLeaseGroup(c *gc.C) {
+	fsm := s.newFSM(c)
+	modelUUID := fmt.Sprintf(benchModelUUIDFormat, 42)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		if leases := fsm.LeaseGroup(zero, benchNamespace, modelUUID); len(leases) != benchLeasesPerModel {
+			c.Fatalf("expected %d leases, got %d", benchLeasesPerModel, len(leases))
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/raft"
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/core/globalclock"
@@ -20,7 +21,12 @@ const (
 	// CommandVersion is the current version of the command format. If
 	// this changes then we need to be sure that reading and applying
 	// commands for previous versions still works.
-	CommandVersion = 1
+	//
+	// Version 2 added the transfer, pin and unpin operations. Commands
+	// for the older operations are still sent as version 1, so that
+	// controllers that haven't been upgraded yet can apply them; see
+	// OperationVersion.
+	CommandVersion = 2
 
 	// SnapshotVersion is the current version of the snapshot
 	// format. Similarly, changes to the snapshot representation need
@@ -38,12 +44,23 @@ const (
 
 	// OperationSetTime denotes updating stored global time.
 	OperationSetTime = "setTime"
+
+	// OperationTransfer denotes handing a lease to a new holder once
+	// the current holder's time runs out.
+	OperationTransfer = "transfer"
+
+	// OperationPin denotes pinning a lease so that it won't expire.
+	OperationPin = "pin"
+
+	// OperationUnpin denotes removing a pin from a lease.
+	OperationUnpin = "unpin"
 )
 
 // NewFSM returns a new FSM to store lease information.
 func NewFSM() *FSM {
 	return &FSM{
 		entries: make(map[lease.Key]*entry),
+		pinned:  make(map[lease.Key]set.Strings),
 	}
 }
 
@@ -52,6 +69,9 @@ type FSM struct {
 	mu         sync.Mutex
 	globalTime time.Time
 	entries    map[lease.Key]*entry
+
+	// pinned records the entities that have pinned each lease.
+	pinned map[lease.Key]set.Strings
 }
 
 func (f *FSM) claim(key lease.Key, holder string, duration time.Duration) error {
@@ -78,6 +98,11 @@ func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) error
 	if entry.holder != holder {
 		return lease.ErrInvalid
 	}
+	if entry.successor != "" {
+		// The lease is being transferred: the holder keeps it
+		// until it expires, but no longer.
+		return lease.ErrClaimDenied
+	}
 	expiry := f.globalTime.Add(duration)
 	if !expiry.After(entry.start.Add(entry.duration)) {
 		// No extension needed - the lease already expires after the
@@ -102,10 +127,72 @@ func (f *FSM) expire(key lease.Key) error {
 	if !f.globalTime.After(expiry) {
 		return lease.ErrInvalid
 	}
+	if _, pinned := f.pinned[key]; pinned {
+		return lease.ErrInvalid
+	}
+	if entry.successor != "" {
+		// Hand the lease over to the successor.
+		entry.holder = entry.successor
+		entry.start = f.globalTime
+		entry.duration = entry.successorDuration
+		entry.successor = ""
+		entry.successorDuration = 0
+		return nil
+	}
 	delete(f.entries, key)
 	return nil
 }
 
+// transfer records that the lease is to pass from holder to successor
+// when it expires. The holder can't extend the lease in the meantime,
+// and nobody else can claim it.
+func (f *FSM) transfer(key lease.Key, holder, successor string, duration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, found := f.entries[key]
+	if !found || entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	if _, pinned := f.pinned[key]; pinned {
+		return lease.ErrPinned
+	}
+	entry.successor = successor
+	entry.successorDuration = duration
+	return nil
+}
+
+func (f *FSM) pin(key lease.Key, entity string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if entry, found := f.entries[key]; found {
+		// Pinning a lease cancels any pending transfer, so that
+		// the holder can extend it again.
+		entry.successor = ""
+		entry.successorDuration = 0
+	}
+	entities, found := f.pinned[key]
+	if !found {
+		entities = set.NewStrings()
+		f.pinned[key] = entities
+	}
+	entities.Add(entity)
+	return nil
+}
+
+func (f *FSM) unpin(key lease.Key, entity string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entities, found := f.pinned[key]
+	if !found {
+		return nil
+	}
+	entities.Remove(entity)
+	if entities.IsEmpty() {
+		delete(f.pinned, key)
+	}
+	return nil
+}
+
 func (f *FSM) setTime(oldTime, newTime time.Time) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -125,17 +212,59 @@ func (f *FSM) GlobalTime() time.Time {
 
 // Leases gets information about all of the leases in the system.
 func (f *FSM) Leases(localTime time.Time) map[lease.Key]lease.Info {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key]lease.Info, len(f.entries))
+	for key, entry := range f.entries {
+		results[key] = f.info(entry, localTime)
+	}
+	return results
+}
+
+// LeaseGroup gets information about the leases in the specified
+// namespace and model.
+func (f *FSM) LeaseGroup(localTime time.Time, namespace, modelUUID string) map[lease.Key]lease.Info {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	results := make(map[lease.Key]lease.Info)
 	for key, entry := range f.entries {
-		globalExpiry := entry.start.Add(entry.duration)
-		remaining := globalExpiry.Sub(f.globalTime)
-		localExpiry := localTime.Add(remaining)
-		results[key] = lease.Info{
-			Holder: entry.holder,
-			Expiry: localExpiry,
+		if key.Namespace != namespace || key.ModelUUID != modelUUID {
+			continue
 		}
+		results[key] = f.info(entry, localTime)
+	}
+	return results
+}
+
+// Lease gets information about the specified lease, if it's held.
+func (f *FSM) Lease(localTime time.Time, key lease.Key) (lease.Info, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, found := f.entries[key]
+	if !found {
+		return lease.Info{}, false
+	}
+	return f.info(entry, localTime), true
+}
+
+// info converts the entry's expiry time from global time to the
+// supplied local time. The caller must hold f.mu.
+func (f *FSM) info(entry *entry, localTime time.Time) lease.Info {
+	globalExpiry := entry.start.Add(entry.duration)
+	remaining := globalExpiry.Sub(f.globalTime)
+	return lease.Info{
+		Holder: entry.holder,
+		Expiry: localTime.Add(remaining),
+	}
+}
+
+// Pinned returns the pinned leases, and the entities that pinned them.
+func (f *FSM) Pinned() map[lease.Key][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key][]string, len(f.pinned))
+	for key, entities := range f.pinned {
+		results[key] = entities.SortedValues()
 	}
 	return results
 }
@@ -151,6 +280,11 @@ type entry struct {
 	// duration is the duration for which the lease is valid,
 	// from the start time.
 	duration time.Duration
+
+	// successor, if set, is who the lease will be claimed for, for
+	// successorDuration, when it expires.
+	successor         string
+	successorDuration time.Duration
 }
 
 // Apply is part of raft.FSM.
@@ -172,6 +306,12 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		return f.expire(command.LeaseKey())
 	case OperationSetTime:
 		return f.setTime(command.OldTime, command.NewTime)
+	case OperationTransfer:
+		return f.transfer(command.LeaseKey(), command.Holder, command.Successor, command.Duration)
+	case OperationPin:
+		return f.pin(command.LeaseKey(), command.PinEntity)
+	case OperationUnpin:
+		return f.unpin(command.LeaseKey(), command.PinEntity)
 	default:
 		return errors.NotValidf("operation %q", command.Operation)
 	}
@@ -188,14 +328,28 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 			ModelUUID: key.ModelUUID,
 			Lease:     key.Lease,
 		}] = SnapshotEntry{
-			Holder:   entry.holder,
-			Start:    entry.start,
-			Duration: entry.duration,
+			Holder:            entry.holder,
+			Start:             entry.start,
+			Duration:          entry.duration,
+			Successor:         entry.successor,
+			SuccessorDuration: entry.successorDuration,
+		}
+	}
+	var pinned map[SnapshotKey][]string
+	if len(f.pinned) > 0 {
+		pinned = make(map[SnapshotKey][]string, len(f.pinned))
+		for key, entities := range f.pinned {
+			pinned[SnapshotKey{
+				Namespace: key.Namespace,
+				ModelUUID: key.ModelUUID,
+				Lease:     key.Lease,
+			}] = entities.SortedValues()
 		}
 	}
 	return &Snapshot{
 		Version:    SnapshotVersion,
 		Entries:    entries,
+		Pinned:     pinned,
 		GlobalTime: f.globalTime,
 	}, nil
 }
@@ -222,17 +376,31 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 			ModelUUID: key.ModelUUID,
 			Lease:     key.Lease,
 		}] = &entry{
-			holder:   ssEntry.Holder,
-			start:    ssEntry.Start,
-			duration: ssEntry.Duration,
+			holder:            ssEntry.Holder,
+			start:             ssEntry.Start,
+			duration:          ssEntry.Duration,
+			successor:         ssEntry.Successor,
+			successorDuration: ssEntry.SuccessorDuration,
 		}
 	}
 
+	// Snapshots taken before leases could be pinned won't have any
+	// pins recorded.
+	newPinned := make(map[lease.Key]set.Strings, len(snapshot.Pinned))
+	for key, entities := range snapshot.Pinned {
+		newPinned[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = set.NewStrings(entities...)
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	f.globalTime = snapshot.GlobalTime
 	f.entries = newEntries
+	f.pinned = newPinned
 
 	return nil
 }
@@ -241,6 +409,7 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 type Snapshot struct {
 	Version    int                           `yaml:"version"`
 	Entries    map[SnapshotKey]SnapshotEntry `yaml:"entries"`
+	Pinned     map[SnapshotKey][]string      `yaml:"pinned,omitempty"`
 	GlobalTime time.Time                     `yaml:"global-time"`
 }
 
@@ -274,9 +443,11 @@ type SnapshotKey struct {
 
 // SnapshotEntry defines the format of a lease entry in a snapshot.
 type SnapshotEntry struct {
-	Holder   string        `yaml:"holder"`
-	Start    time.Time     `yaml:"start"`
-	Duration time.Duration `yaml:"duration"`
+	Holder            string        `yaml:"holder"`
+	Start             time.Time     `yaml:"start"`
+	Duration          time.Duration `yaml:"duration"`
+	Successor         string        `yaml:"successor,omitempty"`
+	SuccessorDuration time.Duration `yaml:"successor-duration,omitempty"`
 }
 
 // Command captures the details of an operation to be run on the FSM.
@@ -285,7 +456,8 @@ type Command struct {
 	// to handle multiple formats.
 	Version int `yaml:"version"`
 
-	// Operation is one of claim, extend, expire or setTime.
+	// Operation is one of claim, extend, expire, setTime, transfer,
+	// pin or unpin.
 	Operation string `yaml:"operation"`
 
 	// Namespace is the kind of lease.
@@ -297,11 +469,19 @@ type Command struct {
 	// Lease is the name of the lease the command affects.
 	Lease string `yaml:"lease,omitempty"`
 
-	// Holder is the name of the party claiming or extending the
-	// lease.
+	// Holder is the name of the party claiming, extending or
+	// transferring the lease.
 	Holder string `yaml:"holder,omitempty"`
 
-	// Duration is how long the lease should last.
+	// Successor is the name of the party a lease is transferred to.
+	Successor string `yaml:"successor,omitempty"`
+
+	// PinEntity is the name of the party pinning or unpinning the
+	// lease.
+	PinEntity string `yaml:"pin-entity,omitempty"`
+
+	// Duration is how long the lease should last; for a transfer,
+	// how long it should last for the successor.
 	Duration time.Duration `yaml:"duration,omitempty"`
 
 	// OldTime is the previous time for time updates (to avoid
@@ -315,10 +495,18 @@ type Command struct {
 // Validate checks that the command describes a valid state change.
 func (c *Command) Validate() error {
 	var zeroTime time.Time
-	// For now there's only version 1.
-	if c.Version != 1 {
+	if c.Version < 1 || c.Version > CommandVersion {
 		return errors.NotValidf("version %d", c.Version)
 	}
+	if c.Version < OperationVersion(c.Operation) {
+		return errors.NotValidf("%s with version %d", c.Operation, c.Version)
+	}
+	if c.PinEntity != "" && c.Operation != OperationPin && c.Operation != OperationUnpin {
+		return errors.NotValidf("%s with pin entity", c.Operation)
+	}
+	if c.Successor != "" && c.Operation != OperationTransfer {
+		return errors.NotValidf("%s with successor", c.Operation)
+	}
 	switch c.Operation {
 	case OperationClaim, OperationExtend:
 		if c.Holder == "" {
@@ -364,6 +552,59 @@ func (c *Command) Validate() error {
 		if c.NewTime != zeroTime {
 			return errors.NotValidf("expire with new time")
 		}
+	case OperationTransfer:
+		if c.Namespace == "" {
+			return errors.NotValidf("transfer with empty namespace")
+		}
+		if c.ModelUUID == "" {
+			return errors.NotValidf("transfer with empty model UUID")
+		}
+		if c.Lease == "" {
+			return errors.NotValidf("transfer with empty lease")
+		}
+		if c.Holder == "" {
+			return errors.NotValidf("transfer with empty holder")
+		}
+		if c.Successor == "" {
+			return errors.NotValidf("transfer with empty successor")
+		}
+		if c.Successor == c.Holder {
+			return errors.NotValidf("transfer to holder")
+		}
+		if c.Duration == 0 {
+			return errors.NotValidf("transfer with zero duration")
+		}
+		if c.OldTime != zeroTime {
+			return errors.NotValidf("transfer with old time")
+		}
+		if c.NewTime != zeroTime {
+			return errors.NotValidf("transfer with new time")
+		}
+	case OperationPin, OperationUnpin:
+		if c.Namespace == "" {
+			return errors.NotValidf("%s with empty namespace", c.Operation)
+		}
+		if c.ModelUUID == "" {
+			return errors.NotValidf("%s with empty model UUID", c.Operation)
+		}
+		if c.Lease == "" {
+			return errors.NotValidf("%s with empty lease", c.Operation)
+		}
+		if c.PinEntity == "" {
+			return errors.NotValidf("%s with empty pin entity", c.Operation)
+		}
+		if c.Holder != "" {
+			return errors.NotValidf("%s with holder", c.Operation)
+		}
+		if c.Duration != 0 {
+			return errors.NotValidf("%s with duration", c.Operation)
+		}
+		if c.OldTime != zeroTime {
+			return errors.NotValidf("%s with old time", c.Operation)
+		}
+		if c.NewTime != zeroTime {
+			return errors.NotValidf("%s with new time", c.Operation)
+		}
 	case OperationSetTime:
 		// An old time of 0 is valid when starting up.
 		if c.NewTime == zeroTime {
@@ -390,6 +631,18 @@ func (c *Command) Validate() error {
 	return nil
 }
 
+// OperationVersion returns the command version that introduced the
+// operation. Commands for an operation are sent with this version, and
+// rejected with any earlier one.
+func OperationVersion(operation string) int {
+	switch operation {
+	case OperationTransfer, OperationPin, OperationUnpin:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // LeaseKey makes a lease key from the fields in the command.
 func (c *Command) LeaseKey() lease.Key {
 	return lease.Key{
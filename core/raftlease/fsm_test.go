@@ -163,6 +163,211 @@ func (s *fsmSuite) TestExpire(c *gc.C) {
 	c.Assert(s.fsm.Leases(zero), gc.DeepEquals, map[lease.Key]lease.Info{})
 }
 
+func (s *fsmSuite) TestTransfer(c *gc.C) {
+	// Can't transfer a non-existent lease.
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Successor: "you",
+		Duration:  time.Minute,
+	}
+	c.Assert(s.apply(c, command), jc.Satisfies, lease.IsInvalid)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}), jc.ErrorIsNil)
+
+	// Only the holder's lease can be transferred.
+	command.Holder = "them"
+	c.Assert(s.apply(c, command), jc.Satisfies, lease.IsInvalid)
+	command.Holder = "me"
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+
+	// The holder keeps the lease until it expires, but can't extend it.
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals, map[lease.Key]lease.Info{
+		{"ns", "model", "lease"}: {
+			Holder: "me",
+			Expiry: offset(time.Second),
+		},
+	})
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExtend,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Minute,
+	}), gc.Equals, lease.ErrClaimDenied)
+
+	// On expiry the lease passes to the successor.
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   s.fsm.GlobalTime(),
+		NewTime:   s.fsm.GlobalTime().Add(2 * time.Second),
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+	}), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals, map[lease.Key]lease.Info{
+		{"ns", "model", "lease"}: {
+			Holder: "you",
+			Expiry: offset(time.Minute),
+		},
+	})
+}
+
+func (s *fsmSuite) TestTransferPinned(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}), jc.ErrorIsNil)
+	pin := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+
+	// Pinned leases can't be transferred.
+	transfer := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Successor: "you",
+		Duration:  time.Minute,
+	}
+	c.Assert(s.apply(c, transfer), gc.Equals, lease.ErrPinned)
+
+	// Pinning cancels a pending transfer.
+	pin.Operation = raftlease.OperationUnpin
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+	c.Assert(s.apply(c, transfer), jc.ErrorIsNil)
+	pin.Operation = raftlease.OperationPin
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+	pin.Operation = raftlease.OperationUnpin
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   s.fsm.GlobalTime(),
+		NewTime:   s.fsm.GlobalTime().Add(2 * time.Second),
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+	}), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals, map[lease.Key]lease.Info{})
+}
+
+func (s *fsmSuite) TestPinUnpin(c *gc.C) {
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+	// Pinning again has no effect.
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+	command.PinEntity = "machine-0"
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"ns", "model", "lease"}: {"machine-0", "user-admin"},
+	})
+
+	command.Operation = raftlease.OperationUnpin
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"ns", "model", "lease"}: {"user-admin"},
+	})
+
+	command.PinEntity = "user-admin"
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{})
+
+	// Unpinning a lease that isn't pinned has no effect.
+	c.Assert(s.apply(c, command), jc.ErrorIsNil)
+}
+
+func (s *fsmSuite) TestExpirePinned(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   s.fsm.GlobalTime(),
+		NewTime:   s.fsm.GlobalTime().Add(2 * time.Second),
+	}), jc.ErrorIsNil)
+
+	// Pinned leases can't be expired.
+	expire := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+	}
+	c.Assert(s.apply(c, expire), jc.Satisfies, lease.IsInvalid)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationUnpin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, expire), jc.ErrorIsNil)
+}
+
 func (s *fsmSuite) TestSetTime(c *gc.C) {
 	// Time always starts at 0.
 	c.Assert(s.apply(c, raftlease.Command{
@@ -215,6 +420,67 @@ func (s *fsmSuite) TestLeases(c *gc.C) {
 	)
 }
 
+func (s *fsmSuite) TestLeaseGroup(c *gc.C) {
+	for _, key := range []lease.Key{
+		{"ns", "model", "lease"},
+		{"ns", "model", "other"},
+		{"ns", "model2", "lease"},
+		{"ns2", "model", "lease"},
+	} {
+		c.Assert(s.apply(c, raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationClaim,
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			Holder:    "me",
+			Duration:  time.Second,
+		}), jc.ErrorIsNil)
+	}
+
+	c.Assert(s.fsm.LeaseGroup(zero, "ns", "model"), gc.DeepEquals,
+		map[lease.Key]lease.Info{
+			{"ns", "model", "lease"}: {
+				Holder: "me",
+				Expiry: offset(time.Second),
+			},
+			{"ns", "model", "other"}: {
+				Holder: "me",
+				Expiry: offset(time.Second),
+			},
+		},
+	)
+	c.Assert(s.fsm.LeaseGroup(zero, "ns3", "model"), gc.HasLen, 0)
+}
+
+func (s *fsmSuite) TestLease(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   zero.Add(500 * time.Millisecond),
+	}), jc.ErrorIsNil)
+
+	info, found := s.fsm.Lease(zero, lease.Key{"ns", "model", "lease"})
+	c.Assert(found, jc.IsTrue)
+	c.Assert(info, gc.DeepEquals, lease.Info{
+		Holder: "me",
+		Expiry: offset(500 * time.Millisecond),
+	})
+
+	_, found = s.fsm.Lease(zero, lease.Key{"ns", "model", "other"})
+	c.Assert(found, jc.IsFalse)
+}
+
 func (s *fsmSuite) TestApplyInvalidCommand(c *gc.C) {
 	c.Assert(s.apply(c, raftlease.Command{
 		Version:   300,
@@ -254,15 +520,37 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 		Duration:  4 * time.Second,
 	}), jc.ErrorIsNil)
 
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns2",
+		ModelUUID: "model2",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}), jc.ErrorIsNil)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Successor: "them",
+		Duration:  3 * time.Second,
+	}), jc.ErrorIsNil)
+
 	snapshot, err := s.fsm.Snapshot()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(snapshot, gc.DeepEquals, &raftlease.Snapshot{
 		Version: 1,
 		Entries: map[raftlease.SnapshotKey]raftlease.SnapshotEntry{
 			{"ns", "model", "lease"}: {
-				Holder:   "me",
-				Start:    zero,
-				Duration: time.Second,
+				Holder:            "me",
+				Start:             zero,
+				Duration:          time.Second,
+				Successor:         "them",
+				SuccessorDuration: 3 * time.Second,
 			},
 			{"ns2", "model2", "lease"}: {
 				Holder:   "you",
@@ -270,6 +558,9 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 				Duration: 4 * time.Second,
 			},
 		},
+		Pinned: map[raftlease.SnapshotKey][]string{
+			{"ns2", "model2", "lease"}: {"user-admin"},
+		},
 		GlobalTime: zero.Add(2 * time.Second),
 	})
 }
@@ -304,6 +595,9 @@ func (s *fsmSuite) TestRestore(c *gc.C) {
 				Duration: 10 * time.Second,
 			},
 		},
+		Pinned: map[raftlease.SnapshotKey][]string{
+			{"ns2", "model2", "lease"}: {"machine-0", "user-admin"},
+		},
 		GlobalTime: zero.Add(3 * time.Second),
 	}
 
@@ -312,6 +606,25 @@ func (s *fsmSuite) TestRestore(c *gc.C) {
 	c.Assert(actual, gc.DeepEquals, expected)
 }
 
+func (s *fsmSuite) TestRestoreWithoutPins(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}), jc.ErrorIsNil)
+
+	// Snapshots from before leases could be pinned restore with no
+	// pins.
+	reader := closer{Reader: bytes.NewBuffer([]byte(unpinnedSnapshotYaml))}
+	err := s.fsm.Restore(&reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{})
+	c.Assert(s.fsm.Leases(zero), gc.HasLen, 1)
+}
+
 func (s *fsmSuite) TestSnapshotPersist(c *gc.C) {
 	snapshot := &raftlease.Snapshot{
 		Version: 1,
@@ -396,6 +709,69 @@ func (s *fsmSuite) TestCommandValidationExtend(c *gc.C) {
 	c.Assert(command.Validate(), gc.ErrorMatches, "extend with empty namespace not valid")
 }
 
+func (s *fsmSuite) TestCommandValidationTransfer(c *gc.C) {
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Successor: "you",
+		Duration:  time.Minute,
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Duration = 0
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with zero duration not valid")
+	command.Duration = time.Minute
+	command.Successor = "me"
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer to holder not valid")
+	command.Successor = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with empty successor not valid")
+	command.Successor = "you"
+	command.Operation = raftlease.OperationClaim
+	c.Assert(command.Validate(), gc.ErrorMatches, "claim with successor not valid")
+}
+
+func (s *fsmSuite) TestCommandValidationVersion(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}
+	// Controllers that only understand version 1 commands would
+	// reject the newer operations, so they can't be sent as version 1.
+	c.Assert(command.Validate(), gc.ErrorMatches, "pin with version 1 not valid")
+	command.Version = 2
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Version = 3
+	c.Assert(command.Validate(), gc.ErrorMatches, "version 3 not valid")
+}
+
+func (s *fsmSuite) TestCommandValidationPin(c *gc.C) {
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "user-admin",
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Holder = "me"
+	c.Assert(command.Validate(), gc.ErrorMatches, "pin with holder not valid")
+	command.Holder = ""
+	command.Operation = raftlease.OperationUnpin
+	command.PinEntity = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "unpin with empty pin entity not valid")
+	command.Operation = raftlease.OperationExpire
+	command.PinEntity = "user-admin"
+	c.Assert(command.Validate(), gc.ErrorMatches, "expire with pin entity not valid")
+}
+
 func (s *fsmSuite) TestCommandValidationSetTime(c *gc.C) {
 	command := raftlease.Command{
 		Version:   1,
@@ -454,5 +830,23 @@ entries:
   : holder: you
     start: 0001-01-01T00:00:02Z
     duration: 10s
+pinned:
+  ? namespace: ns2
+    model-uuid: model2
+    lease: lease
+  : - machine-0
+    - user-admin
+global-time: 0001-01-01T00:00:03Z
+`[1:]
+
+var unpinnedSnapshotYaml = `
+version: 1
+entries:
+  ? namespace: ns
+    model-uuid: model
+    lease: lease
+  : holder: me
+    start: 0001-01-01T00:00:00Z
+    duration: 5s
 global-time: 0001-01-01T00:00:03Z
 `[1:]
@@ -41,6 +41,9 @@ type NotifyTarget interface {
 // - any writes must go through the hub.
 type ReadonlyFSM interface {
 	Leases(time.Time) map[lease.Key]lease.Info
+	LeaseGroup(time.Time, string, string) map[lease.Key]lease.Info
+	Lease(time.Time, lease.Key) (lease.Info, bool)
+	Pinned() map[lease.Key][]string
 	GlobalTime() time.Time
 }
 
@@ -80,7 +83,7 @@ type Store struct {
 // ClaimLease is part of lease.Store.
 func (s *Store) ClaimLease(key lease.Key, req lease.Request) error {
 	err := s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   OperationVersion(OperationClaim),
 		Operation: OperationClaim,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
@@ -97,7 +100,7 @@ func (s *Store) ClaimLease(key lease.Key, req lease.Request) error {
 // ExtendLease is part of lease.Store.
 func (s *Store) ExtendLease(key lease.Key, req lease.Request) error {
 	return errors.Trace(s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   OperationVersion(OperationExtend),
 		Operation: OperationExtend,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
@@ -110,23 +113,90 @@ func (s *Store) ExtendLease(key lease.Key, req lease.Request) error {
 // ExpireLease is part of lease.Store.
 func (s *Store) ExpireLease(key lease.Key) error {
 	err := s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   OperationVersion(OperationExpire),
 		Operation: OperationExpire,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
 		Lease:     key.Lease,
 	})
 	if err == nil {
-		s.config.Target.Expired(key)
+		// A lease being transferred is claimed for its successor
+		// rather than vacated.
+		if info, found := s.fsm.Lease(s.config.Clock.Now(), key); found {
+			s.config.Target.Claimed(key, info.Holder)
+		} else {
+			s.config.Target.Expired(key)
+		}
 	}
 	return errors.Trace(err)
 }
 
+// TransferLease is part of lease.Store.
+func (s *Store) TransferLease(key lease.Key, holder string, req lease.Request) error {
+	return errors.Trace(s.runOnLeader(&Command{
+		Version:   OperationVersion(OperationTransfer),
+		Operation: OperationTransfer,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    holder,
+		Successor: req.Holder,
+		Duration:  req.Duration,
+	}))
+}
+
+// PinLease is part of lease.Store.
+func (s *Store) PinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.runOnLeader(&Command{
+		Version:   OperationVersion(OperationPin),
+		Operation: OperationPin,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		PinEntity: entity,
+	}))
+}
+
+// UnpinLease is part of lease.Store.
+func (s *Store) UnpinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.runOnLeader(&Command{
+		Version:   OperationVersion(OperationUnpin),
+		Operation: OperationUnpin,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		PinEntity: entity,
+	}))
+}
+
+// Pinned is part of lease.Store.
+func (s *Store) Pinned() map[lease.Key][]string {
+	return s.fsm.Pinned()
+}
+
 // Leases is part of lease.Store.
 func (s *Store) Leases() map[lease.Key]lease.Info {
-	leaseMap := s.fsm.Leases(s.config.Clock.Now())
+	return s.addTrapdoors(s.fsm.Leases(s.config.Clock.Now()))
+}
+
+// LeaseGroup is part of lease.Store.
+func (s *Store) LeaseGroup(namespace, modelUUID string) map[lease.Key]lease.Info {
+	return s.addTrapdoors(s.fsm.LeaseGroup(s.config.Clock.Now(), namespace, modelUUID))
+}
+
+// Lease is part of lease.Store.
+func (s *Store) Lease(key lease.Key) (lease.Info, bool) {
+	info, found := s.fsm.Lease(s.config.Clock.Now(), key)
+	if !found {
+		return lease.Info{}, false
+	}
+	info.Trapdoor = s.config.Target.Trapdoor(key, info.Holder)
+	return info, true
+}
+
+// addTrapdoors adds trapdoors into the information from the FSM.
+func (s *Store) addTrapdoors(leaseMap map[lease.Key]lease.Info) map[lease.Key]lease.Info {
 	result := make(map[lease.Key]lease.Info, len(leaseMap))
-	// Add trapdoors into the information from the FSM.
 	for k, v := range leaseMap {
 		v.Trapdoor = s.config.Target.Trapdoor(k, v.Holder)
 		result[k] = v
@@ -145,7 +215,7 @@ func (s *Store) Advance(duration time.Duration) error {
 	defer s.prevTimeMu.Unlock()
 	newTime := s.prevTime.Add(duration)
 	err := s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   OperationVersion(OperationSetTime),
 		Operation: OperationSetTime,
 		OldTime:   s.prevTime,
 		NewTime:   newTime,
@@ -232,6 +302,10 @@ func AsResponseError(err error) *ResponseError {
 	switch errors.Cause(err) {
 	case lease.ErrInvalid:
 		code = "invalid"
+	case lease.ErrClaimDenied:
+		code = "claim-denied"
+	case lease.ErrPinned:
+		code = "pinned"
 	case globalclock.ErrConcurrentUpdate:
 		code = "concurrent-update"
 	default:
@@ -253,6 +327,10 @@ func RecoverError(resp *ResponseError) error {
 	switch resp.Code {
 	case "invalid":
 		return lease.ErrInvalid
+	case "claim-denied":
+		return lease.ErrClaimDenied
+	case "pinned":
+		return lease.ErrPinned
 	case "concurrent-update":
 		return globalclock.ErrConcurrentUpdate
 	default:
@@ -215,11 +215,178 @@ func (s *storeSuite) TestExpire(c *gc.C) {
 			c.Check(err, jc.ErrorIsNil)
 		},
 	)
+	s.fsm.CheckCallNames(c, "Lease")
 	s.target.CheckCall(c, 0, "Expired",
 		lease.Key{"warframe", "oberon", "prime"},
 	)
 }
 
+func (s *storeSuite) TestExpireTransferred(c *gc.C) {
+	key := lease.Key{"warframe", "oberon", "prime"}
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.ExpireLease(key)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationExpire,
+			Namespace: "warframe",
+			ModelUUID: "oberon",
+			Lease:     "prime",
+		},
+		func(req raftlease.ForwardRequest) {
+			// The lease was handed over to its successor.
+			s.fsm.leases[key] = lease.Info{
+				Holder: "tenno",
+				Expiry: s.clock.Now().Add(time.Minute),
+			}
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	s.target.CheckCalls(c, []testing.StubCall{
+		{"Claimed", []interface{}{key, "tenno"}},
+	})
+}
+
+func (s *storeSuite) TestTransfer(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.TransferLease(
+				lease.Key{"warframe", "ash", "prime"},
+				"tenno",
+				lease.Request{"konzu", time.Minute},
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationTransfer,
+			Namespace: "warframe",
+			ModelUUID: "ash",
+			Lease:     "prime",
+			Holder:    "tenno",
+			Successor: "konzu",
+			Duration:  time.Minute,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	// The holder doesn't change until the lease expires.
+	c.Assert(s.target.Calls(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestTransferPinned(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.TransferLease(
+				lease.Key{"warframe", "ash", "prime"},
+				"tenno",
+				lease.Request{"konzu", time.Minute},
+			)
+			c.Assert(err, gc.Equals, lease.ErrPinned)
+		},
+
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationTransfer,
+			Namespace: "warframe",
+			ModelUUID: "ash",
+			Lease:     "prime",
+			Holder:    "tenno",
+			Successor: "konzu",
+			Duration:  time.Minute,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{
+					Error: &raftlease.ResponseError{Code: "pinned"},
+				},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	c.Assert(s.target.Calls(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestPin(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.PinLease(
+				lease.Key{"warframe", "frost", "prime"},
+				"user-admin",
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationPin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: "user-admin",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestUnpin(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.UnpinLease(
+				lease.Key{"warframe", "frost", "prime"},
+				"user-admin",
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationUnpin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: "user-admin",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestPinned(c *gc.C) {
+	s.fsm.pinned = map[lease.Key][]string{
+		{"warframe", "frost", "prime"}: {"machine-0", "user-admin"},
+	}
+	c.Assert(s.store.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"warframe", "frost", "prime"}: {"machine-0", "user-admin"},
+	})
+	s.fsm.CheckCallNames(c, "Pinned")
+}
+
 func (s *storeSuite) TestLeases(c *gc.C) {
 	in5Seconds := s.clock.Now().Add(5 * time.Second)
 	in10Seconds := s.clock.Now().Add(10 * time.Second)
@@ -255,6 +422,47 @@ func (s *storeSuite) TestLeases(c *gc.C) {
 	c.Assert(out, gc.Equals, "{la cry mosa} held by mozart")
 }
 
+func (s *storeSuite) TestLeaseGroup(c *gc.C) {
+	in10Seconds := s.clock.Now().Add(10 * time.Second)
+	lease1 := lease.Key{"quam", "olim", "abrahe"}
+	lease2 := lease.Key{"la", "cry", "mosa"}
+	lease3 := lease.Key{"quam", "olim", "sarai"}
+	s.fsm.leases[lease1] = lease.Info{Holder: "verdi", Expiry: in10Seconds}
+	s.fsm.leases[lease2] = lease.Info{Holder: "mozart", Expiry: in10Seconds}
+	s.fsm.leases[lease3] = lease.Info{Holder: "handel", Expiry: in10Seconds}
+
+	result := s.store.LeaseGroup("quam", "olim")
+	c.Assert(result, gc.HasLen, 2)
+	c.Assert(result[lease1].Holder, gc.Equals, "verdi")
+	c.Assert(result[lease3].Holder, gc.Equals, "handel")
+
+	var out string
+	err := result[lease3].Trapdoor(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{quam olim sarai} held by handel")
+	s.fsm.CheckCallNames(c, "LeaseGroup")
+}
+
+func (s *storeSuite) TestLease(c *gc.C) {
+	in10Seconds := s.clock.Now().Add(10 * time.Second)
+	lease1 := lease.Key{"quam", "olim", "abrahe"}
+	s.fsm.leases[lease1] = lease.Info{Holder: "verdi", Expiry: in10Seconds}
+
+	info, found := s.store.Lease(lease1)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(info.Holder, gc.Equals, "verdi")
+	c.Assert(info.Expiry, gc.Equals, in10Seconds)
+
+	var out string
+	err := info.Trapdoor(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{quam olim abrahe} held by verdi")
+
+	_, found = s.store.Lease(lease.Key{"la", "cry", "mosa"})
+	c.Assert(found, jc.IsFalse)
+	s.fsm.CheckCallNames(c, "Lease", "Lease")
+}
+
 // handleHubRequest takes the action that triggers the request, the
 // expected command, and a function that will be run to make checks on
 // the request and send the response back.
@@ -403,6 +611,22 @@ func (s *storeSuite) TestAsResponseError(c *gc.C) {
 			"concurrent-update",
 		},
 	)
+	c.Assert(
+		raftlease.AsResponseError(lease.ErrClaimDenied),
+		gc.DeepEquals,
+		&raftlease.ResponseError{
+			"lease claim denied",
+			"claim-denied",
+		},
+	)
+	c.Assert(
+		raftlease.AsResponseError(lease.ErrPinned),
+		gc.DeepEquals,
+		&raftlease.ResponseError{
+			"lease pinned",
+			"pinned",
+		},
+	)
 	c.Assert(
 		raftlease.AsResponseError(errors.Errorf("generic")),
 		gc.DeepEquals,
@@ -423,12 +647,15 @@ func (s *storeSuite) TestRecoverError(c *gc.C) {
 	}
 	c.Assert(re("", "invalid"), jc.Satisfies, lease.IsInvalid)
 	c.Assert(re("", "concurrent-update"), jc.Satisfies, globalclock.IsConcurrentUpdate)
+	c.Assert(re("", "claim-denied"), gc.Equals, lease.ErrClaimDenied)
+	c.Assert(re("", "pinned"), gc.Equals, lease.ErrPinned)
 	c.Assert(re("something", "else"), gc.ErrorMatches, "something")
 }
 
 type fakeFSM struct {
 	testing.Stub
 	leases     map[lease.Key]lease.Info
+	pinned     map[lease.Key][]string
 	globalTime time.Time
 }
 
@@ -437,6 +664,28 @@ func (f *fakeFSM) Leases(t time.Time) map[lease.Key]lease.Info {
 	return f.leases
 }
 
+func (f *fakeFSM) LeaseGroup(t time.Time, namespace, modelUUID string) map[lease.Key]lease.Info {
+	f.AddCall("LeaseGroup", t, namespace, modelUUID)
+	results := make(map[lease.Key]lease.Info)
+	for key, info := range f.leases {
+		if key.Namespace == namespace && key.ModelUUID == modelUUID {
+			results[key] = info
+		}
+	}
+	return results
+}
+
+func (f *fakeFSM) Lease(t time.Time, key lease.Key) (lease.Info, bool) {
+	f.AddCall("Lease", t, key)
+	info, found := f.leases[key]
+	return info, found
+}
+
+func (f *fakeFSM) Pinned() map[lease.Key][]string {
+	f.AddCall("Pinned")
+	return f.pinned
+}
+
 func (f *fakeFSM) GlobalTime() time.Time {
 	return f.globalTime
 }
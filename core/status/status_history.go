@@ -93,6 +93,10 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindApplication represents an entry for an application. It is
+	// only reported in model status timelines; the history of a single
+	// application cannot be requested.
+	KindApplication HistoryKind = "application"
 )
 
 // String returns a string representation of the HistoryKind.
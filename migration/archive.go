@@ -0,0 +1,333 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// A model archive is a gzipped tarball holding a model's description
+// along with the charms, agent binaries and resources it uses:
+//
+//	model.yaml
+//	charms/<escaped charm URL>
+//	tools/<binary version>.tar.gz
+//	resources/<application>/<resource name>
+const (
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// ArchiveConfig holds what WriteArchive needs to write a model
+// archive.
+type ArchiveConfig struct {
+	// Model is the exported model to archive.
+	Model description.Model
+
+	// The downloaders read the model's binaries from the controller
+	// the model is exported from. Agent binaries are opened by their
+	// URI on the controller's API server.
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are non-nil.
+func (c *ArchiveConfig) Validate() error {
+	if c.Model == nil {
+		return errors.NotValidf("missing Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteArchive writes a self-contained archive of the model to w,
+// holding the model's description and every charm, agent binary and
+// application resource the model uses. The archive can be imported
+// into another controller with OpenArchive and RebuildModel, without
+// that controller ever connecting to this one.
+func WriteArchive(w io.Writer, config ArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	bytes, err := description.Serialize(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := UsedResources(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := writeArchiveBytes(tw, archiveModelFile, bytes); err != nil {
+		return errors.Annotate(err, "cannot write model description")
+	}
+	for _, charmURL := range UsedCharms(config.Model) {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", curl)
+		}
+		err = writeArchiveFile(tw, archiveCharmPath(curl), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot write charm %s", curl)
+		}
+	}
+	for v, uri := range UsedTools(config.Model) {
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open agent binaries %s", v)
+		}
+		err = writeArchiveFile(tw, archiveToolsPath(v), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot write agent binaries %s", v)
+		}
+	}
+	for _, res := range resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			// Placeholders are recreated from the description.
+			continue
+		}
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+		err = writeArchiveFile(tw, archiveResourcePath(rev.ApplicationID, rev.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot write resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+func writeArchiveBytes(tw *tar.Writer, name string, bytes []byte) error {
+	if err := tw.WriteHeader(archiveHeader(name, int64(len(bytes)))); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := tw.Write(bytes)
+	return errors.Trace(err)
+}
+
+// writeArchiveFile adds the content read from r to the archive. The
+// content is spooled to a temporary file first, as the size must be
+// known before it is written.
+func writeArchiveFile(tw *tar.Writer, name string, r io.Reader) error {
+	content, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(archiveHeader(name, size)); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, content)
+	return errors.Trace(err)
+}
+
+func archiveHeader(name string, size int64) *tar.Header {
+	return &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}
+}
+
+func archiveCharmPath(curl *charm.URL) string {
+	return path.Join(archiveCharmsDir, url.PathEscape(curl.String()))
+}
+
+func archiveToolsPath(v version.Binary) string {
+	return path.Join(archiveToolsDir, v.String()+".tar.gz")
+}
+
+func archiveResourcePath(application, name string) string {
+	return path.Join(archiveResourcesDir, application, name)
+}
+
+// Archive is a model archive unpacked by OpenArchive. It reads the
+// model's charms, agent binaries and resources from the archive, and
+// so can be used as the downloaders in a RebuildConfig.
+type Archive struct {
+	// Model is the description of the archived model.
+	Model description.Model
+
+	dir string
+}
+
+// OpenArchive unpacks the model archive read from r, as written by
+// WriteArchive, into a temporary directory. The caller must close the
+// returned Archive to remove the directory again.
+func OpenArchive(r io.Reader) (_ *Archive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive := &Archive{dir: dir}
+	defer func() {
+		if err != nil {
+			archive.Close()
+		}
+	}()
+	if err := unpackArchive(r, dir); err != nil {
+		return nil, errors.Annotate(err, "cannot unpack model archive")
+	}
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveModelFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive.Model, err = description.Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model description")
+	}
+	return archive, nil
+}
+
+func unpackArchive(r io.Reader, dir string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.NotValidf("archive entry %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := writeUnpackedFile(target, tr); err != nil {
+			return errors.Annotatef(err, "cannot unpack %q", hdr.Name)
+		}
+	}
+}
+
+func writeUnpackedFile(target string, r io.Reader) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// ModelInfo returns the details of the archived model needed for the
+// target controller's prechecks. The controller the model was
+// exported from is no longer known, so the model's agent version
+// stands in for the controller's.
+func (a *Archive) ModelInfo() (coremigration.ModelInfo, error) {
+	config := a.Model.Config()
+	name, _ := config["name"].(string)
+	agentVersion, _ := config["agent-version"].(string)
+	vers, err := version.Parse(agentVersion)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "model agent version")
+	}
+	return coremigration.ModelInfo{
+		UUID:                   a.Model.Tag().Id(),
+		Owner:                  a.Model.Owner(),
+		Name:                   name,
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, nil
+}
+
+// OpenCharm is part of CharmDownloader.
+func (a *Archive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(archiveCharmPath(curl), "charm %s", curl)
+}
+
+// OpenURI is part of ToolsDownloader. The URI is that of the agent
+// binaries on the exporting controller's API server, ending in their
+// version.
+func (a *Archive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	v, err := version.ParseBinary(path.Base(uri))
+	if err != nil {
+		return nil, errors.Annotate(err, "error parsing version")
+	}
+	return a.open(archiveToolsPath(v), "agent binaries %s", v)
+}
+
+// OpenResource is part of ResourceDownloader.
+func (a *Archive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(archiveResourcePath(application, name), "resource %s/%s", application, name)
+}
+
+func (a *Archive) open(name string, what string, args ...interface{}) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf(what+" in model archive", args...)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Close removes the unpacked archive.
+func (a *Archive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/migration"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ArchiveSuite struct {
+	statetesting.StateSuite
+	model description.Model
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	s.model = model
+}
+
+func (s *ArchiveSuite) writeArchive(c *gc.C, downloader *fakeDownloader) *bytes.Buffer {
+	var buf bytes.Buffer
+	err := migration.WriteArchive(&buf, migration.ArchiveConfig{
+		Model:              s.model,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return &buf
+}
+
+func (s *ArchiveSuite) TestValidate(c *gc.C) {
+	check := func(modify func(*migration.ArchiveConfig), missing string) {
+		downloader := &fakeDownloader{}
+		config := migration.ArchiveConfig{
+			Model:              s.model,
+			CharmDownloader:    downloader,
+			ToolsDownloader:    downloader,
+			ResourceDownloader: downloader,
+		}
+		modify(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, "missing "+missing+" not valid")
+	}
+	check(func(c *migration.ArchiveConfig) { c.Model = nil }, "Model")
+	check(func(c *migration.ArchiveConfig) { c.CharmDownloader = nil }, "CharmDownloader")
+	check(func(c *migration.ArchiveConfig) { c.ToolsDownloader = nil }, "ToolsDownloader")
+	check(func(c *migration.ArchiveConfig) { c.ResourceDownloader = nil }, "ResourceDownloader")
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	downloader := &fakeDownloader{}
+	buf := s.writeArchive(c, downloader)
+
+	archive, err := migration.OpenArchive(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	c.Check(archive.Model.Tag(), gc.Equals, s.model.Tag())
+	c.Check(archive.Model.Applications(), gc.HasLen, 1)
+
+	charmURL := s.model.Applications()[0].CharmURL()
+	c.Check(downloader.charms, jc.DeepEquals, []string{charmURL})
+	reader, err := archive.OpenCharm(charm.MustParseURL(charmURL))
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := ioutil.ReadAll(reader)
+	reader.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, charmURL+" content")
+
+	// Each version of the agent binaries in use is archived.
+	c.Assert(downloader.uris, gc.Not(gc.HasLen), 0)
+	for _, uri := range downloader.uris {
+		reader, err := archive.OpenURI(uri, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(reader)
+		reader.Close()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(content), gc.Equals, uri)
+	}
+}
+
+func (s *ArchiveSuite) TestOpenMissingBinaries(c *gc.C) {
+	archive, err := migration.OpenArchive(s.writeArchive(c, &fakeDownloader{}))
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	_, err = archive.OpenCharm(charm.MustParseURL("cs:quantal/missing-1"))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = archive.OpenResource("app", "missing")
+	c.Check(err, gc.ErrorMatches, "resource app/missing in model archive not found")
+	_, err = archive.OpenURI(common.ToolsURL("", s.model.Machines()[0].Tools().Version())+"x", nil)
+	c.Check(err, gc.ErrorMatches, "error parsing version: .*")
+}
+
+func (s *ArchiveSuite) TestModelInfo(c *gc.C) {
+	archive, err := migration.OpenArchive(s.writeArchive(c, &fakeDownloader{}))
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	info, err := archive.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.UUID, gc.Equals, s.State.ModelUUID())
+	c.Check(info.Owner, gc.Equals, s.Model.Owner())
+	c.Check(info.Name, gc.Equals, s.Model.Name())
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, _ := cfg.AgentVersion()
+	c.Check(info.AgentVersion, gc.Equals, agentVersion)
+	c.Check(info.ControllerAgentVersion, gc.Equals, agentVersion)
+}
+
+func (s *ArchiveSuite) TestOpenArchiveMissingModel(c *gc.C) {
+	_, err := migration.OpenArchive(makeTarball(c, "charms/foo", "content"))
+	c.Assert(err, gc.ErrorMatches, "model archive without model.yaml not valid")
+}
+
+func (s *ArchiveSuite) TestOpenArchiveBadPath(c *gc.C) {
+	_, err := migration.OpenArchive(makeTarball(c, "../model.yaml", "content"))
+	c.Assert(err, gc.ErrorMatches, `cannot unpack model archive: archive entry "../model.yaml" not valid`)
+}
+
+func makeTarball(c *gc.C, name, content string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte(content))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	return &buf
+}
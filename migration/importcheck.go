@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// CheckImport reports every reason the controller would refuse to
+// import the serialized model, without importing anything. As well
+// as validating the description, it makes the checks state.Import
+// makes before it writes the model, using state.CheckImport; a nil
+// slice means the model is expected to import cleanly. An error is
+// returned only if the checks could not be made.
+func CheckImport(backend state.ImportCheckBackend, bytes []byte) ([]error, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return []error{errors.Annotate(err, "model description not valid")}, nil
+	}
+
+	var problems []error
+	if err := model.Validate(); err != nil {
+		problems = append(problems, errors.Annotate(err, "model description not valid"))
+	}
+	importProblems, err := state.CheckImport(backend, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(problems, importProblems...), nil
+}
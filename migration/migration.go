@@ -4,18 +4,23 @@
 package migration
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/naturalsort"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -63,6 +68,54 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	return dbModel, dbState, nil
 }
 
+// RebuildArgs describes where ImportRebuiltModel rebuilds a model.
+type RebuildArgs struct {
+	// CloudRegion is the region of the controller's cloud to rebuild
+	// the model in. If it is empty, the controller model's region is
+	// used.
+	CloudRegion string
+
+	// CloudCredential is the name of the model owner's credential
+	// for the controller's cloud, used to provision the model's
+	// machines. It may be empty if the cloud needs no credential.
+	CloudCredential string
+}
+
+// ImportRebuiltModel deserializes a model description from the bytes
+// and imports it so that it is rebuilt on the controller's cloud, with
+// new machines in place of those on the model's original cloud. See
+// state.ImportRebuild.
+func ImportRebuiltModel(st *state.State, bytes []byte, args RebuildArgs) (*state.Model, *state.State, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	controllerModel, err := st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	rebuild := state.RebuildArgs{
+		CloudRegion: args.CloudRegion,
+	}
+	if rebuild.CloudRegion == "" {
+		rebuild.CloudRegion = controllerModel.CloudRegion()
+	}
+	if args.CloudCredential != "" {
+		credID := fmt.Sprintf("%s/%s/%s", controllerModel.Cloud(), model.Owner().Id(), args.CloudCredential)
+		if !names.IsValidCloudCredential(credID) {
+			return nil, nil, errors.NotValidf("credential %q", args.CloudCredential)
+		}
+		rebuild.CloudCredential = names.NewCloudCredentialTag(credID)
+	}
+
+	dbModel, dbState, err := st.ImportRebuild(model, rebuild)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return dbModel, dbState, nil
+}
+
 // CharmDownlaoder defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
@@ -287,3 +340,117 @@ func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error
 	}
 	return nil
 }
+
+// UsedCharms returns the URLs of the charms used by the model's
+// applications.
+func UsedCharms(model description.Model) []string {
+	result := set.NewStrings()
+	for _, application := range model.Applications() {
+		result.Add(application.CharmURL())
+	}
+	return result.Values()
+}
+
+// UsedTools returns the agent binary versions used by the model's
+// machines and units, each with the URI they are downloaded from.
+func UsedTools(model description.Model) map[version.Binary]string {
+	used := make(map[version.Binary]string)
+	var addMachine func(description.Machine)
+	addMachine = func(machine description.Machine) {
+		v := machine.Tools().Version()
+		used[v] = common.ToolsURL("", v)
+		for _, container := range machine.Containers() {
+			addMachine(container)
+		}
+	}
+	for _, machine := range model.Machines() {
+		addMachine(machine)
+	}
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			v := unit.Tools().Version()
+			used[v] = common.ToolsURL("", v)
+		}
+	}
+	return used
+}
+
+// UsedResources returns the revisions of the resources used by the
+// model's applications and their units. The name and application of
+// a resource are kept even where the model has no revision of it.
+func UsedResources(model description.Model) ([]migration.SerializedModelResource, error) {
+	var out []migration.SerializedModelResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			csRev, err := resourceRevision(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceRevision(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return nil, errors.Annotatef(err, "resource %s/%s for unit %s", app.Name(), res.Name(), unit.Name())
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out = append(out, migration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  csRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{Name: name},
+			},
+			ApplicationID: app,
+		}, nil
+	}
+	resType, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        resType,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
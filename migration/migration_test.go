@@ -17,7 +17,9 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/component/all"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
@@ -25,6 +27,7 @@ import (
 	_ "github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
@@ -88,6 +91,104 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
+func (s *ImportSuite) exportForRebuild(c *gc.C) []byte {
+	s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "rebuilt-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ImportSuite) TestImportRebuiltModel(c *gc.C) {
+	bytes := s.exportForRebuild(c)
+
+	dbModel, dbState, err := migration.ImportRebuiltModel(s.State, bytes, migration.RebuildArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer dbState.Close()
+
+	c.Check(dbModel.Name(), gc.Equals, "rebuilt-model")
+	c.Check(dbModel.CloudRegion(), gc.Equals, s.Model.CloudRegion())
+	machines, err := dbState.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	_, err = machines[0].InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *ImportSuite) TestImportRebuiltModelMissingCredential(c *gc.C) {
+	bytes := s.exportForRebuild(c)
+
+	_, _, err := migration.ImportRebuiltModel(s.State, bytes, migration.RebuildArgs{
+		CloudCredential: "nope",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ImportSuite) TestCheckImport(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems, err := migration.CheckImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, gc.HasLen, 0)
+}
+
+func (s *ImportSuite) TestCheckImportBadBytes(c *gc.C) {
+	problems, err := migration.CheckImport(s.State, []byte("not a model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Check(problems[0], gc.ErrorMatches, "model description not valid: yaml: unmarshal errors:\n.*")
+}
+
+func (s *ImportSuite) TestCheckImportReportsAllProblems(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "Not Valid",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		SourceModel: testing.ModelTag,
+		OfferUUID:   utils.MustNewUUID().String(),
+		Tag:         names.NewApplicationTag("remote"),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems, err := migration.CheckImport(noCloudsBackend{s.State}, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	c.Check(messages, jc.DeepEquals, []string{
+		"can't import models with remote applications",
+		`model config not valid: "Not Valid" is not a valid name: model names may only contain lowercase letters, digits and hyphens`,
+		`cloud "dummy" not found on this controller`,
+	})
+}
+
+// noCloudsBackend is a state.ImportCheckBackend for a controller
+// that knows no clouds.
+type noCloudsBackend struct {
+	*state.State
+}
+
+func (noCloudsBackend) Cloud(name string) (cloud.Cloud, error) {
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
 func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
 	type T migration.UploadBinariesConfig // alias for brevity
 
@@ -401,6 +401,42 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestAllProblems(c *gc.C) {
+	backend := newHappyBackend()
+	backend.model.life = state.Dying
+	backend.cleanupNeeded = true
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", life: state.Dying},
+		&fakeMachine{id: "1", instanceStatus: status.Provisioning},
+	}
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+
+	problems, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 5)
+	c.Check(problems[0], gc.ErrorMatches, "model is dying")
+	c.Check(problems[1], gc.ErrorMatches, "machine 0 is dying")
+	c.Check(problems[2], gc.ErrorMatches, `machine 1 not running \(provisioning\)`)
+	c.Check(problems[3], gc.ErrorMatches, "cleanup needed")
+	c.Check(problems[4], gc.ErrorMatches, "controller: upgrade in progress")
+}
+
+func (*SourcePrecheckSuite) TestAllProblemsNone(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	problems, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestAllProblemsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.life = state.Dying
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -628,6 +664,37 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestAllProblems(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID},
+			&fakeModel{uuid: "uuid", name: modelName, owner: modelOwner},
+		},
+	}
+	backend := newFakeBackend()
+	backend.models = pool.uuids()
+	backend.migrationActive = true
+	sourceVersion := backendVersion
+	sourceVersion.Minor++
+	s.modelInfo.ControllerAgentVersion = sourceVersion
+
+	problems, err := migration.TargetPrecheckAll(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 4)
+	c.Check(problems[0], gc.ErrorMatches, "model is being migrated out of target controller")
+	c.Check(problems[1], gc.ErrorMatches, `source controller has higher version than target controller \(1.3.3 > 1.2.3\)`)
+	c.Check(problems[2], gc.ErrorMatches, `model with same UUID already exists \(model-uuid\)`)
+	c.Check(problems[3], gc.ErrorMatches, `model named "model-name" already exists`)
+}
+
+func (s *TargetPrecheckSuite) TestAllProblemsInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	problems, err := migration.TargetPrecheckAll(newFakeBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Check(problems[0], gc.ErrorMatches, "empty UUID not valid")
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
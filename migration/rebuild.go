@@ -0,0 +1,266 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// RebuildTarget defines the target controller API used to rebuild a
+// model on the target controller's cloud. *migrationtarget.Client
+// implements it.
+type RebuildTarget interface {
+	ImportRebuild(bytes []byte, region, credential string) error
+	Activate(modelUUID string) error
+	Abort(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, content io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unitName string, res resource.Resource) error
+}
+
+// DefaultRebuildTimeout is how long a model rebuild is given to send
+// the model's binaries to the target controller and activate it.
+const DefaultRebuildTimeout = time.Hour
+
+// RebuildConfig holds what RebuildModel needs to rebuild a model on
+// another controller's cloud.
+type RebuildConfig struct {
+	// Model is the exported model to rebuild.
+	Model description.Model
+
+	// ModelUUID is the UUID the rebuilt model is given on the target
+	// controller. Providers tag instances with the model UUID, so it
+	// must differ from the source model's UUID: otherwise the two
+	// models would claim the same instances, and destroying the
+	// source model could destroy the rebuilt model's machines.
+	ModelUUID string
+
+	// CloudRegion and CloudCredential are passed to the target
+	// controller; see RebuildArgs.
+	CloudRegion     string
+	CloudCredential string
+
+	Target RebuildTarget
+
+	// The downloaders read the model's binaries from the source
+	// controller. Agent binaries are opened by their URI on the
+	// source controller's API server.
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+
+	// Clock and Timeout limit how long the binaries may take to
+	// send, and the model to activate, once it has been imported.
+	Clock   clock.Clock
+	Timeout time.Duration
+
+	// Abort, if not nil, stops the rebuild when it is closed, in the
+	// same way as the timeout passing.
+	Abort <-chan struct{}
+
+	// SetStatus, if not nil, is called with a message describing
+	// each step of the rebuild as it starts.
+	SetStatus func(message string)
+}
+
+// Validate makes sure that all the config values are valid.
+func (c *RebuildConfig) Validate() error {
+	if c.Model == nil {
+		return errors.NotValidf("missing Model")
+	}
+	if !utils.IsValidUUIDString(c.ModelUUID) {
+		return errors.NotValidf("ModelUUID %q", c.ModelUUID)
+	}
+	if c.ModelUUID == c.Model.Tag().Id() {
+		return errors.NotValidf("ModelUUID same as source model")
+	}
+	if c.Target == nil {
+		return errors.NotValidf("missing Target")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
+	if c.Timeout <= 0 {
+		return errors.NotValidf("non-positive Timeout")
+	}
+	return nil
+}
+
+// RebuildModel rebuilds the model on the target controller's cloud.
+// The model is imported under config.ModelUUID with new machines in
+// place of its existing ones, its charms, agent binaries and resources
+// are sent to the target controller, and it is then activated so that
+// the target controller provisions its machines and deploys its units.
+// If any step after the import fails, or does not finish within
+// config.Timeout, the imported model is removed from the target
+// controller again. The source model is not changed, but config.Model
+// is updated with the new UUID.
+func RebuildModel(config RebuildConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID := config.ModelUUID
+	config.Model.UpdateConfig(map[string]interface{}{"uuid": modelUUID})
+	bytes, err := description.Serialize(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config.setStatus("importing model into target controller")
+	if err := config.Target.ImportRebuild(bytes, config.CloudRegion, config.CloudCredential); err != nil {
+		return errors.Annotate(err, "importing model into target controller")
+	}
+	if err := sendAndActivate(config, modelUUID); err != nil {
+		if abortErr := config.Target.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("cannot remove rebuilt model %s from target controller: %v", modelUUID, abortErr)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (c RebuildConfig) setStatus(message string) {
+	if c.SetStatus != nil {
+		c.SetStatus(message)
+	}
+}
+
+func sendAndActivate(config RebuildConfig, modelUUID string) error {
+	resources, err := UsedResources(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uploader := &rebuildUploader{
+		target:    config.Target,
+		modelUUID: modelUUID,
+		clock:     config.Clock,
+		deadline:  config.Clock.Now().Add(config.Timeout),
+		abort:     config.Abort,
+	}
+	config.setStatus("sending charms, agent binaries and resources to target controller")
+	err = UploadBinaries(UploadBinariesConfig{
+		Charms:          UsedCharms(config.Model),
+		CharmDownloader: config.CharmDownloader,
+		CharmUploader:   uploader,
+
+		Tools:           UsedTools(config.Model),
+		ToolsDownloader: config.ToolsDownloader,
+		ToolsUploader:   uploader,
+
+		Resources:          resources,
+		ResourceDownloader: config.ResourceDownloader,
+		ResourceUploader:   uploader,
+	})
+	if err != nil {
+		return errors.Annotate(err, "sending binaries to target controller")
+	}
+	if err := uploader.checkDeadline(); err != nil {
+		return errors.Trace(err)
+	}
+	config.setStatus("activating model on target controller")
+	return errors.Annotate(config.Target.Activate(modelUUID), "activating model on target controller")
+}
+
+// rebuildUploader prepends the model UUID to the uploads made to the
+// target controller, and fails them once the deadline has passed or
+// the rebuild is aborted. Each upload is streamed from its reader, so
+// an upload in progress fails at its next read after that.
+type rebuildUploader struct {
+	target    RebuildTarget
+	modelUUID string
+	clock     clock.Clock
+	deadline  time.Time
+	abort     <-chan struct{}
+}
+
+func (u *rebuildUploader) checkDeadline() error {
+	select {
+	case <-u.abort:
+		return errors.Errorf("rebuilding model %s aborted", u.modelUUID)
+	default:
+	}
+	if u.clock.Now().After(u.deadline) {
+		return errors.Timeoutf("rebuilding model %s", u.modelUUID)
+	}
+	return nil
+}
+
+func (u *rebuildUploader) reader(r io.ReadSeeker) io.ReadSeeker {
+	return &deadlineReader{ReadSeeker: r, uploader: u}
+}
+
+// UploadCharm is part of CharmUploader.
+func (u *rebuildUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	if err := u.checkDeadline(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.target.UploadCharm(u.modelUUID, curl, u.reader(content))
+}
+
+// UploadTools is part of ToolsUploader.
+func (u *rebuildUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	if err := u.checkDeadline(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.target.UploadTools(u.modelUUID, u.reader(r), vers, additionalSeries...)
+}
+
+// UploadResource is part of ResourceUploader.
+func (u *rebuildUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	if err := u.checkDeadline(); err != nil {
+		return errors.Trace(err)
+	}
+	return u.target.UploadResource(u.modelUUID, res, u.reader(content))
+}
+
+// SetPlaceholderResource is part of ResourceUploader.
+func (u *rebuildUploader) SetPlaceholderResource(res resource.Resource) error {
+	if err := u.checkDeadline(); err != nil {
+		return errors.Trace(err)
+	}
+	return u.target.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of ResourceUploader.
+func (u *rebuildUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	if err := u.checkDeadline(); err != nil {
+		return errors.Trace(err)
+	}
+	return u.target.SetUnitResource(u.modelUUID, unitName, res)
+}
+
+// deadlineReader fails reads once the uploader's deadline has passed.
+type deadlineReader struct {
+	io.ReadSeeker
+	uploader *rebuildUploader
+}
+
+// Read is part of io.Reader.
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if err := r.uploader.checkDeadline(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return r.ReadSeeker.Read(p)
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/tools"
+)
+
+type RebuildSuite struct {
+	statetesting.StateSuite
+	target    *fakeRebuildTarget
+	model     description.Model
+	modelUUID string
+	clock     *testclock.Clock
+}
+
+var _ = gc.Suite(&RebuildSuite{})
+
+func (s *RebuildSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	s.model = model
+	s.modelUUID = utils.MustNewUUID().String()
+	s.clock = testclock.NewClock(time.Now())
+	s.target = &fakeRebuildTarget{}
+}
+
+func (s *RebuildSuite) config() migration.RebuildConfig {
+	downloader := &fakeDownloader{}
+	return migration.RebuildConfig{
+		Model:              s.model,
+		ModelUUID:          s.modelUUID,
+		CloudRegion:        "region",
+		CloudCredential:    "cred",
+		Target:             s.target,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+		Clock:              s.clock,
+		Timeout:            time.Minute,
+	}
+}
+
+func (s *RebuildSuite) TestValidate(c *gc.C) {
+	check := func(modify func(*migration.RebuildConfig), missing string) {
+		config := s.config()
+		modify(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, missing+" not valid")
+	}
+	check(func(c *migration.RebuildConfig) { c.Model = nil }, "missing Model")
+	check(func(c *migration.RebuildConfig) { c.ModelUUID = "" }, `ModelUUID ""`)
+	check(func(c *migration.RebuildConfig) { c.ModelUUID = s.State.ModelUUID() }, "ModelUUID same as source model")
+	check(func(c *migration.RebuildConfig) { c.Target = nil }, "missing Target")
+	check(func(c *migration.RebuildConfig) { c.CharmDownloader = nil }, "missing CharmDownloader")
+	check(func(c *migration.RebuildConfig) { c.ToolsDownloader = nil }, "missing ToolsDownloader")
+	check(func(c *migration.RebuildConfig) { c.ResourceDownloader = nil }, "missing ResourceDownloader")
+	check(func(c *migration.RebuildConfig) { c.Clock = nil }, "missing Clock")
+	check(func(c *migration.RebuildConfig) { c.Timeout = 0 }, "non-positive Timeout")
+}
+
+// usedTools returns the agent versions in use in the model, which
+// depend on the host series.
+func (s *RebuildSuite) usedTools() []interface{} {
+	used := make(map[version.Binary]bool)
+	for _, machine := range s.model.Machines() {
+		used[machine.Tools().Version()] = true
+	}
+	for _, unit := range s.model.Applications()[0].Units() {
+		used[unit.Tools().Version()] = true
+	}
+	var out []interface{}
+	for v := range used {
+		out = append(out, v)
+	}
+	return out
+}
+
+func (s *RebuildSuite) TestRebuildModel(c *gc.C) {
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	bytes, err := description.Serialize(s.model)
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := s.modelUUID
+	charmURL := s.model.Applications()[0].CharmURL()
+	used := s.usedTools()
+
+	calls := s.target.Calls()
+	c.Assert(calls, gc.HasLen, 3+len(used))
+	s.target.CheckCall(c, 0, "ImportRebuild", bytes, "region", "cred")
+	s.target.CheckCall(c, 1, "UploadCharm", uuid, charmURL)
+	var uploaded []interface{}
+	for _, call := range calls[2 : 2+len(used)] {
+		c.Check(call.FuncName, gc.Equals, "UploadTools")
+		c.Check(call.Args[0], gc.Equals, uuid)
+		uploaded = append(uploaded, call.Args[1])
+	}
+	c.Check(uploaded, jc.SameContents, used)
+	s.target.CheckCall(c, 2+len(used), "Activate", uuid)
+}
+
+func (s *RebuildSuite) TestRebuildModelNewUUID(c *gc.C) {
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.target.Calls()[0].FuncName, gc.Equals, "ImportRebuild")
+	imported, err := description.Deserialize(s.target.Calls()[0].Args[0].([]byte))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Tag().Id(), gc.Equals, s.modelUUID)
+	c.Check(imported.Tag().Id(), gc.Not(gc.Equals), s.State.ModelUUID())
+	c.Check(imported.Config()["uuid"], gc.Equals, s.modelUUID)
+	c.Check(imported.Config()["name"], gc.Equals, s.model.Config()["name"])
+}
+
+func (s *RebuildSuite) TestTimeoutAborts(c *gc.C) {
+	s.target.charmUploaded = func() {
+		s.clock.Advance(time.Minute + time.Second)
+	}
+
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, gc.ErrorMatches, "sending binaries to target controller: cannot upload agent binaries: rebuilding model .* timeout")
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsTimeout)
+	s.target.CheckCallNames(c, "ImportRebuild", "UploadCharm", "Abort")
+	s.target.CheckCall(c, 2, "Abort", s.modelUUID)
+}
+
+func (s *RebuildSuite) TestTimeoutFailsUploadInProgress(c *gc.C) {
+	s.target.charmUploading = func() {
+		s.clock.Advance(time.Minute + time.Second)
+	}
+
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, gc.ErrorMatches, "sending binaries to target controller: cannot upload charm: rebuilding model .* timeout")
+	s.target.CheckCallNames(c, "ImportRebuild", "UploadCharm", "Abort")
+}
+
+func (s *RebuildSuite) TestAbort(c *gc.C) {
+	abort := make(chan struct{})
+	s.target.charmUploaded = func() {
+		close(abort)
+	}
+	config := s.config()
+	config.Abort = abort
+
+	err := migration.RebuildModel(config)
+	c.Assert(err, gc.ErrorMatches, "sending binaries to target controller: cannot upload agent binaries: rebuilding model .* aborted")
+	s.target.CheckCallNames(c, "ImportRebuild", "UploadCharm", "Abort")
+}
+
+func (s *RebuildSuite) TestSetStatus(c *gc.C) {
+	var messages []string
+	config := s.config()
+	config.SetStatus = func(message string) {
+		messages = append(messages, message)
+	}
+
+	err := migration.RebuildModel(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(messages, jc.DeepEquals, []string{
+		"importing model into target controller",
+		"sending charms, agent binaries and resources to target controller",
+		"activating model on target controller",
+	})
+}
+
+func (s *RebuildSuite) TestImportFails(c *gc.C) {
+	s.target.SetErrors(errors.New("boom"))
+
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, gc.ErrorMatches, "importing model into target controller: boom")
+	s.target.CheckCallNames(c, "ImportRebuild")
+}
+
+func (s *RebuildSuite) TestUploadFailsAborts(c *gc.C) {
+	s.target.SetErrors(nil, errors.New("boom"))
+
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, gc.ErrorMatches, "sending binaries to target controller: cannot upload charm: boom")
+	s.target.CheckCallNames(c, "ImportRebuild", "UploadCharm", "Abort")
+	s.target.CheckCall(c, 2, "Abort", s.modelUUID)
+}
+
+func (s *RebuildSuite) TestActivateFailsAborts(c *gc.C) {
+	errs := make([]error, 2+len(s.usedTools()))
+	s.target.SetErrors(append(errs, errors.New("boom"))...)
+
+	err := migration.RebuildModel(s.config())
+	c.Assert(err, gc.ErrorMatches, "activating model on target controller: boom")
+	calls := s.target.Calls()
+	c.Assert(calls, gc.HasLen, len(errs)+2)
+	c.Check(calls[len(calls)-2].FuncName, gc.Equals, "Activate")
+	c.Check(calls[len(calls)-1].FuncName, gc.Equals, "Abort")
+}
+
+type fakeRebuildTarget struct {
+	jujutesting.Stub
+
+	// charmUploading and charmUploaded, if set, are called before
+	// and after UploadCharm reads the charm.
+	charmUploading func()
+	charmUploaded  func()
+}
+
+func (t *fakeRebuildTarget) ImportRebuild(bytes []byte, region, credential string) error {
+	t.AddCall("ImportRebuild", bytes, region, credential)
+	return t.NextErr()
+}
+
+func (t *fakeRebuildTarget) Activate(modelUUID string) error {
+	t.AddCall("Activate", modelUUID)
+	return t.NextErr()
+}
+
+func (t *fakeRebuildTarget) Abort(modelUUID string) error {
+	t.AddCall("Abort", modelUUID)
+	return t.NextErr()
+}
+
+func (t *fakeRebuildTarget) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	t.AddCall("UploadCharm", modelUUID, curl.String())
+	if t.charmUploading != nil {
+		t.charmUploading()
+	}
+	if _, err := ioutil.ReadAll(content); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if t.charmUploaded != nil {
+		t.charmUploaded()
+	}
+	return curl, t.NextErr()
+}
+
+func (t *fakeRebuildTarget) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	t.AddCall("UploadTools", modelUUID, vers)
+	return tools.List{&tools.Tools{Version: vers}}, t.NextErr()
+}
+
+func (t *fakeRebuildTarget) UploadResource(modelUUID string, res resource.Resource, content io.ReadSeeker) error {
+	t.AddCall("UploadResource", modelUUID, res.Name)
+	return t.NextErr()
+}
+
+func (t *fakeRebuildTarget) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	t.AddCall("SetPlaceholderResource", modelUUID, res.Name)
+	return t.NextErr()
+}
+
+func (t *fakeRebuildTarget) SetUnitResource(modelUUID, unitName string, res resource.Resource) error {
+	t.AddCall("SetUnitResource", modelUUID, unitName, res.Name)
+	return t.NextErr()
+}
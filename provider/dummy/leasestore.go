@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/raftlease"
@@ -19,6 +20,7 @@ type leaseStore struct {
 	mu      sync.Mutex
 	clock   clock.Clock
 	entries map[lease.Key]*entry
+	pinned  map[lease.Key]set.Strings
 	target  raftlease.NotifyTarget
 }
 
@@ -33,12 +35,18 @@ type entry struct {
 	// duration is the duration for which the lease is valid,
 	// from the start time.
 	duration time.Duration
+
+	// successor, if set, is who the lease will be claimed for, for
+	// successorDuration, when it expires.
+	successor         string
+	successorDuration time.Duration
 }
 
 func newLeaseStore(clock clock.Clock, target raftlease.NotifyTarget) *leaseStore {
 	return &leaseStore{
 		clock:   clock,
 		entries: make(map[lease.Key]*entry),
+		pinned:  make(map[lease.Key]set.Strings),
 		target:  target,
 	}
 }
@@ -70,6 +78,9 @@ func (s *leaseStore) ExtendLease(key lease.Key, req lease.Request) error {
 	if entry.holder != req.Holder {
 		return lease.ErrInvalid
 	}
+	if entry.successor != "" {
+		return lease.ErrClaimDenied
+	}
 	now := s.clock.Now()
 	expiry := now.Add(req.Duration)
 	if !expiry.After(entry.start.Add(entry.duration)) {
@@ -96,26 +107,123 @@ func (s *leaseStore) ExpireLease(key lease.Key) error {
 	if !s.clock.Now().After(expiry) {
 		return lease.ErrInvalid
 	}
+	if _, pinned := s.pinned[key]; pinned {
+		return lease.ErrInvalid
+	}
+	if entry.successor != "" {
+		entry.holder = entry.successor
+		entry.start = s.clock.Now()
+		entry.duration = entry.successorDuration
+		entry.successor = ""
+		entry.successorDuration = 0
+		s.target.Claimed(key, entry.holder)
+		return nil
+	}
 	delete(s.entries, key)
 	s.target.Expired(key)
 	return nil
 }
 
+// TransferLease is part of lease.Store.
+func (s *leaseStore) TransferLease(key lease.Key, holder string, req lease.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, found := s.entries[key]
+	if !found || entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	if _, pinned := s.pinned[key]; pinned {
+		return lease.ErrPinned
+	}
+	entry.successor = req.Holder
+	entry.successorDuration = req.Duration
+	return nil
+}
+
+// PinLease is part of lease.Store.
+func (s *leaseStore) PinLease(key lease.Key, entity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, found := s.entries[key]; found {
+		entry.successor = ""
+		entry.successorDuration = 0
+	}
+	entities, found := s.pinned[key]
+	if !found {
+		entities = set.NewStrings()
+		s.pinned[key] = entities
+	}
+	entities.Add(entity)
+	return nil
+}
+
+// UnpinLease is part of lease.Store.
+func (s *leaseStore) UnpinLease(key lease.Key, entity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entities, found := s.pinned[key]; found {
+		entities.Remove(entity)
+		if entities.IsEmpty() {
+			delete(s.pinned, key)
+		}
+	}
+	return nil
+}
+
+// Pinned is part of lease.Store.
+func (s *leaseStore) Pinned() map[lease.Key][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make(map[lease.Key][]string, len(s.pinned))
+	for key, entities := range s.pinned {
+		results[key] = entities.SortedValues()
+	}
+	return results
+}
+
 // Leases is part of lease.Store.
 func (s *leaseStore) Leases() map[lease.Key]lease.Info {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	results := make(map[lease.Key]lease.Info)
 	for key, entry := range s.entries {
-		results[key] = lease.Info{
-			Holder:   entry.holder,
-			Expiry:   entry.start.Add(entry.duration),
-			Trapdoor: s.target.Trapdoor(key, entry.holder),
+		results[key] = s.info(key, entry)
+	}
+	return results
+}
+
+// LeaseGroup is part of lease.Store.
+func (s *leaseStore) LeaseGroup(namespace, modelUUID string) map[lease.Key]lease.Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make(map[lease.Key]lease.Info)
+	for key, entry := range s.entries {
+		if key.Namespace == namespace && key.ModelUUID == modelUUID {
+			results[key] = s.info(key, entry)
 		}
 	}
 	return results
 }
 
+// Lease is part of lease.Store.
+func (s *leaseStore) Lease(key lease.Key) (lease.Info, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, found := s.entries[key]
+	if !found {
+		return lease.Info{}, false
+	}
+	return s.info(key, entry), true
+}
+
+func (s *leaseStore) info(key lease.Key, entry *entry) lease.Info {
+	return lease.Info{
+		Holder:   entry.holder,
+		Expiry:   entry.start.Add(entry.duration),
+		Trapdoor: s.target.Trapdoor(key, entry.holder),
+	}
+}
+
 // Refresh is part of lease.Store.
 func (s *leaseStore) Refresh() error {
 	return nil
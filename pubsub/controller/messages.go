@@ -22,3 +22,52 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// RaftStatusRequestTopic is published to ask the raft worker on every
+// controller to report its view of the raft cluster on
+// RaftStatusTopic.
+// data: `RaftStatusRequest`
+const RaftStatusRequestTopic = "controller.raft-status-request"
+
+// RaftStatusRequest identifies the worker asking for raft status.
+type RaftStatusRequest struct {
+	Requester string `yaml:"requester"`
+}
+
+// RaftStatusTopic is published by the raft worker on each controller
+// in response to a RaftStatusRequest.
+// data: `RaftStatus`
+const RaftStatusTopic = "controller.raft-status"
+
+// RaftStatus holds a single raft server's view of the raft cluster.
+type RaftStatus struct {
+	// ID is the raft server ID, which is the controller machine ID.
+	ID string `yaml:"id"`
+
+	// State is the raft state of the server: Leader, Follower,
+	// Candidate or Shutdown.
+	State string `yaml:"state"`
+
+	// Leader is the ID of the raft leader as known to the server,
+	// if any.
+	Leader string `yaml:"leader,omitempty"`
+
+	// Term is the server's current raft term.
+	Term uint64 `yaml:"term"`
+
+	// Voters is the number of voting servers in the cluster. It is
+	// only reported by the leader.
+	Voters int `yaml:"voters,omitempty"`
+}
+
+// RaftStepDownTopic is published to ask the raft leader to step
+// down, so that the remaining controllers elect a new leader. It is
+// used to rehearse controller failover.
+// data: `RaftStepDown`
+const RaftStepDownTopic = "controller.raft-step-down"
+
+// RaftStepDown identifies the raft leader that should step down. A
+// server that isn't the leader ignores the request.
+type RaftStepDown struct {
+	Leader string `yaml:"leader"`
+}
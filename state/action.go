@@ -324,6 +324,19 @@ func (m *Model) AllActions() ([]Action, error) {
 	return results, nil
 }
 
+// PendingActionCount returns the number of actions in the model that
+// have been enqueued but have not yet started running.
+func (m *Model) PendingActionCount() (int, error) {
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	count, err := actions.Find(bson.D{{"status", ActionPending}}).Count()
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot count pending actions")
+	}
+	return count, nil
+}
+
 // ActionByTag returns an Action given an ActionTag.
 func (m *Model) ActionByTag(tag names.ActionTag) (Action, error) {
 	return m.Action(tag.Id())
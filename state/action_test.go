@@ -540,6 +540,27 @@ func (s *ActionSuite) TestFindActionsByName(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestPendingActionCount(c *gc.C) {
+	count, err := s.model.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	var actions []state.Action
+	for i := 0; i < 3; i++ {
+		action, err := s.unit.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		actions = append(actions, action)
+	}
+	_, err = actions[0].Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = actions[1].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err = s.model.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+}
+
 func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	// LP-1391914 :: idPrefixWatcher fails watcher contract to send
 	// initial Change event
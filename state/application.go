@@ -2241,6 +2241,23 @@ func (a *Application) StatusHistory(filter status.StatusHistoryFilter) ([]status
 	return statusHistory(args)
 }
 
+// RecordLeadershipChange adds an entry with the supplied message to the
+// application's status history, to record an administrative change to
+// its leadership. The application's current status is unaffected.
+func (a *Application) RecordLeadershipChange(message string) error {
+	current, err := a.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := statusDoc{
+		Status:     current.Status,
+		StatusInfo: message,
+		Updated:    a.st.clock().Now().UnixNano(),
+	}
+	_, err = probablyUpdateStatusHistory(a.st.db(), a.globalKey(), doc)
+	return errors.Annotatef(err, "cannot record leadership change for application %q", a.doc.Name)
+}
+
 // ApplicationAndUnitsStatus returns the status for this application and all its units.
 func (a *Application) ApplicationAndUnitsStatus() (status.StatusInfo, map[string]status.StatusInfo, error) {
 	applicationStatus, err := a.Status()
@@ -37,14 +37,17 @@ package backups
 
 import (
 	"io"
+	"net/url"
 	"os"
 	"path"
 	"strings"
 	"time"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/filestorage"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 )
 
@@ -87,8 +90,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
+	// the provided metadata. The archive is encrypted with
+	// the given key, if any.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *EncryptionKey) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -106,6 +110,26 @@ type Backups interface {
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, args RestoreArgs) (names.Tag, error)
+
+	// ExportModel reads a single model as it was when the backup was
+	// made, as exported for migration, without restoring the rest of
+	// the backup. The model's description and binaries are passed to
+	// use, and the binaries can only be read until use returns.
+	ExportModel(backupId string, args ExportModelArgs, use func(description.Model, ModelBinaries) error) error
+}
+
+// ModelBinaries opens the charms, agent binaries and resources used by
+// a model in a backup. It has the methods used to download a model's
+// binaries during a migration.
+type ModelBinaries interface {
+	// OpenCharm opens the archive of the identified charm.
+	OpenCharm(curl *charm.URL) (io.ReadCloser, error)
+
+	// OpenURI opens the agent binaries with the given tools URI.
+	OpenURI(uri string, query url.Values) (io.ReadCloser, error)
+
+	// OpenResource opens the content of an application's resource.
+	OpenResource(application, name string) (io.ReadCloser, error)
 }
 
 type backups struct {
@@ -122,9 +146,15 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *EncryptionKey) (string, error) {
+	if encryption != nil {
+		if err := encryption.Validate(); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	meta.Encrypted = encryption != nil
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -147,7 +177,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload, encryption}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -76,7 +76,12 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	defer backupReader.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	archive, err := decryptedArchive(backupId, meta, backupReader, args.Decryption)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
@@ -6,6 +6,7 @@
 package backups
 
 import (
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 )
@@ -15,3 +16,9 @@ import (
 func (*backups) Restore(_ string, _ RestoreArgs) (names.Tag, error) {
 	return nil, errors.Errorf("backups supported only on Linux")
 }
+
+// ExportModel satisfies the Backups interface on non-Linux OSes (e.g.
+// windows, darwin).
+func (*backups) ExportModel(_ string, _ ExportModelArgs, _ func(description.Model, ModelBinaries) error) error {
+	return errors.Errorf("backups supported only on Linux")
+}
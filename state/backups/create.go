@@ -33,6 +33,7 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encryption     *EncryptionKey
 }
 
 type createResult struct {
@@ -46,7 +47,7 @@ type createResult struct {
 // updates the metadata with the file info.
 func create(args *createArgs) (_ *createResult, err error) {
 	// Prepare the backup builder.
-	builder, err := newBuilder(args.backupDir, args.filesToBackUp, args.db)
+	builder, err := newBuilder(args.backupDir, args.filesToBackUp, args.db, args.encryption)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -98,6 +99,8 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// encryption is the key used to encrypt the archive, if any.
+	encryption *EncryptionKey
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -111,7 +114,7 @@ type builder struct {
 // directories which backup uses as its staging area while building the
 // archive.  It also creates the archive
 // (temp root, tarball root, DB dumpdir), along with any error.
-func newBuilder(backupDir string, filesToBackUp []string, db DBDumper) (b *builder, err error) {
+func newBuilder(backupDir string, filesToBackUp []string, db DBDumper, encryption *EncryptionKey) (b *builder, err error) {
 	// Create the backups workspace root directory.
 	rootDir, err := ioutil.TempDir(backupDir, tempPrefix)
 	if err != nil {
@@ -125,6 +128,7 @@ func newBuilder(backupDir string, filesToBackUp []string, db DBDumper) (b *build
 		filename:      filepath.Join(rootDir, TempFilename),
 		filesToBackUp: filesToBackUp,
 		db:            db,
+		encryption:    encryption,
 	}
 	defer func() {
 		if err != nil {
@@ -317,8 +321,24 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encryption == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		// The checksum is of the encrypted archive, which
+		// is the file users have to compare it against.
+		encrypted, err := encryptArchive(hasher, *b.encryption)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypted); err != nil {
+			encrypted.Close()
+			return errors.Trace(err)
+		}
+		if err := encrypted.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"compress/gzip"
 	"os"
 	"path"
 	"runtime"
@@ -58,6 +59,45 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestCreateArgs(backupDir, testFiles, dumper, metadataFile, true)
+	backups.SetCreateArgsEncryption(args, &backups.EncryptionKey{Passphrase: "sekrit"})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+
+	// The size and checksum are those of the encrypted archive.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	encrypted, archive, err := backups.IsEncryptedArchive(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, jc.IsTrue)
+	decrypted, err := backups.DecryptArchive(archive, backups.DecryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	tarFile, err := gzip.NewReader(decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTarContents(c, tarFile, []tarContent{
+		{"juju-backup", "", nil},
+		{"juju-backup/dump", "", nil},
+		{"juju-backup/root.tar", "", expected},
+		{"juju-backup/metadata.json", "", nil},
+	})
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var backupDir string
 	var testFiles []string
@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// gzipMagic is the header that starts every unencrypted backup archive.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// EncryptionKey holds what is needed to encrypt a backup archive.
+// Exactly one of its fields must be set.
+type EncryptionKey struct {
+	// Passphrase is used to encrypt the archive symmetrically.
+	Passphrase string
+
+	// PublicKey is an armored OpenPGP public key ring. The archive
+	// is encrypted for every key in it.
+	PublicKey string
+}
+
+// Validate returns an error if the key is not valid.
+func (k EncryptionKey) Validate() error {
+	if k.Passphrase == "" && k.PublicKey == "" {
+		return errors.NotValidf("empty encryption key")
+	}
+	if k.Passphrase != "" && k.PublicKey != "" {
+		return errors.NotValidf("encryption key with both passphrase and public key")
+	}
+	if k.PublicKey != "" {
+		if _, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.PublicKey)); err != nil {
+			return errors.Annotate(err, "invalid public key")
+		}
+	}
+	return nil
+}
+
+// DecryptionKey holds what is needed to decrypt a backup archive.
+type DecryptionKey struct {
+	// Passphrase is the passphrase the archive was encrypted with,
+	// or the passphrase protecting the private key, if any.
+	Passphrase string
+
+	// PrivateKey is an armored OpenPGP private key ring holding the
+	// key the archive was encrypted for.
+	PrivateKey string
+}
+
+// encryptArchive returns a writer that encrypts everything written to
+// it with the given key, writing the resulting OpenPGP message to w.
+// The writer must be closed to complete the message.
+func encryptArchive(w io.Writer, key EncryptionKey) (io.WriteCloser, error) {
+	if err := key.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if key.Passphrase != "" {
+		encrypted, err := openpgp.SymmetricallyEncrypt(w, []byte(key.Passphrase), nil, nil)
+		return encrypted, errors.Trace(err)
+	}
+	recipients, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PublicKey))
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid public key")
+	}
+	encrypted, err := openpgp.Encrypt(w, recipients, nil, nil, nil)
+	return encrypted, errors.Trace(err)
+}
+
+// DecryptArchive returns a reader of the backup archive encrypted in
+// the OpenPGP message read from r. The integrity of the archive is
+// only checked when the returned reader reaches the end of it.
+func DecryptArchive(r io.Reader, key DecryptionKey) (io.Reader, error) {
+	var keyring openpgp.EntityList
+	if key.PrivateKey != "" {
+		var err error
+		keyring, err = openpgp.ReadArmoredKeyRing(strings.NewReader(key.PrivateKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid private key")
+		}
+	}
+
+	// ReadMessage calls prompt again whenever the key it is given
+	// doesn't work, so we only try the passphrase once.
+	tried := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if tried || key.Passphrase == "" {
+			return nil, errors.New("backup archive cannot be decrypted with the given key")
+		}
+		tried = true
+		if symmetric {
+			return []byte(key.Passphrase), nil
+		}
+		var decryptErr error
+		unlocked := false
+		for _, k := range keys {
+			if k.PrivateKey == nil || !k.PrivateKey.Encrypted {
+				continue
+			}
+			if err := k.PrivateKey.Decrypt([]byte(key.Passphrase)); err != nil {
+				decryptErr = err
+				continue
+			}
+			unlocked = true
+		}
+		if !unlocked && decryptErr != nil {
+			return nil, errors.Annotate(decryptErr, "wrong passphrase for the private key")
+		}
+		return nil, nil
+	}
+	message, err := openpgp.ReadMessage(r, keyring, prompt, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decrypt backup archive")
+	}
+	return &decryptedReader{r: message.UnverifiedBody}, nil
+}
+
+// decryptedArchive returns a reader of the identified backup's archive,
+// read from r, decrypting it with key if the backup is encrypted.
+func decryptedArchive(backupId string, meta *Metadata, r io.Reader, key *DecryptionKey) (io.Reader, error) {
+	if !meta.Encrypted {
+		return r, nil
+	}
+	if key == nil {
+		return nil, errors.Errorf("backup %q is encrypted: a decryption key is required", backupId)
+	}
+	decrypted, err := DecryptArchive(r, *key)
+	return decrypted, errors.Trace(err)
+}
+
+// decryptedReader reads a decrypted archive, and keeps returning the
+// error that ended it. The integrity check done when the end of the
+// message is reached fails if it is done twice, so the message must
+// not be read again once it has ended.
+type decryptedReader struct {
+	r   io.Reader
+	err error
+}
+
+// Read is part of io.Reader.
+func (r *decryptedReader) Read(buf []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(buf)
+	r.err = err
+	return n, err
+}
+
+// IsEncryptedArchive reports whether the backup archive read from r is
+// encrypted. The returned reader reads the whole archive, including the
+// part read to decide.
+func IsEncryptedArchive(r io.Reader) (bool, io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return false, nil, errors.Trace(err)
+	}
+	return !bytes.Equal(header, gzipMagic), buffered, nil
+}
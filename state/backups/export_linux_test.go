@@ -0,0 +1,24 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+//go:build linux
+// +build linux
+
+package backups
+
+var (
+	ExportModelFn   = &exportModelFn
+	WaitForListener = waitForListener
+	FreeLocalPort   = freeLocalPort
+	GetMongodPath   = &getMongodPath
+)
+
+// StartScratchMongo starts a scratch mongod, returning the directory
+// it keeps its data in and a function that stops it.
+func StartScratchMongo() (string, func(), error) {
+	m, err := startScratchMongo()
+	if err != nil {
+		return "", nil, err
+	}
+	return m.dbDir, m.stop, nil
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/juju/testing"
 	"github.com/juju/utils/filestorage"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
 var (
-	Create        = create
-	FileTimestamp = fileTimestamp
+	Create         = create
+	FileTimestamp  = fileTimestamp
+	EncryptArchive = encryptArchive
 
 	TestGetFilesToBackUp  = &getFilesToBackUp
 	GetDBDumper           = &getDBDumper
@@ -30,6 +32,7 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	S3IdleTimeout         = &s3IdleTimeout
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
@@ -57,6 +60,30 @@ func GetBackupMetadata(st *state.State, id string) (*Metadata, error) {
 	return docAsMetadata(doc), nil
 }
 
+// NewStorageWithConfig returns a new FileStorage like NewStorage,
+// using the given controller config to find backup storage targets.
+func NewStorageWithConfig(st *state.State, controllerConfig controller.Config) filestorage.FileStorage {
+	dbWrap := getBackupDBWrapper(st)
+	defer dbWrap.Close()
+
+	files := newTargetFileStorage(dbWrap, newFileStorage(dbWrap, backupStorageRoot), func() (controller.Config, error) {
+		return controllerConfig, nil
+	})
+	docs := newMetadataStorage(dbWrap)
+	return filestorage.NewFileStorage(docs, files)
+}
+
+// NewTargetStorage returns the raw file storage for the given backup
+// storage target, for a backup created on controller machine 0.
+// S3 storage signs requests using the given time.
+func NewTargetStorage(target string, controllerConfig controller.Config, now time.Time) (filestorage.RawFileStorage, error) {
+	stor, err := newTargetStorage(target, "0", controllerConfig)
+	if s3, ok := stor.(*s3Storage); ok {
+		s3.now = func() time.Time { return now }
+	}
+	return stor, err
+}
+
 // AddBackupMetadata adds the metadata to storage.
 func AddBackupMetadata(st *state.State, meta *Metadata) (string, error) {
 	db := getBackupDBWrapper(st)
@@ -105,6 +132,12 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetCreateArgsEncryption sets the key used to encrypt the archive
+// built by create().
+func SetCreateArgsEncryption(args *createArgs, key *EncryptionKey) {
+	args.encryption = key
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
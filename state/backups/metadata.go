@@ -19,9 +19,9 @@ import (
 	jujuversion "github.com/juju/juju/version"
 )
 
-// checksumFormat identifies how to interpret the checksum for a backup
+// ChecksumFormat identifies how to interpret the checksum for a backup
 // generated with this version of juju.
-const checksumFormat = "SHA-1, base64 encoded"
+const ChecksumFormat = "SHA-1, base64 encoded"
 
 // Origin identifies where a backup archive came from.  While it is
 // more about where and Metadata about what and when, that distinction
@@ -68,6 +68,20 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled is whether the controller created the backup on its
+	// backup schedule, rather than at a user's request. Only scheduled
+	// backups are subject to the controller's retention policy.
+	Scheduled bool
+
+	// Storage is the backup storage target holding the archive,
+	// as for the backup-storage controller config. It is empty
+	// when the archive is stored in the controller's database.
+	Storage string
+
+	// Encrypted is whether the archive is encrypted, in which case
+	// a key is needed to restore it.
+	Encrypted bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -123,6 +137,7 @@ func NewMetadataState(db DB, machine, series string) (*Metadata, error) {
 	}
 	meta.CACert, _ = controllerCfg.CACert()
 	meta.CAPrivateKey = si.CAPrivateKey
+	meta.Storage = controllerCfg.BackupStorage()
 	return meta, nil
 }
 
@@ -135,7 +150,7 @@ func (m *Metadata) MarkComplete(size int64, checksum string) error {
 	if checksum == "" {
 		return errors.New("missing checksum")
 	}
-	format := checksumFormat
+	format := ChecksumFormat
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	finished := time.Now().UTC()
 
@@ -0,0 +1,372 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+// scratchMongoDialTimeout is how long to wait for a scratch mongod to
+// start accepting connections.
+const scratchMongoDialTimeout = 2 * time.Minute
+
+// scratchMongoPollInterval is how often to check whether a scratch
+// mongod has started accepting connections.
+const scratchMongoPollInterval = 100 * time.Millisecond
+
+// scratchMongoUser is the user created in a scratch mongod to restore
+// the backup into it and read it back.
+const scratchMongoUser = "juju-scratch"
+
+var exportModelFn = exportModelFromWorkspace
+
+// ExportModel reads a single model as it was when the backup was made.
+// The backup's database dump is restored into a scratch mongod, which
+// is not part of the controller's replica set, and the model is
+// exported from there; the running controller is not touched. The
+// scratch mongod is stopped once use returns.
+func (b *backups) ExportModel(backupId string, args ExportModelArgs, use func(description.Model, ModelBinaries) error) error {
+	meta, backupReader, err := b.Get(backupId)
+	if err != nil {
+		return errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	defer backupReader.Close()
+
+	archive, err := decryptedArchive(backupId, meta, backupReader, args.Decryption)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	workspace, err := NewArchiveWorkspaceReader(archive)
+	if err != nil {
+		return errors.Annotate(err, "cannot unpack backup file")
+	}
+	defer workspace.Close()
+
+	return errors.Trace(exportModelFn(workspace, args.ModelUUID, use))
+}
+
+// exportModelFromWorkspace restores the database dump in the unpacked
+// backup into a scratch mongod, exports the identified model from it,
+// and passes it to use along with the model's binaries.
+func exportModelFromWorkspace(workspace *ArchiveWorkspace, modelUUID string, use func(description.Model, ModelBinaries) error) error {
+	mongod, err := startScratchMongo()
+	if err != nil {
+		return errors.Annotate(err, "cannot start scratch database")
+	}
+	defer mongod.stop()
+	// mongod takes a while to start listening, and mongorestore
+	// fails rather than waiting for it.
+	if err := waitForListener(mongod.addr, mongod.exited, scratchMongoDialTimeout); err != nil {
+		return errors.Annotate(err, "scratch database not available")
+	}
+	password, err := utils.RandomPassword()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := createScratchUser(mongod.addr, password); err != nil {
+		return errors.Annotate(err, "cannot create scratch database user")
+	}
+
+	// The admin database holds the users of the backed up
+	// controller, which would replace the scratch user; the model
+	// doesn't need it.
+	if err := stripIgnored(set.NewStrings("admin"), workspace.DBDumpDir); err != nil {
+		return errors.Trace(err)
+	}
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return errors.Annotate(err, "mongorestore not available")
+	}
+	if err := runCommandFn(mongorestorePath,
+		"--host", mongod.addr,
+		"--authenticationDatabase", "admin",
+		"--username", scratchMongoUser,
+		"--password", password,
+		"--drop",
+		"--oplogReplay",
+		"--batchSize", "10",
+		workspace.DBDumpDir,
+	); err != nil {
+		return errors.Annotate(err, "cannot restore database dump")
+	}
+
+	session, err := mgo.DialWithInfo(&mgo.DialInfo{
+		Addrs:    []string{mongod.addr},
+		Direct:   true,
+		Timeout:  scratchMongoDialTimeout,
+		Source:   "admin",
+		Username: scratchMongoUser,
+		Password: password,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to scratch database")
+	}
+	defer session.Close()
+
+	controllerTag, controllerModelTag, err := readControllerTags(session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, err := state.Open(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      controllerTag,
+		ControllerModelTag: controllerModelTag,
+		MongoSession:       session,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot open backed up state")
+	}
+	defer st.Close()
+	pool := state.NewStatePool(st)
+	defer pool.Close()
+
+	modelSt, err := pool.Get(modelUUID)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("model %q in backup", modelUUID)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelSt.Release()
+
+	model, err := modelSt.Export()
+	if err != nil {
+		return errors.Annotatef(err, "cannot export model %q", modelUUID)
+	}
+	return errors.Trace(use(model, &backupBinaries{st: modelSt.State}))
+}
+
+// backupBinaries reads a model's binaries from the blobstore of a
+// restored backup.
+type backupBinaries struct {
+	st *state.State
+}
+
+// OpenCharm is part of ModelBinaries.
+func (b *backupBinaries) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := b.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm %q", curl)
+	}
+	stor := storage.NewStorage(b.st.ModelUUID(), b.st.MongoSession())
+	r, _, err := stor.Get(ch.StoragePath())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %q from backup", curl)
+	}
+	return r, nil
+}
+
+// OpenURI is part of ModelBinaries. Only the tools URIs used when
+// migrating a model, which name the agent binaries' version, are
+// supported.
+func (b *backupBinaries) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	if !strings.HasPrefix(uri, "/tools/") {
+		return nil, errors.NotSupportedf("URI %q", uri)
+	}
+	vers, err := version.ParseBinary(strings.TrimPrefix(uri, "/tools/"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor, err := b.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, r, err := stor.Open(vers.String())
+	if err != nil {
+		stor.Close()
+		return nil, errors.Annotatef(err, "cannot read agent binaries %v from backup", vers)
+	}
+	return &toolsReader{ReadCloser: r, storage: stor}, nil
+}
+
+// OpenResource is part of ModelBinaries.
+func (b *backupBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	resources, err := b.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, r, err := resources.OpenResource(application, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read resource %s/%s from backup", application, name)
+	}
+	return r, nil
+}
+
+// toolsReader closes the tools storage it was opened from when it is
+// closed.
+type toolsReader struct {
+	io.ReadCloser
+	storage io.Closer
+}
+
+// Close is part of io.Closer.
+func (r *toolsReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.storage.Close()
+	return err
+}
+
+// readControllerTags reads the tags of the controller and of its model
+// from the controllers collection of a restored juju database.
+func readControllerTags(session *mgo.Session) (names.ControllerTag, names.ModelTag, error) {
+	controllers := session.DB("juju").C("controllers")
+	var modelDoc struct {
+		ModelUUID string `bson:"model-uuid"`
+	}
+	if err := controllers.FindId(modelSettingsKey).One(&modelDoc); err != nil {
+		return names.ControllerTag{}, names.ModelTag{}, errors.Annotate(err, "cannot read controller model")
+	}
+	var settingsDoc struct {
+		Settings struct {
+			ControllerUUID string `bson:"controller-uuid"`
+		} `bson:"settings"`
+	}
+	if err := controllers.FindId("controllerSettings").One(&settingsDoc); err != nil {
+		return names.ControllerTag{}, names.ModelTag{}, errors.Annotate(err, "cannot read controller settings")
+	}
+	if !names.IsValidController(settingsDoc.Settings.ControllerUUID) || !names.IsValidModel(modelDoc.ModelUUID) {
+		return names.ControllerTag{}, names.ModelTag{}, errors.New("backup has no valid controller details")
+	}
+	return names.NewControllerTag(settingsDoc.Settings.ControllerUUID), names.NewModelTag(modelDoc.ModelUUID), nil
+}
+
+// createScratchUser creates the only user of a newly started scratch
+// mongod. mongod lets the first user be created without logging in,
+// as long as the connection comes from localhost.
+func createScratchUser(addr, password string) error {
+	session, err := mgo.DialWithInfo(&mgo.DialInfo{
+		Addrs:   []string{addr},
+		Direct:  true,
+		Timeout: scratchMongoDialTimeout,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer session.Close()
+	return errors.Trace(session.DB("admin").UpsertUser(&mgo.User{
+		Username: scratchMongoUser,
+		Password: password,
+		Roles:    []mgo.Role{mgo.RoleRoot},
+	}))
+}
+
+// scratchMongo is a standalone mongod, listening only on a random
+// localhost port and requiring authentication, used to read a backed
+// up database.
+type scratchMongo struct {
+	addr string
+	cmd  *exec.Cmd
+	// dbDir is the private directory the mongod keeps its data in;
+	// it is removed when the mongod is stopped.
+	dbDir string
+	// exited is closed when the mongod exits.
+	exited chan struct{}
+}
+
+// startScratchMongo starts a mongod keeping its data in a new private
+// directory.
+func startScratchMongo() (_ *scratchMongo, err error) {
+	mongodPath, err := getMongodPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get mongod path")
+	}
+	dbDir, err := ioutil.TempDir("", "juju-backups-mongod-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dbDir)
+		}
+	}()
+	port, err := freeLocalPort()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cmd := exec.Command(mongodPath,
+		"--dbpath", dbDir,
+		"--bind_ip", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--nounixsocket",
+		"--auth",
+	)
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Annotatef(err, "error executing %q", mongodPath)
+	}
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+	return &scratchMongo{
+		addr:   net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		cmd:    cmd,
+		dbDir:  dbDir,
+		exited: exited,
+	}, nil
+}
+
+// stop kills the mongod, waits for it to exit and removes its data.
+func (m *scratchMongo) stop() {
+	if err := m.cmd.Process.Kill(); err != nil {
+		logger.Warningf("cannot stop scratch mongod: %v", err)
+	}
+	<-m.exited
+	if err := os.RemoveAll(m.dbDir); err != nil {
+		logger.Warningf("cannot remove scratch mongod data: %v", err)
+	}
+}
+
+// waitForListener waits until a TCP connection can be made to addr,
+// failing if exited is closed or the timeout passes first.
+func waitForListener(addr string, exited <-chan struct{}, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, scratchMongoPollInterval)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		select {
+		case <-exited:
+			return errors.Errorf("mongod exited before listening on %s", addr)
+		case <-deadline:
+			return errors.Errorf("mongod not listening on %s after %v", addr, timeout)
+		case <-time.After(scratchMongoPollInterval):
+		}
+	}
+}
+
+// freeLocalPort returns a TCP port on localhost that was free when it
+// was checked.
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot find a free port")
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type exportModelSuite struct {
+	bt.BaseSuite
+}
+
+var _ = gc.Suite(&exportModelSuite{})
+
+func (s *exportModelSuite) setArchive(c *gc.C, encryption *backups.EncryptionKey) {
+	meta := bt.NewMetadataStarted()
+	meta.Encrypted = encryption != nil
+	archive, err := bt.NewArchive(meta, nil, []bt.File{{
+		Name:    "juju/models.bson",
+		Content: "<BSON data goes here>",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	data := archive.Bytes()
+	if encryption != nil {
+		var buf bytes.Buffer
+		w, err := backups.EncryptArchive(&buf, *encryption)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write(data)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(w.Close(), jc.ErrorIsNil)
+		data = buf.Bytes()
+	}
+	s.Storage.Meta = meta
+	s.Storage.File = ioutil.NopCloser(bytes.NewReader(data))
+}
+
+func (s *exportModelSuite) patchExport(c *gc.C, model description.Model, err error) *string {
+	var dumped string
+	s.PatchValue(backups.ExportModelFn, func(
+		workspace *backups.ArchiveWorkspace, modelUUID string, use func(description.Model, backups.ModelBinaries) error,
+	) error {
+		c.Check(modelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f001")
+		data, readErr := ioutil.ReadFile(filepath.Join(workspace.DBDumpDir, "juju", "models.bson"))
+		c.Assert(readErr, jc.ErrorIsNil)
+		dumped = string(data)
+		if err != nil {
+			return err
+		}
+		return use(model, nil)
+	})
+	return &dumped
+}
+
+func (s *exportModelSuite) TestExportModel(c *gc.C) {
+	s.setArchive(c, nil)
+	model := description.NewModel(description.ModelArgs{})
+	dumped := s.patchExport(c, model, nil)
+
+	api := backups.NewBackups(s.Storage)
+	var result description.Model
+	err := api.ExportModel("some-id", backups.ExportModelArgs{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+	}, func(model description.Model, _ backups.ModelBinaries) error {
+		result = model
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, model)
+	c.Check(*dumped, gc.Equals, "<BSON data goes here>")
+	c.Check(s.Storage.IDArg, gc.Equals, "some-id")
+}
+
+func (s *exportModelSuite) TestExportModelEncrypted(c *gc.C) {
+	s.setArchive(c, &backups.EncryptionKey{Passphrase: "sekrit"})
+	dumped := s.patchExport(c, nil, nil)
+
+	api := backups.NewBackups(s.Storage)
+	err := api.ExportModel("some-id", backups.ExportModelArgs{
+		ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f001",
+		Decryption: &backups.DecryptionKey{Passphrase: "sekrit"},
+	}, ignoreExported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*dumped, gc.Equals, "<BSON data goes here>")
+}
+
+func (s *exportModelSuite) TestExportModelEncryptedNoKey(c *gc.C) {
+	s.setArchive(c, &backups.EncryptionKey{Passphrase: "sekrit"})
+
+	api := backups.NewBackups(s.Storage)
+	err := api.ExportModel("some-id", backups.ExportModelArgs{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+	}, ignoreExported)
+	c.Assert(err, gc.ErrorMatches, `backup "some-id" is encrypted: a decryption key is required`)
+}
+
+func (s *exportModelSuite) TestExportModelFails(c *gc.C) {
+	s.setArchive(c, nil)
+	s.patchExport(c, nil, errors.NotFoundf(`model "deadbeef-0bad-400d-8000-4b1d0d06f001" in backup`))
+
+	api := backups.NewBackups(s.Storage)
+	err := api.ExportModel("some-id", backups.ExportModelArgs{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+	}, ignoreExported)
+	c.Assert(err, gc.ErrorMatches, `model "deadbeef-0bad-400d-8000-4b1d0d06f001" in backup not found`)
+}
+
+func (s *exportModelSuite) TestExportModelUseFails(c *gc.C) {
+	s.setArchive(c, nil)
+	s.patchExport(c, description.NewModel(description.ModelArgs{}), nil)
+
+	api := backups.NewBackups(s.Storage)
+	err := api.ExportModel("some-id", backups.ExportModelArgs{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f001",
+	}, func(description.Model, backups.ModelBinaries) error {
+		return errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func ignoreExported(description.Model, backups.ModelBinaries) error {
+	return nil
+}
+
+func (s *exportModelSuite) TestWaitForListener(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+
+	err = backups.WaitForListener(l.Addr().String(), nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportModelSuite) TestWaitForListenerExited(c *gc.C) {
+	port, err := backups.FreeLocalPort()
+	c.Assert(err, jc.ErrorIsNil)
+	exited := make(chan struct{})
+	close(exited)
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	err = backups.WaitForListener(addr, exited, time.Minute)
+	c.Assert(err, gc.ErrorMatches, `mongod exited before listening on 127.0.0.1:\d+`)
+}
+
+func (s *exportModelSuite) TestWaitForListenerTimeout(c *gc.C) {
+	port, err := backups.FreeLocalPort()
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	err = backups.WaitForListener(addr, nil, time.Millisecond)
+	c.Assert(err, gc.ErrorMatches, `mongod not listening on 127.0.0.1:\d+ after 1ms`)
+}
+
+func (s *exportModelSuite) TestScratchMongoRemovesData(c *gc.C) {
+	s.PatchValue(backups.GetMongodPath, func() (string, error) {
+		return "/bin/true", nil
+	})
+
+	dbDir, stop, err := backups.StartScratchMongo()
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(dbDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0700))
+
+	stop()
+	_, err = os.Stat(dbDir)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *exportModelSuite) TestScratchMongoRemovesDataWhenStartFails(c *gc.C) {
+	dir := c.MkDir()
+	s.PatchValue(backups.GetMongodPath, func() (string, error) {
+		return filepath.Join(dir, "no-mongod"), nil
+	})
+	s.PatchEnvironment("TMPDIR", dir)
+
+	_, _, err := backups.StartScratchMongo()
+	c.Assert(err, gc.ErrorMatches, `error executing ".*no-mongod": .*`)
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
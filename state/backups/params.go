@@ -16,4 +16,17 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// Decryption is the key used to decrypt an encrypted backup.
+	Decryption *DecryptionKey
+}
+
+// ExportModelArgs holds the args to be used to call
+// state/backups.ExportModel.
+type ExportModelArgs struct {
+	// ModelUUID identifies the model to export from the backup.
+	ModelUUID string
+
+	// Decryption is the key used to decrypt an encrypted backup.
+	Decryption *DecryptionKey
 }
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+	"time"
+)
+
+// ScheduledNotes is the annotation recorded in the metadata of the
+// backups the controller creates on a schedule. It is informational
+// only: scheduled backups are identified by Metadata.Scheduled, so a
+// user-created backup with the same notes is never removed.
+const ScheduledNotes = "scheduled backup"
+
+// RetentionPolicy describes which scheduled backups are kept.
+type RetentionPolicy struct {
+	// Daily is the number of days, including today, for which the
+	// most recent scheduled backup of each day is kept.
+	Daily int
+
+	// Weekly is the number of weeks, including this week, for which
+	// the most recent scheduled backup of each week is kept. Weeks
+	// start on Monday.
+	Weekly int
+}
+
+// Expired returns the scheduled backups in the given list that are
+// not kept by the policy at the given time. The most recent scheduled
+// backup is always kept, whatever the policy. Backups created by users
+// are never expired.
+func (p RetentionPolicy) Expired(all []*Metadata, now time.Time) []*Metadata {
+	var scheduled []*Metadata
+	for _, meta := range all {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	today := startOfDay(now)
+	thisWeek := startOfWeek(now)
+	keptDays := make(map[time.Time]bool)
+	keptWeeks := make(map[time.Time]bool)
+	var expired []*Metadata
+	for i, meta := range scheduled {
+		keep := i == 0
+		day := startOfDay(meta.Started)
+		if daysBetween(day, today) < p.Daily && !keptDays[day] {
+			keptDays[day] = true
+			keep = true
+		}
+		week := startOfWeek(meta.Started)
+		if daysBetween(week, thisWeek)/7 < p.Weekly && !keptWeeks[week] {
+			keptWeeks[week] = true
+			keep = true
+		}
+		if !keep {
+			expired = append(expired, meta)
+		}
+	}
+	return expired
+}
+
+// LastSuccessful returns the metadata of the most recently completed
+// backup in the given list that was stored, or nil if there is none.
+func LastSuccessful(all []*Metadata) *Metadata {
+	var last *Metadata
+	for _, meta := range all {
+		if meta.Finished == nil || meta.Stored() == nil {
+			continue
+		}
+		if last == nil || meta.Finished.After(*last.Finished) {
+			last = meta
+		}
+	}
+	return last
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	// Go's weeks start on Sunday; ours start on Monday.
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// daysBetween returns the number of whole days from the start
+// of one day to the start of a later day.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours()+0.5) / 24
+}
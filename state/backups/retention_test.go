@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+// Wednesday 13th June 2018.
+var retentionNow = time.Date(2018, time.June, 13, 15, 0, 0, 0, time.UTC)
+
+func newScheduledMetadata(id string, started time.Time) *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Notes = backups.ScheduledNotes
+	meta.Scheduled = true
+	return meta
+}
+
+func metadataIDs(metas []*backups.Metadata) []string {
+	var ids []string
+	for _, meta := range metas {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (s *retentionSuite) TestExpiredKeepsNewestPerDay(c *gc.C) {
+	all := []*backups.Metadata{
+		newScheduledMetadata("today-early", retentionNow.Add(-12*time.Hour)),
+		newScheduledMetadata("today-late", retentionNow.Add(-time.Hour)),
+		newScheduledMetadata("yesterday", retentionNow.Add(-24*time.Hour)),
+		newScheduledMetadata("three-days-ago", retentionNow.Add(-72*time.Hour)),
+	}
+	policy := backups.RetentionPolicy{Daily: 3}
+	c.Assert(metadataIDs(policy.Expired(all, retentionNow)), jc.DeepEquals, []string{
+		"today-early", "three-days-ago",
+	})
+}
+
+func (s *retentionSuite) TestExpiredKeepsNewestPerWeek(c *gc.C) {
+	all := []*backups.Metadata{
+		// Monday and Sunday of the previous week.
+		newScheduledMetadata("last-monday", time.Date(2018, time.June, 4, 1, 0, 0, 0, time.UTC)),
+		newScheduledMetadata("last-sunday", time.Date(2018, time.June, 10, 1, 0, 0, 0, time.UTC)),
+		// The week before that.
+		newScheduledMetadata("two-weeks-ago", time.Date(2018, time.May, 29, 1, 0, 0, 0, time.UTC)),
+		// Outside the retention period.
+		newScheduledMetadata("three-weeks-ago", time.Date(2018, time.May, 22, 1, 0, 0, 0, time.UTC)),
+		newScheduledMetadata("today", time.Date(2018, time.June, 13, 1, 0, 0, 0, time.UTC)),
+	}
+	policy := backups.RetentionPolicy{Daily: 1, Weekly: 3}
+	c.Assert(metadataIDs(policy.Expired(all, retentionNow)), jc.DeepEquals, []string{
+		"last-monday", "three-weeks-ago",
+	})
+}
+
+func (s *retentionSuite) TestExpiredAlwaysKeepsNewest(c *gc.C) {
+	all := []*backups.Metadata{
+		newScheduledMetadata("old", retentionNow.AddDate(0, -2, 0)),
+		newScheduledMetadata("older", retentionNow.AddDate(0, -3, 0)),
+	}
+	policy := backups.RetentionPolicy{Daily: 7, Weekly: 4}
+	c.Assert(metadataIDs(policy.Expired(all, retentionNow)), jc.DeepEquals, []string{"older"})
+}
+
+func (s *retentionSuite) TestExpiredIgnoresUnscheduled(c *gc.C) {
+	manual := backupstesting.NewMetadataStarted()
+	manual.SetID("manual")
+	manual.Started = retentionNow.AddDate(-1, 0, 0)
+	// The notes a user gives a backup do not make it scheduled.
+	manual.Notes = backups.ScheduledNotes
+	all := []*backups.Metadata{
+		manual,
+		newScheduledMetadata("scheduled", retentionNow.Add(-time.Hour)),
+	}
+	policy := backups.RetentionPolicy{}
+	c.Assert(policy.Expired(all, retentionNow), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestLastSuccessful(c *gc.C) {
+	stored := retentionNow
+	var all []*backups.Metadata
+	for i, id := range []string{"older", "newest", "unstored", "unfinished"} {
+		meta := newScheduledMetadata(id, retentionNow.Add(time.Duration(i-4)*time.Hour))
+		if id != "unfinished" {
+			backupstesting.FinishMetadata(meta)
+		}
+		if id != "unstored" {
+			meta.SetStored(&stored)
+		}
+		all = append(all, meta)
+	}
+	last := backups.LastSuccessful(all)
+	c.Assert(last, gc.NotNil)
+	c.Assert(last.ID(), gc.Equals, "newest")
+}
+
+func (s *retentionSuite) TestLastSuccessfulNone(c *gc.C) {
+	c.Assert(backups.LastSuccessful(nil), gc.IsNil)
+}
@@ -44,9 +44,12 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started   int64  `bson:"started,minsize"`
+	Finished  int64  `bson:"finished,minsize"`
+	Notes     string `bson:"notes,omitempty"`
+	Storage   string `bson:"storage,omitempty"`
+	Encrypted bool   `bson:"encrypted,omitempty"`
+	Scheduled bool   `bson:"scheduled,omitempty"`
 
 	// origin
 
@@ -124,6 +127,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Storage = doc.Storage
+	meta.Encrypted = doc.Encrypted
+	meta.Scheduled = doc.Scheduled
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +181,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Storage = meta.Storage
+	doc.Encrypted = meta.Encrypted
+	doc.Scheduled = meta.Scheduled
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -557,7 +566,7 @@ func NewStorage(st DB) filestorage.FileStorage {
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	files := newTargetFileStorage(dbWrap, newFileStorage(dbWrap, backupStorageRoot), st.ControllerConfig)
 	docs := newMetadataStorage(dbWrap)
 	return filestorage.NewFileStorage(docs, files)
 }
@@ -66,6 +66,7 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 		c.Check(meta.ID(), gc.Equals, id)
 	}
 	c.Check(meta.Notes, gc.Equals, expected.Notes)
+	c.Check(meta.Scheduled, gc.Equals, expected.Scheduled)
 	c.Check(meta.Started.Unix(), gc.Equals, expected.Started.Unix())
 	c.Check(meta.Checksum(), gc.Equals, expected.Checksum())
 	c.Check(meta.ChecksumFormat(), gc.Equals, expected.ChecksumFormat())
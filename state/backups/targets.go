@@ -0,0 +1,457 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+// archiveExt is the extension of the names of backup archives
+// stored outside the controller's database.
+const archiveExt = ".tar.gz"
+
+// newTargetStorage returns the raw file storage for the given backup
+// storage target, as described by the backup-storage controller
+// config, for the backup created on the given controller machine.
+// The target must not be the controller's database.
+var newTargetStorage = func(target, machine string, controllerConfig controller.Config) (filestorage.RawFileStorage, error) {
+	if err := controller.ValidateBackupStorage(target); err != nil {
+		return nil, errors.Trace(err)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch u.Scheme {
+	case "file":
+		return &dirStorage{root: filepath.FromSlash(u.Path), machine: machine}, nil
+	case "s3":
+		accessKey, secretKey := controllerConfig.BackupS3Credentials()
+		if accessKey == "" || secretKey == "" {
+			return nil, errors.Errorf("backup storage %q requires %s and %s to be set", target,
+				controller.BackupS3AccessKey, controller.BackupS3SecretKey)
+		}
+		return newS3Storage(u, accessKey, secretKey)
+	}
+	return nil, errors.NotValidf("backup storage %q", target)
+}
+
+//---------------------------
+// storage target routing
+
+// targetFileStorage is a raw file storage that stores each backup
+// archive in the storage target recorded in the backup's metadata.
+// Archives without a storage target are stored in the controller's
+// database.
+type targetFileStorage struct {
+	dbWrap           *storageDBWrapper
+	controller       filestorage.RawFileStorage
+	controllerConfig func() (controller.Config, error)
+}
+
+func newTargetFileStorage(
+	dbWrap *storageDBWrapper,
+	controllerStorage filestorage.RawFileStorage,
+	controllerConfig func() (controller.Config, error),
+) filestorage.RawFileStorage {
+	return &targetFileStorage{
+		dbWrap:           dbWrap.Copy(),
+		controller:       controllerStorage,
+		controllerConfig: controllerConfig,
+	}
+}
+
+// storageFor returns the raw file storage holding the identified
+// backup's archive.
+func (s *targetFileStorage) storageFor(id string) (filestorage.RawFileStorage, error) {
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+
+	doc, err := getStorageMetadata(dbWrap, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc.Storage == "" {
+		return s.controller, nil
+	}
+	controllerConfig, err := s.controllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	stor, err := newTargetStorage(doc.Storage, doc.Machine, controllerConfig)
+	return stor, errors.Trace(err)
+}
+
+// File returns the identified file from storage.
+func (s *targetFileStorage) File(id string) (io.ReadCloser, error) {
+	stor, err := s.storageFor(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := stor.File(id)
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *targetFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	stor, err := s.storageFor(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stor.AddFile(id, file, size))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *targetFileStorage) RemoveFile(id string) error {
+	stor, err := s.storageFor(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stor.RemoveFile(id))
+}
+
+// Close closes the storage.
+func (s *targetFileStorage) Close() error {
+	s.dbWrap.Close()
+	return errors.Trace(s.controller.Close())
+}
+
+//---------------------------
+// directory storage
+
+// dirStorage stores backup archives in a directory. The directory
+// must be shared by all the controller machines, such as an NFS mount;
+// if it is not, an archive can only be read or removed on the
+// controller machine that created the backup.
+type dirStorage struct {
+	root string
+
+	// machine is the controller machine that created the backup.
+	machine string
+}
+
+// notFound returns the error for an archive missing from the directory,
+// naming the controller machine that stored it.
+func (s *dirStorage) notFound(id string) error {
+	return errors.NotFoundf(
+		"backup archive %q in %s (stored by controller machine %q; file backup storage must be shared by all controller machines)",
+		id, s.root, s.machine,
+	)
+}
+
+func (s *dirStorage) path(id string) string {
+	return filepath.Join(s.root, id+archiveExt)
+}
+
+// File returns the identified file from storage.
+func (s *dirStorage) File(id string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, s.notFound(id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *dirStorage) AddFile(id string, file io.Reader, size int64) error {
+	if err := os.MkdirAll(s.root, 0700); err != nil {
+		return errors.Annotate(err, "creating backup storage directory")
+	}
+	// Write to a temporary file first, so that an archive
+	// is never seen half written.
+	tmp, err := ioutil.TempFile(s.root, "."+id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tmp.Name())
+	written, err := io.Copy(tmp, file)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "writing backup archive %q", id)
+	}
+	if size > 0 && written != size {
+		return errors.Errorf("writing backup archive %q: expected %d bytes, got %d", id, size, written)
+	}
+	return errors.Trace(os.Rename(tmp.Name(), s.path(id)))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *dirStorage) RemoveFile(id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return s.notFound(id)
+	}
+	return errors.Trace(err)
+}
+
+// Close closes the storage.
+func (s *dirStorage) Close() error {
+	return nil
+}
+
+//---------------------------
+// S3 storage
+
+const (
+	defaultS3Region   = "us-east-1"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3SignedHeaders   = "host;x-amz-content-sha256;x-amz-date"
+
+	// s3ConnectTimeout bounds how long connecting to the object
+	// store, including the TLS handshake, may take.
+	s3ConnectTimeout = 30 * time.Second
+)
+
+// s3IdleTimeout is how long a connection to the object store may go
+// without sending or receiving anything before the request is given
+// up on. Archives can take a long time to transfer, so the request as
+// a whole isn't limited; but a stalled transfer fails.
+var s3IdleTimeout = 2 * time.Minute
+
+// newS3Client returns an HTTP client for talking to the object
+// store, whose requests fail if their connection stalls.
+func newS3Client() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   s3ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	idleTimeout := s3IdleTimeout
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				return &idleTimeoutConn{Conn: conn, timeout: idleTimeout}, nil
+			},
+			TLSHandshakeTimeout: s3ConnectTimeout,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// idleTimeoutConn is a net.Conn whose reads and writes fail if they
+// make no progress for the timeout.
+type idleTimeoutConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+// Read is part of net.Conn.
+func (c *idleTimeoutConn) Read(b []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Read(b)
+}
+
+// Write is part of net.Conn.
+func (c *idleTimeoutConn) Write(b []byte) (int, error) {
+	if err := c.Conn.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Write(b)
+}
+
+// s3Storage stores backup archives in a bucket of an S3 compatible
+// object store, using path style requests signed with AWS Signature
+// Version 4.
+type s3Storage struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	now       func() time.Time
+}
+
+// newS3Storage returns a storage for the bucket and prefix of the
+// given "s3://<bucket>[/<prefix>]" URL. The object store's endpoint
+// and region are taken from the URL's query, and default to those
+// of AWS in us-east-1.
+func newS3Storage(u *url.URL, accessKey, secretKey string) (*s3Storage, error) {
+	region := u.Query().Get("region")
+	if region == "" {
+		region = defaultS3Region
+	}
+	endpoint := u.Query().Get("endpoint")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid S3 endpoint")
+	}
+	return &s3Storage{
+		client:    newS3Client(),
+		endpoint:  endpointURL,
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		now:       time.Now,
+	}, nil
+}
+
+// File returns the identified file from storage.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	resp, err := s.do("GET", id, nil, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
+// AddFile adds the file to storage.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	resp, err := s.do("PUT", id, file, size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *s3Storage) RemoveFile(id string) error {
+	resp, err := s.do("DELETE", id, nil, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+// Close closes the storage.
+func (s *s3Storage) Close() error {
+	return nil
+}
+
+// do makes a signed request for the identified archive's object,
+// and returns the response if it succeeded.
+func (s *s3Storage) do(method, id string, body io.Reader, size int64) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = path.Join("/", u.Path, s.bucket, s.prefix, id+archiveExt)
+	u.RawPath = s3EscapePath(u.Path)
+	if body != nil && size < 0 {
+		return nil, errors.Errorf("%s backup archive %q: size unknown", method, id)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if body != nil {
+		// S3 rejects chunked uploads, so the length is always
+		// sent; an empty body must be marked as such, or it's
+		// taken to be of unknown length.
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, errors.NotFoundf("backup archive %q in bucket %q", id, s.bucket)
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		defer resp.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("%s backup archive %q in bucket %q: %s: %s",
+			method, id, s.bucket, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the
+// request. The payload is not signed, so that archives can be
+// streamed without reading them twice.
+func (s *s3Storage) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{now.Format("20060102"), s.region, "s3", "aws4_request"}, "/")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		s3SignedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range strings.Split(scope, "/") {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, s3SignedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath escapes every character of the path except the
+// unreserved characters and "/", as required for signing.
+func s3EscapePath(p string) string {
+	var buf bytes.Buffer
+	for _, b := range []byte(p) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			buf.WriteByte(b)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,272 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type targetsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&targetsSuite{})
+
+var signingTime = time.Date(2018, time.June, 13, 15, 0, 0, 0, time.UTC)
+
+func (s *targetsSuite) TestDirStorage(c *gc.C) {
+	root := filepath.Join(c.MkDir(), "backups")
+	stor, err := backups.NewTargetStorage("file://"+filepath.ToSlash(root), nil, signingTime)
+	c.Assert(err, jc.ErrorIsNil)
+	defer stor.Close()
+
+	err = stor.AddFile("spam", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(root, "spam.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	file, err := stor.File("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadAll(file)
+	file.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	err = stor.RemoveFile("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(root, "spam.tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *targetsSuite) TestDirStorageNotFound(c *gc.C) {
+	stor, err := backups.NewTargetStorage("file://"+filepath.ToSlash(c.MkDir()), nil, signingTime)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stor.File("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `backup archive "spam" in .* \(stored by controller machine "0"; file backup storage must be shared by all controller machines\) not found`)
+	err = stor.RemoveFile("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetsSuite) TestDirStorageShortWrite(c *gc.C) {
+	root := c.MkDir()
+	stor, err := backups.NewTargetStorage("file://"+filepath.ToSlash(root), nil, signingTime)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.AddFile("spam", bytes.NewBufferString("<arch"), 9)
+	c.Assert(err, gc.ErrorMatches, `writing backup archive "spam": expected 9 bytes, got 5`)
+	_, err = os.Stat(filepath.Join(root, "spam.tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *targetsSuite) TestS3StorageRequiresCredentials(c *gc.C) {
+	_, err := backups.NewTargetStorage("s3://bucket", controller.Config{}, signingTime)
+	c.Assert(err, gc.ErrorMatches, `backup storage "s3://bucket" requires backup-s3-access-key and backup-s3-secret-key to be set`)
+}
+
+func (s *targetsSuite) TestInvalidTarget(c *gc.C) {
+	_, err := backups.NewTargetStorage("ftp://host/dir", nil, signingTime)
+	c.Assert(err, gc.ErrorMatches, `invalid backup storage "ftp://host/dir": .*`)
+}
+
+type s3Request struct {
+	method           string
+	path             string
+	body             string
+	contentLength    int64
+	transferEncoding []string
+	authorization    string
+	contentSHA256    string
+	date             string
+}
+
+func (s *targetsSuite) newS3Server(c *gc.C, status int, response string) (*httptest.Server, *[]s3Request) {
+	var requests []s3Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		requests = append(requests, s3Request{
+			method:           req.Method,
+			path:             req.URL.EscapedPath(),
+			body:             string(body),
+			contentLength:    req.ContentLength,
+			transferEncoding: req.TransferEncoding,
+			authorization:    req.Header.Get("Authorization"),
+			contentSHA256:    req.Header.Get("x-amz-content-sha256"),
+			date:             req.Header.Get("x-amz-date"),
+		})
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	return server, &requests
+}
+
+func (s *targetsSuite) newS3Storage(c *gc.C, server *httptest.Server) filestorage.RawFileStorage {
+	stor, err := backups.NewTargetStorage(
+		"s3://bucket/juju/prod?region=eu-west-2&endpoint="+server.URL,
+		controller.Config{
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		},
+		signingTime,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return stor
+}
+
+func (s *targetsSuite) TestS3StorageAddFile(c *gc.C) {
+	server, requests := s.newS3Server(c, http.StatusOK, "")
+	stor := s.newS3Storage(c, server)
+
+	err := stor.AddFile("20180613-150000.deadbeef", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(*requests, gc.HasLen, 1)
+	req := (*requests)[0]
+	c.Check(req.method, gc.Equals, "PUT")
+	c.Check(req.path, gc.Equals, "/bucket/juju/prod/20180613-150000.deadbeef.tar.gz")
+	c.Check(req.body, gc.Equals, "<archive>")
+	c.Check(req.contentSHA256, gc.Equals, "UNSIGNED-PAYLOAD")
+	c.Check(req.date, gc.Equals, "20180613T150000Z")
+	c.Check(req.authorization, gc.Matches,
+		`AWS4-HMAC-SHA256 Credential=access/20180613/eu-west-2/s3/aws4_request, `+
+			`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}`)
+}
+
+func (s *targetsSuite) TestS3StorageAddEmptyFile(c *gc.C) {
+	server, requests := s.newS3Server(c, http.StatusOK, "")
+	stor := s.newS3Storage(c, server)
+
+	// The reader's length can't be told from its type, so it must
+	// come from the size.
+	err := stor.AddFile("spam", io.MultiReader(), 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(*requests, gc.HasLen, 1)
+	req := (*requests)[0]
+	c.Check(req.method, gc.Equals, "PUT")
+	c.Check(req.contentLength, gc.Equals, int64(0))
+	c.Check(req.transferEncoding, gc.HasLen, 0)
+}
+
+func (s *targetsSuite) TestS3StorageAddFileSendsLength(c *gc.C) {
+	server, requests := s.newS3Server(c, http.StatusOK, "")
+	stor := s.newS3Storage(c, server)
+
+	err := stor.AddFile("spam", io.MultiReader(bytes.NewBufferString("<archive>")), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(*requests, gc.HasLen, 1)
+	req := (*requests)[0]
+	c.Check(req.body, gc.Equals, "<archive>")
+	c.Check(req.contentLength, gc.Equals, int64(9))
+	c.Check(req.transferEncoding, gc.HasLen, 0)
+}
+
+func (s *targetsSuite) TestS3StorageStalled(c *gc.C) {
+	s.PatchValue(backups.S3IdleTimeout, 50*time.Millisecond)
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-stalled
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.AddCleanup(func(*gc.C) { close(stalled) })
+	stor := s.newS3Storage(c, server)
+
+	_, err := stor.File("spam")
+	c.Assert(err, gc.ErrorMatches, ".*i/o timeout")
+}
+
+func (s *targetsSuite) TestS3StorageFile(c *gc.C) {
+	server, requests := s.newS3Server(c, http.StatusOK, "<archive>")
+	stor := s.newS3Storage(c, server)
+
+	file, err := stor.File("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(file)
+	file.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	c.Assert(*requests, gc.HasLen, 1)
+	c.Check((*requests)[0].method, gc.Equals, "GET")
+	c.Check((*requests)[0].path, gc.Equals, "/bucket/juju/prod/spam.tar.gz")
+}
+
+func (s *targetsSuite) TestS3StorageRemoveFile(c *gc.C) {
+	server, requests := s.newS3Server(c, http.StatusNoContent, "")
+	stor := s.newS3Storage(c, server)
+
+	err := stor.RemoveFile("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(*requests, gc.HasLen, 1)
+	c.Check((*requests)[0].method, gc.Equals, "DELETE")
+	c.Check((*requests)[0].path, gc.Equals, "/bucket/juju/prod/spam.tar.gz")
+}
+
+func (s *targetsSuite) TestS3StorageNotFound(c *gc.C) {
+	server, _ := s.newS3Server(c, http.StatusNotFound, "")
+	stor := s.newS3Storage(c, server)
+
+	_, err := stor.File("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetsSuite) TestS3StorageError(c *gc.C) {
+	server, _ := s.newS3Server(c, http.StatusForbidden, "AccessDenied\n")
+	stor := s.newS3Storage(c, server)
+
+	err := stor.AddFile("spam", bytes.NewBufferString("<archive>"), 9)
+	c.Check(err, gc.ErrorMatches, `PUT backup archive "spam" in bucket "bucket": 403 Forbidden: AccessDenied`)
+}
+
+func (s *storageSuite) TestStorageTarget(c *gc.C) {
+	root := c.MkDir()
+	stor := backups.NewStorageWithConfig(s.State, nil)
+	defer stor.Close()
+
+	original := s.metadata(c)
+	original.Storage = "file://" + filepath.ToSlash(root)
+	archive := strings.Repeat("<archive>", 5)[:original.Size()]
+	id, err := stor.Add(original, bytes.NewBufferString(archive))
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(root, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archive)
+
+	meta, file, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadAll(file)
+	file.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archive)
+	c.Check(meta.(*backups.Metadata).Storage, gc.Equals, original.Storage)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(root, id+".tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
@@ -6,6 +6,7 @@ package testing
 import (
 	"io"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/filestorage"
@@ -53,6 +54,16 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// EncryptionArg holds the encryption key that was passed in.
+	EncryptionArg *backups.EncryptionKey
+	// DecryptionArg holds the decryption key that was passed in.
+	DecryptionArg *backups.DecryptionKey
+	// ModelUUIDArg holds the model UUID that was passed in.
+	ModelUUIDArg string
+	// Model holds the model description to export.
+	Model description.Model
+	// Binaries holds the model binaries to export.
+	Binaries backups.ModelBinaries
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -64,6 +75,7 @@ func (b *FakeBackups) Create(
 	paths *backups.Paths,
 	dbInfo *backups.DBInfo,
 	keepCopy, noDownload bool,
+	encryption *backups.EncryptionKey,
 ) (string, error) {
 	b.Calls = append(b.Calls, "Create")
 
@@ -72,6 +84,7 @@ func (b *FakeBackups) Create(
 	b.MetaArg = meta
 	b.KeepCopy = keepCopy
 	b.NoDownload = noDownload
+	b.EncryptionArg = encryption
 
 	if b.Meta != nil {
 		*meta = *b.Meta
@@ -117,9 +130,23 @@ func (b *FakeBackups) Restore(bkpId string, args backups.RestoreArgs) (names.Tag
 	b.Calls = append(b.Calls, "Restore")
 	b.PrivateAddr = args.PrivateAddress
 	b.InstanceId = args.NewInstId
+	b.DecryptionArg = args.Decryption
 	return nil, errors.Trace(b.Error)
 }
 
+// ExportModel passes the description and binaries of a model in a
+// backup to use.
+func (b *FakeBackups) ExportModel(bkpId string, args backups.ExportModelArgs, use func(description.Model, backups.ModelBinaries) error) error {
+	b.Calls = append(b.Calls, "ExportModel")
+	b.IDArg = bkpId
+	b.ModelUUIDArg = args.ModelUUID
+	b.DecryptionArg = args.Decryption
+	if b.Error != nil {
+		return errors.Trace(b.Error)
+	}
+	return use(b.Model, b.Binaries)
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
@@ -0,0 +1,370 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxBSONDocumentSize is the largest document mongodump can write,
+// allowing for some overhead above mongo's 16MiB document limit.
+const maxBSONDocumentSize = 16*1024*1024 + 16*1024
+
+const (
+	// These match the names used by the state package, which
+	// this package cannot import.
+	modelsCollection   = "models"
+	settingsCollection = "settings"
+	modelSettingsKey   = "e"
+)
+
+// VerifiedModel describes a model found in a backup archive.
+type VerifiedModel struct {
+	UUID  string
+	Name  string
+	Owner string
+
+	// AgentVersion is the model's agent-version, if it has one.
+	AgentVersion string
+}
+
+// VerifyResult describes a backup archive that was found to be
+// restorable by VerifyArchive.
+type VerifyResult struct {
+	// Metadata is the metadata stored in the archive, if any.
+	Metadata *Metadata
+
+	// Version is the version of juju that created the archive.
+	Version version.Number
+
+	// Size and Checksum are the size and checksum of the archive as
+	// it was read, before any decryption.
+	Size     int64
+	Checksum string
+
+	// Encrypted is whether the archive was encrypted.
+	Encrypted bool
+
+	// ChecksumVerified is whether the archive's checksum was checked
+	// against an expected checksum. An archive's own metadata cannot
+	// record the checksum of the archive holding it, so without
+	// expected metadata the checksum is not verified.
+	ChecksumVerified bool
+
+	// Databases holds the names of the databases in the dump.
+	Databases []string
+
+	// Collections is the number of collections in the dump.
+	Collections int
+
+	// Files is the number of files in the files bundle.
+	Files int
+
+	// Models holds the models in the dumped juju database.
+	Models []VerifiedModel
+}
+
+// VerifyArchive reads the whole backup archive from r and checks that
+// it can be restored, without unpacking it or touching any controller.
+// It checks that the archive's size and checksum match those of
+// expected, if given, or otherwise those recorded in the archive's own
+// metadata; that the compressed archive and the files bundle in it are
+// intact; and that the database dump holds the juju database, with
+// every collection in it holding well formed BSON documents. If the
+// archive is encrypted, key must hold what is needed to
+// decrypt it.
+func VerifyArchive(r io.Reader, expected *Metadata, key *DecryptionKey) (*VerifyResult, error) {
+	// The checksum is of the archive as stored, which for encrypted
+	// archives is the encrypted file.
+	hasher := sha1.New()
+	counter := &countingWriter{}
+	raw := io.TeeReader(r, io.MultiWriter(hasher, counter))
+
+	encrypted, plain, err := IsEncryptedArchive(raw)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if encrypted {
+		if key == nil {
+			return nil, errors.New("backup archive is encrypted: a decryption key is required")
+		}
+		if plain, err = DecryptArchive(plain, *key); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	result := &VerifyResult{Encrypted: encrypted}
+	v := newArchiveVerifier(result)
+	gzr, err := gzip.NewReader(plain)
+	if err != nil {
+		return nil, errors.Annotate(err, "while uncompressing archive")
+	}
+	if err := v.readArchive(tar.NewReader(gzr)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Read what follows the end of the tar file, so that the gzip
+	// checksum, any decryption integrity check, and our own checksum
+	// all cover the whole archive.
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return nil, errors.Annotate(err, "while uncompressing archive")
+	}
+	if _, err := io.Copy(ioutil.Discard, plain); err != nil {
+		return nil, errors.Annotate(err, "while reading archive")
+	}
+	if _, err := io.Copy(ioutil.Discard, raw); err != nil {
+		return nil, errors.Annotate(err, "while reading archive")
+	}
+	result.Size = counter.n
+	result.Checksum = base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+
+	if err := v.finish(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if expected == nil {
+		expected = result.Metadata
+	}
+	if expected != nil {
+		if err := checkArchiveFileInfo(result, expected); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.ChecksumVerified = expected.Checksum() != ""
+	}
+	return result, nil
+}
+
+// checkArchiveFileInfo returns an error if the size or checksum of the
+// verified archive do not match those of the expected metadata. Either
+// may be missing from the metadata, in which case it isn't checked.
+func checkArchiveFileInfo(result *VerifyResult, expected *Metadata) error {
+	if size := expected.Size(); size != 0 && size != result.Size {
+		return errors.Errorf("backup archive size %d does not match expected size %d", result.Size, size)
+	}
+	if checksum := expected.Checksum(); checksum != "" && checksum != result.Checksum {
+		return errors.Errorf("backup archive checksum %q does not match expected checksum %q", result.Checksum, checksum)
+	}
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	w.n += int64(len(data))
+	return len(data), nil
+}
+
+// archiveVerifier checks the entries of a backup archive as they are
+// read, and gathers what it finds into a VerifyResult.
+type archiveVerifier struct {
+	paths  ArchivePaths
+	result *VerifyResult
+
+	foundFilesBundle bool
+	databases        set.Strings
+	models           map[string]*VerifiedModel
+	agentVersions    map[string]string
+}
+
+func newArchiveVerifier(result *VerifyResult) *archiveVerifier {
+	return &archiveVerifier{
+		paths:         NewCanonicalArchivePaths(),
+		result:        result,
+		databases:     set.NewStrings(),
+		models:        make(map[string]*VerifiedModel),
+		agentVersions: make(map[string]string),
+	}
+}
+
+func (v *archiveVerifier) readArchive(tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Annotate(err, "while reading archive")
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		if err := v.readFile(name, tr); err != nil {
+			return errors.Annotatef(err, "while reading %q", name)
+		}
+	}
+}
+
+func (v *archiveVerifier) readFile(name string, r io.Reader) error {
+	switch {
+	case name == v.paths.MetadataFile:
+		meta, err := NewMetadataJSONReader(r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		v.result.Metadata = meta
+	case name == v.paths.FilesBundle:
+		v.foundFilesBundle = true
+		return errors.Trace(v.readFilesBundle(tar.NewReader(r)))
+	case strings.HasPrefix(name, v.paths.DBDumpDir+"/"):
+		return errors.Trace(v.readDumpFile(strings.TrimPrefix(name, v.paths.DBDumpDir+"/"), r))
+	}
+	return nil
+}
+
+func (v *archiveVerifier) readFilesBundle(tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			v.result.Files++
+		}
+	}
+}
+
+// readDumpFile checks a file written by mongodump, named relative
+// to the dump directory.
+func (v *archiveVerifier) readDumpFile(name string, r io.Reader) error {
+	dbName, filename := path.Split(name)
+	dbName = strings.TrimSuffix(dbName, "/")
+	switch {
+	case strings.HasSuffix(filename, ".metadata.json"):
+		var metadata map[string]interface{}
+		return errors.Annotate(json.NewDecoder(r).Decode(&metadata), "invalid collection metadata")
+	case !strings.HasSuffix(filename, ".bson"):
+		return nil
+	case dbName == "":
+		// The oplog is dumped at the top of the dump directory.
+		return errors.Trace(readBSONDocuments(r, nil))
+	}
+	v.databases.Add(dbName)
+	v.result.Collections++
+
+	var handle func([]byte) error
+	if dbName == "juju" {
+		switch filename {
+		case modelsCollection + ".bson":
+			handle = v.readModel
+		case settingsCollection + ".bson":
+			handle = v.readSettings
+		}
+	}
+	return errors.Trace(readBSONDocuments(r, handle))
+}
+
+func (v *archiveVerifier) readModel(data []byte) error {
+	var doc struct {
+		UUID  string `bson:"_id"`
+		Name  string `bson:"name"`
+		Owner string `bson:"owner"`
+	}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return errors.Trace(err)
+	}
+	v.models[doc.UUID] = &VerifiedModel{
+		UUID:  doc.UUID,
+		Name:  doc.Name,
+		Owner: doc.Owner,
+	}
+	return nil
+}
+
+func (v *archiveVerifier) readSettings(data []byte) error {
+	var doc struct {
+		DocID     string                 `bson:"_id"`
+		ModelUUID string                 `bson:"model-uuid"`
+		Settings  map[string]interface{} `bson:"settings"`
+	}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return errors.Trace(err)
+	}
+	if doc.DocID != doc.ModelUUID+":"+modelSettingsKey {
+		return nil
+	}
+	if agentVersion, ok := doc.Settings["agent-version"].(string); ok {
+		v.agentVersions[doc.ModelUUID] = agentVersion
+	}
+	return nil
+}
+
+// finish checks that everything needed to restore the archive was
+// found in it, and completes the result.
+func (v *archiveVerifier) finish() error {
+	if !v.foundFilesBundle {
+		return errors.Errorf("backup archive has no files bundle %q", v.paths.FilesBundle)
+	}
+	if !v.databases.Contains("juju") {
+		return errors.Errorf("backup archive has no juju database dump in %q", v.paths.DBDumpDir)
+	}
+	if v.result.Metadata != nil {
+		v.result.Version = v.result.Metadata.Origin.Version
+	} else {
+		v.result.Version = legacyVersion
+	}
+	v.result.Databases = v.databases.SortedValues()
+	for uuid, model := range v.models {
+		model.AgentVersion = v.agentVersions[uuid]
+		v.result.Models = append(v.result.Models, *model)
+	}
+	sort.Slice(v.result.Models, func(i, j int) bool {
+		a, b := v.result.Models[i], v.result.Models[j]
+		if a.Owner != b.Owner {
+			return a.Owner < b.Owner
+		}
+		return a.Name < b.Name
+	})
+	return nil
+}
+
+// readBSONDocuments reads the BSON documents in a collection dumped by
+// mongodump, checking that each of them is well formed. If handle is
+// not nil, it is called with each document.
+func readBSONDocuments(r io.Reader, handle func([]byte) error) error {
+	var header [4]byte
+	for n := 0; ; n++ {
+		if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Annotatef(err, "document %d truncated", n)
+		}
+		size := binary.LittleEndian.Uint32(header[:])
+		if size < 5 || size > maxBSONDocumentSize {
+			return errors.Errorf("document %d has invalid size %d", n, size)
+		}
+		data := make([]byte, size)
+		copy(data, header[:])
+		if _, err := io.ReadFull(r, data[len(header):]); err != nil {
+			return errors.Annotatef(err, "document %d truncated", n)
+		}
+		var doc bson.M
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return errors.Annotatef(err, "document %d invalid", n)
+		}
+		if handle != nil {
+			if err := handle(data); err != nil {
+				return errors.Annotatef(err, "document %d", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func bsonDocs(c *gc.C, docs ...interface{}) string {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *verifySuite) dump(c *gc.C) []bt.File {
+	return []bt.File{{
+		Name: "juju/models.bson",
+		Content: bsonDocs(c,
+			bson.M{"_id": "deadbeef-0bad-400d-8000-4b1d0d06f00d", "name": "controller", "owner": "admin"},
+			bson.M{"_id": "deadbeef-0bad-400d-8000-4b1d0d06f001", "name": "prod", "owner": "bob"},
+		),
+	}, {
+		Name:    "juju/models.metadata.json",
+		Content: `{"options": {}, "indexes": []}`,
+	}, {
+		Name: "juju/settings.bson",
+		Content: bsonDocs(c,
+			bson.M{
+				"_id":        "deadbeef-0bad-400d-8000-4b1d0d06f00d:e",
+				"model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				"settings":   bson.M{"agent-version": "2.4.1"},
+			},
+			bson.M{
+				"_id":        "deadbeef-0bad-400d-8000-4b1d0d06f001:e",
+				"model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f001",
+				"settings":   bson.M{"agent-version": "2.4.0"},
+			},
+			bson.M{
+				"_id":        "deadbeef-0bad-400d-8000-4b1d0d06f001:a#mysql",
+				"model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f001",
+				"settings":   bson.M{"agent-version": "not a model"},
+			},
+		),
+	}, {
+		Name:    "admin/system.users.bson",
+		Content: bsonDocs(c, bson.M{"_id": "admin.machine-0"}),
+	}, {
+		Name:    "oplog.bson",
+		Content: bsonDocs(c, bson.M{"ts": 1}),
+	}}
+}
+
+func (s *verifySuite) archive(c *gc.C, meta *backups.Metadata, dump []bt.File) []byte {
+	files := []bt.File{{
+		Name:    "var/lib/juju/system-identity",
+		Content: "<an ssh key goes here>",
+	}}
+	archive, err := bt.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive.Bytes()
+}
+
+func sha1Checksum(data []byte) string {
+	sum := sha1.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *verifySuite) TestVerifyArchive(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.archive(c, meta, s.dump(c))
+
+	result, err := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Metadata.Origin.Model, gc.Equals, meta.Origin.Model)
+	c.Check(result.Version, gc.Equals, meta.Origin.Version)
+	c.Check(result.Size, gc.Equals, int64(len(archive)))
+	c.Check(result.Checksum, gc.Equals, sha1Checksum(archive))
+	c.Check(result.ChecksumVerified, jc.IsFalse)
+	c.Check(result.Encrypted, jc.IsFalse)
+	c.Check(result.Databases, jc.DeepEquals, []string{"admin", "juju"})
+	c.Check(result.Collections, gc.Equals, 3)
+	c.Check(result.Files, gc.Equals, 1)
+	c.Check(result.Models, jc.DeepEquals, []backups.VerifiedModel{{
+		UUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:         "controller",
+		Owner:        "admin",
+		AgentVersion: "2.4.1",
+	}, {
+		UUID:         "deadbeef-0bad-400d-8000-4b1d0d06f001",
+		Name:         "prod",
+		Owner:        "bob",
+		AgentVersion: "2.4.0",
+	}})
+}
+
+func (s *verifySuite) TestVerifyArchiveExpectedMetadata(c *gc.C) {
+	archive := s.archive(c, bt.NewMetadataStarted(), s.dump(c))
+	expected := bt.NewMetadataStarted()
+	err := expected.MarkComplete(int64(len(archive)), sha1Checksum(archive))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := backups.VerifyArchive(bytes.NewReader(archive), expected, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ChecksumVerified, jc.IsTrue)
+}
+
+func (s *verifySuite) TestVerifyArchiveChecksumMismatch(c *gc.C) {
+	archive := s.archive(c, bt.NewMetadataStarted(), s.dump(c))
+	expected := bt.NewMetadataStarted()
+	err := expected.MarkComplete(int64(len(archive)), "bogus")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.VerifyArchive(bytes.NewReader(archive), expected, nil)
+	c.Assert(err, gc.ErrorMatches, `backup archive checksum ".*" does not match expected checksum "bogus"`)
+}
+
+func (s *verifySuite) TestVerifyArchiveTruncated(c *gc.C) {
+	archive := s.archive(c, bt.NewMetadataStarted(), s.dump(c))
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive[:len(archive)-20]), nil, nil)
+	c.Assert(err, gc.ErrorMatches, `while .*: unexpected EOF`)
+}
+
+func (s *verifySuite) TestVerifyArchiveInvalidBSON(c *gc.C) {
+	dump := append(s.dump(c), bt.File{
+		Name:    "juju/machines.bson",
+		Content: "<BSON data goes here>",
+	})
+	archive := s.archive(c, bt.NewMetadataStarted(), dump)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Assert(err, gc.ErrorMatches, `while reading "juju-backup/dump/juju/machines.bson": document 0 has invalid size .*`)
+}
+
+func (s *verifySuite) TestVerifyArchiveNoJujuDatabase(c *gc.C) {
+	archive := s.archive(c, bt.NewMetadataStarted(), nil)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Assert(err, gc.ErrorMatches, `backup archive has no juju database dump in "juju-backup/dump"`)
+}
+
+func (s *verifySuite) TestVerifyArchiveEncrypted(c *gc.C) {
+	archive := s.archive(c, bt.NewMetadataStarted(), s.dump(c))
+	var buf bytes.Buffer
+	w, err := backups.EncryptArchive(&buf, backups.EncryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	encrypted := buf.Bytes()
+
+	_, err = backups.VerifyArchive(bytes.NewReader(encrypted), nil, nil)
+	c.Assert(err, gc.ErrorMatches, "backup archive is encrypted: a decryption key is required")
+
+	result, err := backups.VerifyArchive(bytes.NewReader(encrypted), nil, &backups.DecryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsTrue)
+	c.Check(result.Checksum, gc.Equals, sha1Checksum(encrypted))
+	c.Check(result.Models, gc.HasLen, 2)
+}
@@ -42,6 +42,12 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.CharmStoreURL,
 		controller.Features,
 		controller.MeteringURL,
+		controller.BackupInterval,
+		controller.BackupRetainDaily,
+		controller.BackupRetainWeekly,
+		controller.BackupStorage,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
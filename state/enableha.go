@@ -380,3 +380,186 @@ func (st *State) RemoveControllerMachine(m *Machine) error {
 	}
 	return nil
 }
+
+// DecommissionController starts removing the given machine from the
+// controller. The machine gives up its vote and becomes Dying; the
+// peergrouper then removes it from the mongo replica set and as a
+// controller, which drops it from the raft cluster, after which the
+// machine itself is removed.
+//
+// If the machine wants to vote and replace is true, another controller
+// machine is promoted to vote in its place; if there is none, a new
+// controller machine is added with the same series and constraints.
+// The number of voting controllers is thus maintained.
+//
+// If replace is false the controller shrinks instead. At least one
+// other controller machine must want to vote, and if that would leave
+// an even number of them, one is demoted so that the number of voters
+// stays odd.
+func (st *State) DecommissionController(m *Machine, replace bool) (ControllersChanges, error) {
+	var change ControllersChanges
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt != 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, errors.Errorf("machine %s is already being removed", m.Id())
+		}
+		if !m.IsManager() {
+			return nil, errors.Errorf("machine %s is not a controller", m.Id())
+		}
+		if len(m.doc.Principals) > 0 {
+			return nil, &HasAssignedUnitsError{
+				MachineId: m.doc.Id,
+				UnitNames: m.doc.Principals,
+			}
+		}
+		containers, err := m.Containers()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if len(containers) > 0 {
+			return nil, &HasContainersError{
+				MachineId:    m.doc.Id,
+				ContainerIds: containers,
+			}
+		}
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(info.MachineIds) <= 1 {
+			return nil, errors.Errorf("machine %s is the only controller machine", m.Id())
+		}
+
+		change = ControllersChanges{Removed: []string{m.doc.Id}}
+		ops := []txn.Op{{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Assert: append(bson.D{
+				{"jobs", JobManageModel},
+				{"$or", []bson.D{
+					{{"principals", bson.D{{"$size", 0}}}},
+					{{"principals", bson.D{{"$exists", false}}}},
+				}},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"life", Dying}, {"novote", true}}}},
+		}, {
+			C:  containerRefsC,
+			Id: m.doc.DocID,
+			Assert: bson.D{{"$or", []bson.D{
+				{{"children", bson.D{{"$size", 0}}}},
+				{{"children", bson.D{{"$exists", false}}}},
+			}}},
+		}, newCleanupOp(cleanupDyingMachine, m.doc.Id)}
+		assertControllersOp := txn.Op{
+			C:      controllersC,
+			Id:     modelGlobalKey,
+			Assert: bson.D{{"machineids", info.MachineIds}},
+		}
+		if !m.WantsVote() {
+			return append(ops, assertControllersOp), nil
+		}
+		if !replace {
+			voterOps, demoted, err := st.remainingVoterOps(info, m)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			change.Demoted = demoted
+			ops = append(ops, voterOps...)
+			return append(ops, assertControllersOp), nil
+		}
+
+		replacement, err := st.replacementController(info, m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if replacement != nil {
+			change.Promoted = []string{replacement.doc.Id}
+			ops = append(ops, promoteControllerOps(replacement)...)
+			return append(ops, assertControllersOp), nil
+		}
+		cons, err := m.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		mdoc, addOps, err := st.addMachineOps(MachineTemplate{
+			Series:      m.Series(),
+			Jobs:        []MachineJob{JobHostUnits, JobManageModel},
+			Constraints: cons,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ssOps, err := st.maintainControllersOps([]*machineDoc{mdoc}, info)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot prepare machine add operations")
+		}
+		change.Added = []string{mdoc.Id}
+		ops = append(ops, addOps...)
+		return append(ops, ssOps...), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return ControllersChanges{}, errors.Annotatef(err, "cannot decommission controller machine %s", m.Id())
+	}
+	return change, nil
+}
+
+// remainingVoterOps returns operations that assert the controller
+// machines other than the one given that want to vote still do, and
+// that demote one of them if there would otherwise be an even number
+// of voters. It also returns the ID of any machine demoted.
+func (st *State) remainingVoterOps(info *ControllerInfo, m *Machine) ([]txn.Op, []string, error) {
+	var voters []*Machine
+	for _, id := range info.MachineIds {
+		if id == m.Id() {
+			continue
+		}
+		voter, err := st.Machine(id)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if voter.Life() == Alive && voter.WantsVote() {
+			voters = append(voters, voter)
+		}
+	}
+	if len(voters) == 0 {
+		return nil, nil, errors.Errorf("machine %s is the only voting controller machine", m.Id())
+	}
+	var demoted []string
+	ops := make([]txn.Op, len(voters))
+	for i, voter := range voters {
+		ops[i] = txn.Op{
+			C:      machinesC,
+			Id:     voter.doc.DocID,
+			Assert: append(bson.D{{"novote", false}}, isAliveDoc...),
+		}
+	}
+	if len(voters)%2 == 0 {
+		last := len(voters) - 1
+		demoted = []string{voters[last].doc.Id}
+		ops[last].Update = bson.D{{"$set", bson.D{{"novote", true}}}}
+	}
+	return ops, demoted, nil
+}
+
+// replacementController returns an alive controller machine, other
+// than the one given, that doesn't want to vote and so can be
+// promoted in its place. It returns nil if there is none.
+func (st *State) replacementController(info *ControllerInfo, m *Machine) (*Machine, error) {
+	for _, id := range info.MachineIds {
+		if id == m.Id() {
+			continue
+		}
+		candidate, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if candidate.Life() == Alive && !candidate.WantsVote() {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type EnableHASuite struct {
@@ -516,3 +517,177 @@ func (s *EnableHASuite) TestRemoveControllerMachineRace(c *gc.C) {
 	c.Check(m0.HasVote(), jc.IsFalse)
 	c.Check(m0.Jobs(), gc.DeepEquals, []state.MachineJob{state.JobHostUnits, state.JobManageModel})
 }
+
+func (s *EnableHASuite) TestDecommissionControllerAddsReplacement(c *gc.C) {
+	cons := constraints.MustParse("mem=8G")
+	changes, err := s.State.EnableHA(3, cons, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err = s.State.DecommissionController(m1, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed: []string{"1"},
+		Added:   []string{"3"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "2", "3"}, nil)
+
+	c.Assert(m1.Refresh(), jc.ErrorIsNil)
+	c.Check(m1.Life(), gc.Equals, state.Dying)
+	c.Check(m1.WantsVote(), jc.IsFalse)
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m3.Series(), gc.Equals, "quantal")
+	c.Check(m3.IsManager(), jc.IsTrue)
+	gotCons, err := m3.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(gotCons, gc.DeepEquals, cons)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerPromotesNonVoter(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m2, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m2.SetWantsVote(false), jc.ErrorIsNil)
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err = s.State.DecommissionController(m0, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed:  []string{"0"},
+		Promoted: []string{"2"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"1", "2"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerNonVoter(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m2, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m2.SetWantsVote(false), jc.ErrorIsNil)
+
+	changes, err = s.State.DecommissionController(m2, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed: []string{"2"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerWithoutReplacement(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Two voters would remain, so one is demoted.
+	changes, err = s.State.DecommissionController(m1, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed: []string{"1"},
+		Demoted: []string{"2"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0"}, nil)
+
+	// The demoted machine can go too, leaving a single voter.
+	m2, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	changes, err = s.State.DecommissionController(m2, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed: []string{"2"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerWithoutReplacementKeepsOddVoters(c *gc.C) {
+	changes, err := s.State.EnableHA(5, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 5)
+	m4, err := s.State.Machine("4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m4.SetWantsVote(false), jc.ErrorIsNil)
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Three voters remain, so none is demoted, and the non-voter
+	// isn't promoted.
+	changes, err = s.State.DecommissionController(m0, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Removed: []string{"0"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3", "4"}, []string{"1", "2", "3"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerWithoutReplacementLastVoter(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	for _, id := range []string{"1", "2"} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(m.SetWantsVote(false), jc.ErrorIsNil)
+	}
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.DecommissionController(m0, false)
+	c.Check(err, gc.ErrorMatches, "cannot decommission controller machine 0: machine 0 is the only voting controller machine")
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerErrors(c *gc.C) {
+	m0, err := s.State.AddMachine("quantal", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.DecommissionController(m0, true)
+	c.Check(err, gc.ErrorMatches, "cannot decommission controller machine 0: machine 0 is the only controller machine")
+
+	m1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.DecommissionController(m1, true)
+	c.Check(err, gc.ErrorMatches, "cannot decommission controller machine 1: machine 1 is not a controller")
+}
+
+func (s *EnableHASuite) TestDecommissionControllerWithUnits(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: m1})
+
+	_, err = s.State.DecommissionController(m1, true)
+	c.Check(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot decommission controller machine 1: machine 1 has unit %q assigned`, unit.Name()))
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, nil)
+}
+
+func (s *EnableHASuite) TestDecommissionControllerThenRemove(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m0.SetHasVote(true), jc.ErrorIsNil)
+
+	_, err = s.State.DecommissionController(m0, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Pretend to be the peergrouper, removing the machine's vote and
+	// then removing it as a controller.
+	c.Assert(m0.Refresh(), jc.ErrorIsNil)
+	c.Assert(m0.SetHasVote(false), jc.ErrorIsNil)
+	c.Assert(s.State.RemoveControllerMachine(m0), jc.ErrorIsNil)
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+	c.Assert(m0.EnsureDead(), jc.ErrorIsNil)
+	s.assertControllerInfo(c, []string{"1", "2", "3"}, []string{"1", "2", "3"}, nil)
+}
@@ -45,6 +45,28 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	}
 }
 
+// LeadershipPinner returns a leadership.Pinner for applications in the
+// state's model.
+func (st *State) LeadershipPinner() leadership.Pinner {
+	return leadershipPinner{
+		lazyLeasePinner{func() (lease.Pinner, error) {
+			manager := st.workers.leadershipManager()
+			return manager.Pinner(applicationLeadershipNamespace, st.modelUUID())
+		}},
+	}
+}
+
+// LeadershipTransferrer returns a leadership.Transferrer for units and
+// applications in the state's model.
+func (st *State) LeadershipTransferrer() leadership.Transferrer {
+	return leadershipTransferrer{
+		lazyLeaseTransferrer{func() (lease.Transferrer, error) {
+			manager := st.workers.leadershipManager()
+			return manager.Transferrer(applicationLeadershipNamespace, st.modelUUID())
+		}},
+	}
+}
+
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
 // with checks and asserts on the supplied token.
 func buildTxnWithLeadership(buildTxn jujutxn.TransactionSource, token leadership.Token) jujutxn.TransactionSource {
@@ -116,3 +138,36 @@ func (m leadershipClaimer) BlockUntilLeadershipReleased(applicationname string,
 	}
 	return errors.Trace(err)
 }
+
+// leadershipPinner implements leadership.Pinner by wrapping a lease.Pinner.
+type leadershipPinner struct {
+	pinner lease.Pinner
+}
+
+// PinLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) PinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.Pin(applicationname, entity))
+}
+
+// UnpinLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) UnpinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.Unpin(applicationname, entity))
+}
+
+// leadershipTransferrer implements leadership.Transferrer by wrapping
+// a lease.Transferrer.
+type leadershipTransferrer struct {
+	transferrer lease.Transferrer
+}
+
+// TransferLeadership is part of the leadership.Transferrer interface.
+func (m leadershipTransferrer) TransferLeadership(applicationname, unitName string, duration time.Duration) error {
+	err := m.transferrer.Transfer(applicationname, unitName, duration)
+	switch errors.Cause(err) {
+	case lease.ErrPinned:
+		return leadership.ErrPinned
+	case lease.ErrClaimDenied:
+		return leadership.ErrClaimDenied
+	}
+	return errors.Trace(err)
+}
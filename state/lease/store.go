@@ -65,25 +65,60 @@ func (store *store) Leases() map[lease.Key]lease.Info {
 	store.mu.Lock()
 	defer store.mu.Unlock()
 	localTime := store.config.LocalClock.Now()
-	leases := make(map[lease.Key]lease.Info)
+	leases := make(map[lease.Key]lease.Info, len(store.entries))
 	for name, entry := range store.entries {
-		globalExpiry := entry.start.Add(entry.duration)
-		remaining := globalExpiry.Sub(store.globalTime)
-		localExpiry := localTime.Add(remaining)
-		key := lease.Key{
-			Namespace: store.config.Namespace,
-			ModelUUID: store.config.ModelUUID,
-			Lease:     name,
-		}
-		leases[key] = lease.Info{
-			Holder:   entry.holder,
-			Expiry:   localExpiry,
-			Trapdoor: store.assertOpTrapdoor(name, entry.holder),
-		}
+		leases[store.key(name)] = store.info(name, entry, localTime)
 	}
 	return leases
 }
 
+// LeaseGroup is part of the lease.Store interface. A store only holds
+// the leases for its own namespace and model, so this returns either
+// all of them or none.
+func (store *store) LeaseGroup(namespace, modelUUID string) map[lease.Key]lease.Info {
+	if namespace != store.config.Namespace || modelUUID != store.config.ModelUUID {
+		return make(map[lease.Key]lease.Info)
+	}
+	return store.Leases()
+}
+
+// Lease is part of the lease.Store interface.
+func (store *store) Lease(key lease.Key) (lease.Info, bool) {
+	if key != store.key(key.Lease) {
+		return lease.Info{}, false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	entry, found := store.entries[key.Lease]
+	if !found {
+		return lease.Info{}, false
+	}
+	return store.info(key.Lease, entry, store.config.LocalClock.Now()), true
+}
+
+// key returns the key of the named lease in the store's namespace and
+// model.
+func (store *store) key(name string) lease.Key {
+	return lease.Key{
+		Namespace: store.config.Namespace,
+		ModelUUID: store.config.ModelUUID,
+		Lease:     name,
+	}
+}
+
+// info returns the information about the named lease, with its expiry
+// time converted from global time to the supplied local time. The
+// caller must hold store.mu.
+func (store *store) info(name string, entry entry, localTime time.Time) lease.Info {
+	globalExpiry := entry.start.Add(entry.duration)
+	remaining := globalExpiry.Sub(store.globalTime)
+	return lease.Info{
+		Holder:   entry.holder,
+		Expiry:   localTime.Add(remaining),
+		Trapdoor: store.assertOpTrapdoor(name, entry.holder),
+	}
+}
+
 // ClaimLease is part of the lease.Store interface.
 func (store *store) ClaimLease(key lease.Key, request lease.Request) error {
 	return store.request(key.Lease, request, store.claimLeaseOps, "claiming")
@@ -188,6 +223,29 @@ func (store *store) ExpireLease(key lease.Key) error {
 	return nil
 }
 
+// TransferLease is part of the Store interface. Leases can only be
+// transferred when they're stored in raft.
+func (store *store) TransferLease(key lease.Key, holder string, request lease.Request) error {
+	return errors.NotImplementedf("lease transfer")
+}
+
+// PinLease is part of the Store interface. Leases can only be pinned
+// when they're stored in raft.
+func (store *store) PinLease(key lease.Key, entity string) error {
+	return errors.NotImplementedf("lease pinning")
+}
+
+// UnpinLease is part of the Store interface.
+func (store *store) UnpinLease(key lease.Key, entity string) error {
+	return errors.NotImplementedf("lease unpinning")
+}
+
+// Pinned is part of the Store interface. No leases are ever pinned
+// in this store.
+func (store *store) Pinned() map[lease.Key][]string {
+	return make(map[lease.Key][]string)
+}
+
 // Refresh is part of the Store interface.
 func (store *store) Refresh() error {
 	store.mu.Lock()
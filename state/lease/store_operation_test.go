@@ -6,6 +6,7 @@ package lease_test
 import (
 	"time" // Only used for time types.
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -170,3 +171,22 @@ func (s *StoreOperationSuite) TestCannotExpireUnheldLease(c *gc.C) {
 	err := fix.Store.ExpireLease(key("name"))
 	c.Assert(err, gc.Equals, lease.ErrInvalid)
 }
+
+func (s *StoreOperationSuite) TestTransferNotImplemented(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Store.ClaimLease(key("name"), lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fix.Store.TransferLease(key("name"), "holder", lease.Request{"other-holder", time.Minute})
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Check(key("name"), fix.Holder(), "holder")
+}
+
+func (s *StoreOperationSuite) TestPinningNotImplemented(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Store.PinLease(key("name"), "user-admin")
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	err = fix.Store.UnpinLease(key("name"), "user-admin")
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Check(fix.Store.Pinned(), gc.HasLen, 0)
+}
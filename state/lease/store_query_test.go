@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"fmt"
+	"time" // Only used for time types.
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// StoreQuerySuite verifies the targeted lease queries.
+type StoreQuerySuite struct {
+	FixtureSuite
+}
+
+var _ = gc.Suite(&StoreQuerySuite{})
+
+func (s *StoreQuerySuite) TestLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Store.ClaimLease(key("name"), lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, found := fix.Store.Lease(key("name"))
+	c.Assert(found, jc.IsTrue)
+	c.Check(info.Holder, gc.Equals, "holder")
+	c.Check(info.Expiry, gc.Equals, fix.Zero.Add(time.Minute))
+	c.Check(info.Trapdoor, gc.NotNil)
+}
+
+func (s *StoreQuerySuite) TestLeaseNotHeld(c *gc.C) {
+	fix := s.EasyFixture(c)
+	_, found := fix.Store.Lease(key("name"))
+	c.Check(found, jc.IsFalse)
+}
+
+func (s *StoreQuerySuite) TestLeaseOtherNamespaceOrModel(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Store.ClaimLease(key("name"), lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherNamespace := key("name")
+	otherNamespace.Namespace = "other-namespace"
+	_, found := fix.Store.Lease(otherNamespace)
+	c.Check(found, jc.IsFalse)
+
+	otherModel := key("name")
+	otherModel.ModelUUID = "other-model-uuid"
+	_, found = fix.Store.Lease(otherModel)
+	c.Check(found, jc.IsFalse)
+}
+
+func (s *StoreQuerySuite) TestLeaseGroup(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Store.ClaimLease(key("name"), lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Store.ClaimLease(key("other"), lease.Request{"grasper", time.Hour})
+	c.Assert(err, jc.ErrorIsNil)
+
+	leases := fix.Store.LeaseGroup("default-namespace", "model-uuid")
+	c.Assert(leases, gc.HasLen, 2)
+	c.Check(leases[key("name")].Holder, gc.Equals, "holder")
+	c.Check(leases[key("other")].Holder, gc.Equals, "grasper")
+
+	c.Check(fix.Store.LeaseGroup("other-namespace", "model-uuid"), gc.HasLen, 0)
+	c.Check(fix.Store.LeaseGroup("default-namespace", "other-model-uuid"), gc.HasLen, 0)
+}
+
+const benchLeases = 1000
+
+func (s *StoreQuerySuite) benchFixture(c *gc.C) *Fixture {
+	fix := s.EasyFixture(c)
+	for i := 0; i < benchLeases; i++ {
+		name := fmt.Sprintf("app-%d", i)
+		err := fix.Store.ClaimLease(key(name), lease.Request{name + "-0", time.Minute})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return fix
+}
+
+func (s *StoreQuerySuite) BenchmarkLeasesLookup(c *gc.C) {
+	fix := s.benchFixture(c)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		if _, found := fix.Store.Leases()[key("app-7")]; !found {
+			c.Fatalf("lease not found")
+		}
+	}
+}
+
+func (s *StoreQuerySuite) BenchmarkLease(c *gc.C) {
+	fix := s.benchFixture(c)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		if _, found := fix.Store.Lease(key("app-7")); !found {
+			c.Fatalf("lease not found")
+		}
+	}
+}
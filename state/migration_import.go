@@ -5,8 +5,6 @@ package state
 
 import (
 	"encoding/hex"
-	"fmt"
-	"reflect"
 	"time"
 
 	"github.com/juju/description"
@@ -41,7 +39,41 @@ import (
 var initialLeaderClaimTime = time.Minute
 
 // Import the database agnostic model representation into the database.
-func (st *State) Import(model description.Model) (_ *Model, _ *State, err error) {
+func (st *State) Import(model description.Model) (*Model, *State, error) {
+	return st.importModel(model, nil)
+}
+
+// RebuildArgs describes where a model imported with ImportRebuild is
+// rebuilt.
+type RebuildArgs struct {
+	// Cloud is the cloud on which the model's machines are
+	// recreated. It may be empty, meaning the controller's cloud.
+	Cloud string
+
+	// CloudRegion is the region of the cloud in which the model's
+	// machines are recreated.
+	CloudRegion string
+
+	// CloudCredential is the credential used to recreate the model's
+	// machines. It must belong to the model owner. It may be empty
+	// if the cloud supports the "empty" auth type.
+	CloudCredential names.CloudCredentialTag
+}
+
+// ImportRebuild imports the model representation into the database
+// so that the model is rebuilt on the given cloud, rather than
+// taking over the model's existing machines. The applications, units,
+// relations, config, leadership and resources are imported as usual,
+// but none of the machines' cloud instances are: each machine is
+// left to be provisioned afresh, and each unit to be deployed again
+// once its machine exists. Provider specific networking details,
+// such as subnets and link layer devices, are not imported. Models
+// with storage cannot be rebuilt.
+func (st *State) ImportRebuild(model description.Model, args RebuildArgs) (*Model, *State, error) {
+	return st.importModel(model, &args)
+}
+
+func (st *State) importModel(model description.Model, rebuild *RebuildArgs) (_ *Model, _ *State, err error) {
 	modelUUID := model.Tag().Id()
 	logger := loggo.GetLogger("juju.state.import-model")
 	logger.Debugf("import starting for model %s", modelUUID)
@@ -55,11 +87,14 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		return nil, nil, errors.AlreadyExistsf("model %s", modelUUID)
 	}
 
-	if len(model.RemoteApplications()) != 0 {
-		// Cross-model relations are currently limited to models on
-		// the same controller, while migration is for getting the
-		// model to a new controller.
-		return nil, nil, errors.New("can't import models with remote applications")
+	// Make the same checks as CheckImport, so that a model that
+	// passes them is not refused here.
+	problems, err := checkImport(st, model, rebuild != nil)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(problems) > 0 {
+		return nil, nil, problems[0]
 	}
 
 	// Unfortunately a version was released that exports v4 models
@@ -87,19 +122,11 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		EnvironVersion:          model.EnvironVersion(),
 		StorageProviderRegistry: storage.StaticProviderRegistry{},
 	}
-	if creds := model.CloudCredential(); creds != nil {
-		// Need to add credential or make sure an existing credential
-		// matches.
-		// TODO: there really should be a way to create a cloud credential
-		// tag in the names package from the cloud, owner and name.
-		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
-		if !names.IsValidCloudCredential(credID) {
-			return nil, nil, errors.Errorf("model credentials id not valid: %q", credID)
-		}
-		credTag := names.NewCloudCredentialTag(credID)
-
-		existingCreds, err := st.CloudCredential(credTag)
-
+	if creds := model.CloudCredential(); creds != nil && rebuild == nil {
+		// Need to add the credential if it's missing; checkImport
+		// has made sure an existing credential matches.
+		credTag := names.NewCloudCredentialTag(importCredentialID(creds))
+		_, err := st.CloudCredential(credTag)
 		if errors.IsNotFound(err) {
 			credential := cloud.NewCredential(
 				cloud.AuthType(creds.AuthType()),
@@ -109,21 +136,15 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 			}
 		} else if err != nil {
 			return nil, nil, errors.Trace(err)
-		} else {
-			// ensure existing creds match
-			if existingCreds.AuthType != creds.AuthType() {
-				return nil, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
-			}
-			if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
-				return nil, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
-			}
-			if existingCreds.Revoked {
-				return nil, nil, errors.Errorf("credential %q is revoked", credID)
-			}
 		}
 
 		args.CloudCredential = credTag
 	}
+	if rebuild != nil {
+		if args, err = st.rebuildModelArgs(args, *rebuild); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 	dbModel, newSt, err := st.NewModel(args)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -150,6 +171,7 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		dbModel: dbModel,
 		model:   model,
 		logger:  logger,
+		rebuild: rebuild != nil,
 	}
 	if err := restore.sequences(); err != nil {
 		return nil, nil, errors.Annotate(err, "sequences")
@@ -162,11 +184,14 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := newSt.SetModelConstraints(restore.constraints(model.Constraints())); err != nil {
 		return nil, nil, errors.Annotate(err, "model constraints")
 	}
-	if err := restore.sshHostKeys(); err != nil {
-		return nil, nil, errors.Annotate(err, "sshHostKeys")
-	}
-	if err := restore.cloudimagemetadata(); err != nil {
-		return nil, nil, errors.Annotate(err, "cloudimagemetadata")
+	if !restore.rebuild {
+		// Host keys and image metadata belong to the source cloud.
+		if err := restore.sshHostKeys(); err != nil {
+			return nil, nil, errors.Annotate(err, "sshHostKeys")
+		}
+		if err := restore.cloudimagemetadata(); err != nil {
+			return nil, nil, errors.Annotate(err, "cloudimagemetadata")
+		}
 	}
 	if err := restore.actions(); err != nil {
 		return nil, nil, errors.Annotate(err, "actions")
@@ -187,14 +212,16 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.spaces(); err != nil {
 		return nil, nil, errors.Annotate(err, "spaces")
 	}
-	if err := restore.linklayerdevices(); err != nil {
-		return nil, nil, errors.Annotate(err, "linklayerdevices")
-	}
-	if err := restore.subnets(); err != nil {
-		return nil, nil, errors.Annotate(err, "subnets")
-	}
-	if err := restore.ipaddresses(); err != nil {
-		return nil, nil, errors.Annotate(err, "ipaddresses")
+	if !restore.rebuild {
+		if err := restore.linklayerdevices(); err != nil {
+			return nil, nil, errors.Annotate(err, "linklayerdevices")
+		}
+		if err := restore.subnets(); err != nil {
+			return nil, nil, errors.Annotate(err, "subnets")
+		}
+		if err := restore.ipaddresses(); err != nil {
+			return nil, nil, errors.Annotate(err, "ipaddresses")
+		}
 	}
 
 	if dbModel.Type() == ModelTypeIAAS {
@@ -228,11 +255,53 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	return dbModel, newSt, nil
 }
 
+// rebuildModelArgs returns the arguments for creating a model being
+// rebuilt on the given cloud, or the controller's cloud.
+func (st *State) rebuildModelArgs(args ModelArgs, rebuild RebuildArgs) (ModelArgs, error) {
+	cloudName := rebuild.Cloud
+	if cloudName == "" {
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return ModelArgs{}, errors.Trace(err)
+		}
+		cloudName = info.CloudName
+	}
+	rebuildCloud, err := st.Cloud(cloudName)
+	if err != nil {
+		return ModelArgs{}, errors.Trace(err)
+	}
+	if rebuild.CloudCredential != (names.CloudCredentialTag{}) {
+		cred, err := st.CloudCredential(rebuild.CloudCredential)
+		if err != nil {
+			return ModelArgs{}, errors.Trace(err)
+		}
+		if cred.Revoked {
+			return ModelArgs{}, errors.Errorf("credential %q is revoked", rebuild.CloudCredential.Id())
+		}
+	}
+	// The model config names the provider to use, which must now be
+	// that of the cloud the model is rebuilt on.
+	cfg, err := args.Config.Apply(map[string]interface{}{
+		config.TypeKey: rebuildCloud.Type,
+	})
+	if err != nil {
+		return ModelArgs{}, errors.Trace(err)
+	}
+	args.Config = cfg
+	args.CloudName = rebuildCloud.Name
+	args.CloudRegion = rebuild.CloudRegion
+	args.CloudCredential = rebuild.CloudCredential
+	return args, nil
+}
+
 type importer struct {
 	st      *State
 	dbModel *Model
 	model   description.Model
 	logger  loggo.Logger
+	// rebuild is true if the model's machines are to be provisioned
+	// afresh, rather than taken over from the source cloud.
+	rebuild bool
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to the units of that application.
 	applicationUnits map[string]map[string]*Unit
@@ -382,15 +451,31 @@ func (i *importer) machine(m description.Machine) error {
 		StatusData: mStatus.Data(),
 		Updated:    mStatus.Updated().UnixNano(),
 	}
-	// A machine isn't valid if it doesn't have an instance.
-	instance := m.Instance()
-	instStatus := instance.Status()
-	instanceStatusDoc := statusDoc{
-		ModelUUID:  i.st.ModelUUID(),
-		Status:     status.Status(instStatus.Value()),
-		StatusInfo: instStatus.Message(),
-		StatusData: instStatus.Data(),
-		Updated:    instStatus.Updated().UnixNano(),
+	var instanceStatusDoc statusDoc
+	if i.rebuild {
+		// The machine is provisioned afresh, as if it had just
+		// been added.
+		now := i.st.clock().Now().UnixNano()
+		machineStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.Pending,
+			Updated:   now,
+		}
+		instanceStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.Pending,
+			Updated:   now,
+		}
+	} else {
+		// A machine isn't valid if it doesn't have an instance.
+		instStatus := m.Instance().Status()
+		instanceStatusDoc = statusDoc{
+			ModelUUID:  i.st.ModelUUID(),
+			Status:     status.Status(instStatus.Value()),
+			StatusInfo: instStatus.Message(),
+			StatusData: instStatus.Data(),
+			Updated:    instStatus.Updated().UnixNano(),
+		}
 	}
 	cons := i.constraints(m.Constraints())
 	prereqOps, machineOp := i.st.baseNewMachineOps(
@@ -401,7 +486,9 @@ func (i *importer) machine(m description.Machine) error {
 	)
 
 	// 3. create op for adding in instance data
-	prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
+	if !i.rebuild {
+		prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, m.Instance()))
+	}
 
 	if parentId := ParentId(mdoc.Id); parentId != "" {
 		prereqOps = append(prereqOps,
@@ -432,11 +519,13 @@ func (i *importer) machine(m description.Machine) error {
 	if err := i.importStatusHistory(machine.globalKey(), m.StatusHistory()); err != nil {
 		return errors.Trace(err)
 	}
-	if err := i.importStatusHistory(machine.globalInstanceKey(), instance.StatusHistory()); err != nil {
-		return errors.Trace(err)
-	}
-	if err := i.importMachineBlockDevices(machine, m); err != nil {
-		return errors.Trace(err)
+	if !i.rebuild {
+		if err := i.importStatusHistory(machine.globalInstanceKey(), m.Instance().StatusHistory()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := i.importMachineBlockDevices(machine, m); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Now that this machine exists in the database, process each of the
@@ -552,6 +641,21 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		return nil, errors.Trace(err)
 	}
 	machineTag := m.Tag()
+	if i.rebuild {
+		// The machine has no instance yet, so nothing about the
+		// source cloud's instance or agent is kept.
+		return &machineDoc{
+			DocID:         i.st.docID(id),
+			Id:            id,
+			ModelUUID:     i.st.ModelUUID(),
+			Series:        m.Series(),
+			ContainerType: m.ContainerType(),
+			Life:          Alive,
+			Jobs:          jobs,
+			NoVote:        true,
+			Clean:         !i.machineHasUnits(machineTag),
+		}, nil
+	}
 	return &machineDoc{
 		DocID:                    i.st.docID(id),
 		Id:                       id,
@@ -924,8 +1028,24 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		return errors.NotValidf("missing workload status")
 	}
 	workloadStatusDoc := i.makeStatusDoc(workloadStatus)
+	if i.rebuild {
+		// The unit is deployed again once its machine is provisioned.
+		now := i.st.clock().Now().UnixNano()
+		agentStatusDoc = statusDoc{
+			Status:  status.Allocating,
+			Updated: now,
+		}
+		workloadStatusDoc = statusDoc{
+			Status:     status.Waiting,
+			StatusInfo: status.MessageWaitForMachine,
+			Updated:    now,
+		}
+	}
 
 	workloadVersion := u.WorkloadVersion()
+	if i.rebuild {
+		workloadVersion = ""
+	}
 	versionStatus := status.Active
 	if workloadVersion == "" {
 		versionStatus = status.Unknown
@@ -1094,6 +1214,20 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 		}
 	}
 
+	if i.rebuild {
+		// The unit has yet to be deployed, so its agent has not
+		// set a password, agent version or charm.
+		return &unitDoc{
+			Name:                   u.Name(),
+			Application:            s.Name(),
+			Series:                 s.Series(),
+			Principal:              u.Principal().Id(),
+			Subordinates:           subordinates,
+			StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+			MachineId:              u.Machine().Id(),
+			Life:                   Alive,
+		}, nil
+	}
 	return &unitDoc{
 		Name:                   u.Name(),
 		Application:            s.Name(),
@@ -1227,8 +1361,13 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 func (i *importer) spaces() error {
 	i.logger.Debugf("importing spaces")
 	for _, s := range i.model.Spaces() {
-		// The subnets are added after the spaces.
-		_, err := i.st.AddSpace(s.Name(), network.Id(s.ProviderID()), nil, s.Public())
+		// The subnets are added after the spaces. A rebuilt model's
+		// spaces are not yet known to its new cloud.
+		providerID := network.Id(s.ProviderID())
+		if i.rebuild {
+			providerID = ""
+		}
+		_, err := i.st.AddSpace(s.Name(), providerID, nil, s.Public())
 		if err != nil {
 			i.logger.Errorf("error importing space %s: %s", s.Name(), err)
 			return errors.Annotate(err, s.Name())
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/feature"
@@ -1658,6 +1659,48 @@ func (s *MigrationImportSuite) TestRemoteApplications(c *gc.C) {
 		defer newSt.Close()
 	}
 	c.Assert(err, gc.ErrorMatches, "can't import models with remote applications")
+
+	problems, err := state.CheckImport(s.State, in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Check(problems[0], gc.ErrorMatches, "can't import models with remote applications")
+}
+
+func (s *MigrationImportSuite) TestCheckImport(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+
+	problems, err := state.CheckImport(s.State, in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, gc.HasLen, 0)
+}
+
+func (s *MigrationImportSuite) TestCheckImportCredentialMismatch(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/existing")
+	err := s.State.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	in.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:      s.Owner,
+		Cloud:      names.NewCloudTag("dummy"),
+		Name:       "existing",
+		AuthType:   string(cloud.EmptyAuthType),
+		Attributes: map[string]string{"different": "value"},
+	})
+
+	problems, err := state.CheckImport(s.State, in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Check(problems[0], gc.ErrorMatches, `credential ".*" attributes differ on this controller`)
+
+	_, newSt, err := s.State.Import(in)
+	if err == nil {
+		defer newSt.Close()
+	}
+	c.Assert(err, gc.ErrorMatches, `credential ".*" attributes differ on this controller`)
 }
 
 func (s *MigrationImportSuite) TestApplicationsWithNilConfigValues(c *gc.C) {
@@ -1813,6 +1856,92 @@ func (s *MigrationImportSuite) TestImportingModelWithBlankType(c *gc.C) {
 	c.Assert(imported.Type(), gc.Equals, state.ModelTypeIAAS)
 }
 
+func (s *MigrationImportSuite) importRebuild(c *gc.C, args state.RebuildArgs) (*state.Model, *state.State, error) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	in := newModel(out, utils.MustNewUUID().String(), "rebuilt")
+	newModel, newSt, err := s.State.ImportRebuild(in, args)
+	if err == nil {
+		s.AddCleanup(func(c *gc.C) {
+			c.Check(newSt.Close(), jc.ErrorIsNil)
+		})
+	}
+	return newModel, newSt, err
+}
+
+func (s *MigrationImportSuite) TestImportRebuild(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	exported, pwd := s.Factory.MakeUnitReturningPassword(c, &factory.UnitParams{
+		Application: app,
+	})
+	err := app.UpdateLeaderSettings(&goodToken{}, map[string]string{
+		"leader": "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateCharmConfig(charm.Settings{"blog-title": "rebuilt"})
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := exported.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt, err := s.importRebuild(c, state.RebuildArgs{
+		CloudRegion: "nether-region",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newModel.Cloud(), gc.Equals, "dummy")
+	c.Check(newModel.CloudRegion(), gc.Equals, "nether-region")
+
+	// The machine is left to be provisioned again.
+	machine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Check(machine.Addresses(), gc.HasLen, 0)
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machineStatus.Status, gc.Equals, status.Pending)
+
+	// The unit is deployed again on the new machine.
+	unit, err := newSt.Unit(exported.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unit.PasswordValid(pwd), jc.IsFalse)
+	_, ok := unit.CharmURL()
+	c.Check(ok, jc.IsFalse)
+	agentStatus, err := unit.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(agentStatus.Status, gc.Equals, status.Allocating)
+	assigned, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(assigned, gc.Equals, machineId)
+
+	// The application's config and leader settings are kept.
+	imported, err := newSt.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := imported.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings["blog-title"], gc.Equals, "rebuilt")
+	leaderSettings, err := imported.LeaderSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leaderSettings, jc.DeepEquals, map[string]string{"leader": "true"})
+}
+
+func (s *MigrationImportSuite) TestImportRebuildBadRegion(c *gc.C) {
+	_, _, err := s.importRebuild(c, state.RebuildArgs{
+		CloudRegion: "nowhere",
+	})
+	c.Assert(err, gc.ErrorMatches, `region "nowhere" not found \(expected one of .*\)`)
+}
+
+func (s *MigrationImportSuite) TestImportRebuildWithStorage(c *gc.C) {
+	s.makeUnitWithStorage(c)
+	_, _, err := s.importRebuild(c, state.RebuildArgs{
+		CloudRegion: "dummy-region",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "rebuilding models with storage not supported")
+}
+
 // newModel replaces the uuid and name of the config attributes so we
 // can use all the other data to validate imports. An owner and name of the
 // model are unique together in a controller.
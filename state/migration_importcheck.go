@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/config"
+)
+
+// ImportCheckBackend defines the controller state needed to check
+// whether a model would be accepted by Import. *State implements it.
+type ImportCheckBackend interface {
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredential(tag names.CloudCredentialTag) (Credential, error)
+}
+
+// CheckImport reports every reason Import would refuse the described
+// model, without importing anything. Import makes the same checks, and
+// fails with the first problem found; a nil slice means the model is
+// expected to import cleanly. Whether a model with the same UUID is
+// already on the controller is not checked. An error is returned only
+// if the checks could not be made.
+func CheckImport(backend ImportCheckBackend, model description.Model) ([]error, error) {
+	return checkImport(backend, model, false)
+}
+
+// checkImport reports every reason the described model cannot be
+// imported, or rebuilt if rebuild is true. A rebuilt model does not
+// use its own cloud or credential, so they are not checked.
+func checkImport(backend ImportCheckBackend, model description.Model, rebuild bool) ([]error, error) {
+	var problems []error
+	if len(model.RemoteApplications()) != 0 {
+		// Cross-model relations are currently limited to models on
+		// the same controller, while migration is for getting the
+		// model to a new controller.
+		problems = append(problems, errors.New("can't import models with remote applications"))
+	}
+	// Unfortunately a version was released that exports v4 models
+	// with the Type field blank. Treat this as IAAS.
+	modelType := ModelTypeIAAS
+	if model.Type() != "" {
+		var err error
+		if modelType, err = ParseModelType(model.Type()); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if _, err := config.New(config.NoDefaults, model.Config()); err != nil {
+		problems = append(problems, errors.Annotate(err, "model config not valid"))
+	}
+
+	if rebuild {
+		if modelType != ModelTypeIAAS {
+			problems = append(problems, errors.NotSupportedf("rebuilding %s models", modelType))
+		}
+		if len(model.Storages()) != 0 || len(model.Volumes()) != 0 || len(model.Filesystems()) != 0 {
+			problems = append(problems, errors.NotSupportedf("rebuilding models with storage"))
+		}
+		return problems, nil
+	}
+
+	cloudProblems, err := checkImportCloud(backend, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems = append(problems, cloudProblems...)
+
+	credProblems, err := checkImportCredential(backend, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(problems, credProblems...), nil
+}
+
+func checkImportCloud(backend ImportCheckBackend, model description.Model) ([]error, error) {
+	modelCloud, err := backend.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		return []error{errors.Errorf("cloud %q not found on this controller", model.Cloud())}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.CloudRegion() == "" {
+		return nil, nil
+	}
+	if _, err := cloud.RegionByName(modelCloud.Regions, model.CloudRegion()); err != nil {
+		return []error{errors.Errorf("cloud %q has no region %q on this controller", model.Cloud(), model.CloudRegion())}, nil
+	}
+	return nil, nil
+}
+
+func checkImportCredential(backend ImportCheckBackend, model description.Model) ([]error, error) {
+	creds := model.CloudCredential()
+	if creds == nil {
+		return nil, nil
+	}
+	credID := importCredentialID(creds)
+	if !names.IsValidCloudCredential(credID) {
+		return []error{errors.Errorf("model credentials id not valid: %q", credID)}, nil
+	}
+	existing, err := backend.CloudCredential(names.NewCloudCredentialTag(credID))
+	if errors.IsNotFound(err) {
+		// The credential will be added by the import.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var problems []error
+	if existing.AuthType != creds.AuthType() {
+		problems = append(problems, errors.Errorf("credential auth type mismatch: %q != %q", existing.AuthType, creds.AuthType()))
+	}
+	if !reflect.DeepEqual(existing.Attributes, creds.Attributes()) {
+		problems = append(problems, errors.Errorf("credential %q attributes differ on this controller", credID))
+	}
+	if existing.Revoked {
+		problems = append(problems, errors.Errorf("credential %q is revoked", credID))
+	}
+	return problems, nil
+}
+
+// importCredentialID returns the ID of the described cloud credential.
+// TODO: there really should be a way to create a cloud credential
+// tag in the names package from the cloud, owner and name.
+func importCredentialID(creds description.CloudCredential) string {
+	return fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+}
@@ -439,7 +439,7 @@ func (st *State) ApplicationLeaders() (map[string]string, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	leases := store.Leases()
+	leases := store.LeaseGroup(lease.ApplicationLeadershipNamespace, st.ModelUUID())
 	result := make(map[string]string, len(leases))
 	for key, value := range leases {
 		result[key.Lease] = value.Holder
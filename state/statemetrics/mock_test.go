@@ -4,6 +4,7 @@
 package statemetrics_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	"gopkg.in/juju/names.v2"
 
@@ -146,12 +147,41 @@ func (m *mockState) AllMachines() ([]statemetrics.Machine, error) {
 	return out, nil
 }
 
+func (m *mockState) AllRelations() ([]statemetrics.Relation, error) {
+	m.MethodCall(m, "AllRelations")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Relation, len(m.model.relations))
+	for i, r := range m.model.relations {
+		out[i] = r
+	}
+	return out, nil
+}
+
 type mockModel struct {
 	testing.Stub
-	tag      names.ModelTag
-	life     state.Life
-	status   status.StatusInfo
-	machines []*mockMachine
+	tag            names.ModelTag
+	name           string
+	owner          names.UserTag
+	life           state.Life
+	status         status.StatusInfo
+	machines       []*mockMachine
+	units          []*mockUnit
+	relations      []*mockRelation
+	pendingActions int
+}
+
+func (m *mockModel) AllUnits() ([]statemetrics.Unit, error) {
+	m.MethodCall(m, "AllUnits")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Unit, len(m.units))
+	for i, u := range m.units {
+		out[i] = u
+	}
+	return out, nil
 }
 
 func (m *mockModel) Life() state.Life {
@@ -164,6 +194,32 @@ func (m *mockModel) ModelTag() names.ModelTag {
 	return m.tag
 }
 
+func (m *mockModel) Name() string {
+	m.MethodCall(m, "Name")
+	return m.name
+}
+
+func (m *mockModel) Owner() names.UserTag {
+	m.MethodCall(m, "Owner")
+	return m.owner
+}
+
+func (m *mockModel) LoadModelStatus() (statemetrics.ModelStatus, error) {
+	m.MethodCall(m, "LoadModelStatus")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return mockModelStatus{m.units}, nil
+}
+
+func (m *mockModel) PendingActionCount() (int, error) {
+	m.MethodCall(m, "PendingActionCount")
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.pendingActions, nil
+}
+
 func (m *mockModel) Status() (status.StatusInfo, error) {
 	m.MethodCall(m, "Status")
 	if err := m.NextErr(); err != nil {
@@ -222,3 +278,50 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	}
 	return m.agentStatus, nil
 }
+
+type mockModelStatus struct {
+	units []*mockUnit
+}
+
+func (m mockModelStatus) unit(name string) (*mockUnit, error) {
+	for _, u := range m.units {
+		if u.name == name {
+			return u, nil
+		}
+	}
+	return nil, errors.NotFoundf("unit %q", name)
+}
+
+func (m mockModelStatus) UnitAgent(name string) (status.StatusInfo, error) {
+	u, err := m.unit(name)
+	if err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.agentStatus, nil
+}
+
+func (m mockModelStatus) UnitWorkload(name string) (status.StatusInfo, error) {
+	u, err := m.unit(name)
+	if err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.workloadStatus, nil
+}
+
+type mockUnit struct {
+	name           string
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+type mockRelation struct {
+	suspended bool
+}
+
+func (r *mockRelation) Suspended() bool {
+	return r.suspended
+}
@@ -15,6 +15,7 @@ import (
 type State interface {
 	AllMachines() ([]Machine, error)
 	AllModelUUIDs() ([]string, error)
+	AllRelations() ([]Relation, error)
 	AllUsers() ([]User, error)
 	ControllerTag() names.ControllerTag
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
@@ -43,11 +44,33 @@ type Machine interface {
 
 // Model represents a Juju model.
 type Model interface {
+	AllUnits() ([]Unit, error)
 	Life() state.Life
+	LoadModelStatus() (ModelStatus, error)
 	ModelTag() names.ModelTag
+	Name() string
+	Owner() names.UserTag
+	PendingActionCount() (int, error)
 	Status() (status.StatusInfo, error)
 }
 
+// ModelStatus represents the statuses of the entities in a Juju
+// model, loaded at once.
+type ModelStatus interface {
+	UnitAgent(unitName string) (status.StatusInfo, error)
+	UnitWorkload(unitName string) (status.StatusInfo, error)
+}
+
+// Relation represents a relation in a Juju model.
+type Relation interface {
+	Suspended() bool
+}
+
+// Unit represents a unit in a Juju model.
+type Unit interface {
+	Name() string
+}
+
 // User represents a user known to the Juju controller.
 type User interface {
 	IsDeleted() bool
@@ -73,6 +96,10 @@ type pooledStateShim struct {
 	*state.PooledState
 }
 
+type modelShim struct {
+	*state.Model
+}
+
 func (p statePoolShim) SystemState() State {
 	return stateShim{p.pool.SystemState()}
 }
@@ -90,7 +117,23 @@ func (p statePoolShim) GetModel(modelUUID string) (Model, state.PoolHelper, erro
 	if err != nil {
 		return nil, nil, err
 	}
-	return model, ph, err
+	return modelShim{model}, ph, err
+}
+
+func (m modelShim) LoadModelStatus() (ModelStatus, error) {
+	return m.Model.LoadModelStatus()
+}
+
+func (m modelShim) AllUnits() ([]Unit, error) {
+	units, err := m.Model.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Unit, len(units))
+	for i, u := range units {
+		out[i] = u
+	}
+	return out, nil
 }
 
 func (s stateShim) AllMachines() ([]Machine, error) {
@@ -115,6 +158,26 @@ func allMachines(st *state.State) ([]Machine, error) {
 	return out, nil
 }
 
+func (s stateShim) AllRelations() ([]Relation, error) {
+	return allRelations(s.State)
+}
+
+func (s pooledStateShim) AllRelations() ([]Relation, error) {
+	return allRelations(s.State)
+}
+
+func allRelations(st *state.State) ([]Relation, error) {
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = r
+	}
+	return out, nil
+}
+
 func (s stateShim) AllUsers() ([]User, error) {
 	return allUsers(s.State)
 }
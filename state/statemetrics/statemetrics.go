@@ -4,6 +4,9 @@
 package statemetrics
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +23,10 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	workloadStatusLabel   = "workload_status"
+	suspendedLabel        = "suspended"
+	modelLabel            = "model"
+	modelUUIDLabel        = "model_uuid"
 )
 
 var (
@@ -41,6 +48,32 @@ var (
 		domainLabel,
 	}
 
+	modelMachineLabelNames = []string{
+		agentStatusLabel,
+		lifeLabel,
+		machineStatusLabel,
+		modelLabel,
+		modelUUIDLabel,
+	}
+
+	modelUnitLabelNames = []string{
+		agentStatusLabel,
+		modelLabel,
+		modelUUIDLabel,
+		workloadStatusLabel,
+	}
+
+	modelRelationLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		suspendedLabel,
+	}
+
+	modelActionLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+	}
+
 	logger = loggo.GetLogger("juju.state.statemetrics")
 )
 
@@ -55,6 +88,13 @@ type Collector struct {
 	models   *prometheus.GaugeVec
 	machines *prometheus.GaugeVec
 	users    *prometheus.GaugeVec
+
+	// The following metrics are reported for each model, so
+	// that the health of a model can be monitored.
+	modelMachines       *prometheus.GaugeVec
+	modelUnits          *prometheus.GaugeVec
+	modelRelations      *prometheus.GaugeVec
+	modelPendingActions *prometheus.GaugeVec
 }
 
 // New returns a new Collector.
@@ -100,6 +140,39 @@ func New(pool StatePool) *Collector {
 			},
 			userLabelNames,
 		),
+
+		modelMachines: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_machines",
+				Help:      "Number of machines in each model, by status.",
+			},
+			modelMachineLabelNames,
+		),
+		modelUnits: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_units",
+				Help:      "Number of units in each model, by workload and agent status.",
+			},
+			modelUnitLabelNames,
+		),
+		modelRelations: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_relations",
+				Help:      "Number of relations in each model.",
+			},
+			modelRelationLabelNames,
+		),
+		modelPendingActions: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_pending_actions",
+				Help:      "Number of actions in each model waiting to run.",
+			},
+			modelActionLabelNames,
+		),
 	}
 }
 
@@ -108,6 +181,10 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
 	c.users.Describe(ch)
+	c.modelMachines.Describe(ch)
+	c.modelUnits.Describe(ch)
+	c.modelRelations.Describe(ch)
+	c.modelPendingActions.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
 	c.scrapeDuration.Describe(ch)
@@ -124,12 +201,20 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.machines.Reset()
 	c.models.Reset()
 	c.users.Reset()
+	c.modelMachines.Reset()
+	c.modelUnits.Reset()
+	c.modelRelations.Reset()
+	c.modelPendingActions.Reset()
 
 	c.updateMetrics()
 
 	c.machines.Collect(ch)
 	c.models.Collect(ch)
 	c.users.Collect(ch)
+	c.modelMachines.Collect(ch)
+	c.modelUnits.Collect(ch)
+	c.modelRelations.Collect(ch)
+	c.modelPendingActions.Collect(ch)
 }
 
 func (c *Collector) updateMetrics() {
@@ -210,6 +295,11 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 	}
 	defer st.Release()
 
+	modelLabels := prometheus.Labels{
+		modelLabel:     fmt.Sprintf("%s/%s", model.Owner().Id(), model.Name()),
+		modelUUIDLabel: modelTag.Id(),
+	}
+
 	machines, err := st.AllMachines()
 	if err != nil {
 		c.scrapeErrors.Inc()
@@ -242,6 +332,33 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 			lifeLabel:          m.Life().String(),
 			machineStatusLabel: string(machineStatus.Status),
 		}).Inc()
+		c.modelMachines.With(withModelLabels(modelLabels, prometheus.Labels{
+			agentStatusLabel:   string(agentStatus.Status),
+			lifeLabel:          m.Life().String(),
+			machineStatusLabel: string(machineStatus.Status),
+		})).Inc()
+	}
+
+	c.updateUnitMetrics(model, modelLabels)
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting relations: %v", err)
+		relations = nil
+	}
+	for _, r := range relations {
+		c.modelRelations.With(withModelLabels(modelLabels, prometheus.Labels{
+			suspendedLabel: strconv.FormatBool(r.Suspended()),
+		})).Inc()
+	}
+
+	pendingActions, err := model.PendingActionCount()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting pending actions: %v", err)
+	} else {
+		c.modelPendingActions.With(modelLabels).Set(float64(pendingActions))
 	}
 
 	c.models.With(prometheus.Labels{
@@ -249,3 +366,58 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 		statusLabel: string(modelStatus.Status),
 	}).Inc()
 }
+
+// updateUnitMetrics counts the model's units by their workload and
+// agent statuses. The agent status is as recorded by the agent; the
+// lost status reported for agents that are not connected to the
+// controller is not reflected.
+func (c *Collector) updateUnitMetrics(model Model, modelLabels prometheus.Labels) {
+	units, err := model.AllUnits()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting units: %v", err)
+		return
+	}
+	if len(units) == 0 {
+		return
+	}
+	// The statuses of all the units are read at once,
+	// rather than querying each unit's statuses in turn.
+	modelStatus, err := model.LoadModelStatus()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting unit statuses: %v", err)
+		return
+	}
+	for _, u := range units {
+		agentStatus, err := modelStatus.UnitAgent(u.Name())
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit agent status: %v", err)
+			continue
+		}
+		workloadStatus, err := modelStatus.UnitWorkload(u.Name())
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit workload status: %v", err)
+			continue
+		}
+		c.modelUnits.With(withModelLabels(modelLabels, prometheus.Labels{
+			agentStatusLabel:    string(agentStatus.Status),
+			workloadStatusLabel: string(workloadStatus.Status),
+		})).Inc()
+	}
+}
+
+// withModelLabels returns the given labels, along with
+// the labels identifying a model.
+func withModelLabels(modelLabels, labels prometheus.Labels) prometheus.Labels {
+	for name, value := range modelLabels {
+		labels[name] = value
+	}
+	return labels
+}
@@ -50,6 +50,8 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 	s.pool = &mockStatePool{
 		models: []*mockModel{{
 			tag:    names.NewModelTag("b266dff7-eee8-4297-b03a-4692796ec193"),
+			name:   "prod",
+			owner:  names.NewUserTag("admin"),
 			life:   state.Alive,
 			status: status.StatusInfo{Status: status.Available},
 			machines: []*mockMachine{{
@@ -57,8 +59,25 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Started},
 				instanceStatus: status.StatusInfo{Status: status.Running},
 			}},
+			units: []*mockUnit{{
+				name:           "mysql/0",
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				name:           "mysql/1",
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				name:           "wordpress/0",
+				agentStatus:    status.StatusInfo{Status: status.Executing},
+				workloadStatus: status.StatusInfo{Status: status.Maintenance},
+			}},
+			relations:      []*mockRelation{{}, {suspended: true}},
+			pendingActions: 2,
 		}, {
 			tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			name:   "staging",
+			owner:  names.NewUserTag("bob"),
 			life:   state.Dying,
 			status: status.StatusInfo{Status: status.Destroying},
 			machines: []*mockMachine{{
@@ -89,6 +108,10 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 		`.*fqName: "juju_state_machines".*`,
 		`.*fqName: "juju_state_models".*`,
 		`.*fqName: "juju_state_users".*`,
+		`.*fqName: "juju_state_model_machines".*`,
+		`.*fqName: "juju_state_model_units".*`,
+		`.*fqName: "juju_state_model_relations".*`,
+		`.*fqName: "juju_state_model_pending_actions".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
 	}
@@ -214,6 +237,82 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 			},
 		},
 
+		// juju_state_model_machines
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "started"),
+				labelpair("life", "alive"),
+				labelpair("machine_status", "running"),
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "error"),
+				labelpair("life", "alive"),
+				labelpair("machine_status", "provisioning error"),
+				labelpair("model", "bob/staging"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_model_units
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "executing"),
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "maintenance"),
+			},
+		},
+
+		// juju_state_model_relations
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("suspended", "false"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("suspended", "true"),
+			},
+		},
+
+		// juju_state_model_pending_actions
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "admin/prod"),
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "bob/staging"),
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
 		// juju_state_scrape_errors
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(0)},
@@ -155,6 +155,31 @@ func (s *ApplicationStatusSuite) TestSetStatusSince(c *gc.C) {
 	c.Assert(timeBeforeOrEqual(*firstTime, *statusInfo.Since), jc.IsTrue)
 }
 
+func (s *ApplicationStatusSuite) TestRecordLeadershipChange(c *gc.C) {
+	now := testing.ZeroTime()
+	err := s.application.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.RecordLeadershipChange("leadership pinned by admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.application.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.Not(gc.HasLen), 0)
+	c.Check(history[0].Status, gc.Equals, status.Active)
+	c.Check(history[0].Message, gc.Equals, "leadership pinned by admin")
+
+	// The application's current status is left alone.
+	statusInfo, err := s.application.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Active)
+	c.Check(statusInfo.Message, gc.Equals, "ready")
+}
+
 func (s *ApplicationStatusSuite) TestDeriveStatus(c *gc.C) {
 	// NOTE(fwereade): as detailed in the code, this implementation is not sane.
 	// The specified behaviour is arguably sane, but the code is in the wrong
@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// StatusTimelineFilter holds the criteria for the status history
+// entries returned by State.StatusTimeline.
+type StatusTimelineFilter struct {
+	// From, if set, excludes entries recorded before it.
+	From *time.Time
+
+	// To, if set, excludes entries recorded after it.
+	To *time.Time
+
+	// Kinds, if not empty, restricts the entries to the given kinds
+	// of status. KindUnit includes both unit agent and workload
+	// statuses.
+	Kinds []status.HistoryKind
+
+	// Exclude holds status messages that should be excluded from
+	// the timeline.
+	Exclude set.Strings
+
+	// Size, if non-zero, restricts the timeline to the most recent
+	// Size entries.
+	Size int
+}
+
+// Validate checks that the filter bounds the timeline, and that the
+// kinds of status requested are known.
+func (f StatusTimelineFilter) Validate() error {
+	if f.From == nil && f.Size <= 0 {
+		return errors.NotValidf("status timeline without a start time or size")
+	}
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return errors.NotValidf("status timeline ending before it starts")
+	}
+	for _, kind := range f.Kinds {
+		if !kind.Valid() && kind != status.KindApplication {
+			return errors.NotValidf("status kind %q", kind)
+		}
+	}
+	return nil
+}
+
+func (f StatusTimelineFilter) includesKind(kind status.HistoryKind) bool {
+	if len(f.Kinds) == 0 {
+		return true
+	}
+	for _, k := range f.Kinds {
+		if k == kind {
+			return true
+		}
+		if k == status.KindUnit && (kind == status.KindWorkload || kind == status.KindUnitAgent) {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusTimelineEntry is a status history entry of an application,
+// machine or unit, as reported by State.StatusTimeline.
+type StatusTimelineEntry struct {
+	// Entity identifies the application, machine or unit.
+	Entity names.Tag
+
+	// Kind identifies which of the entity's statuses changed.
+	Kind status.HistoryKind
+
+	status.StatusInfo
+}
+
+// StatusTimeline returns the status history entries of the model's
+// applications, machines and units that match the filter, merged and
+// ordered by the time they were recorded, oldest first.
+func (st *State) StatusTimeline(filter StatusTimelineFilter) ([]StatusTimelineEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statusHistory, closer := st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	query := bson.M{}
+	updated := bson.M{}
+	if filter.From != nil {
+		updated["$gte"] = filter.From.UnixNano()
+	}
+	if filter.To != nil {
+		updated["$lte"] = filter.To.UnixNano()
+	}
+	if len(updated) > 0 {
+		query["updated"] = updated
+	}
+	if excludes := filter.Exclude.Values(); len(excludes) > 0 {
+		query["statusinfo"] = bson.M{"$nin": excludes}
+	}
+
+	// The kinds of status are identified by the global keys, so
+	// the entries are filtered here rather than in the query. We
+	// read the most recent entries first, so that we can stop
+	// once we have enough.
+	iter := statusHistory.Find(query).Sort("-updated").Iter()
+	defer iter.Close()
+
+	var entries []StatusTimelineEntry
+	for {
+		var doc historicalStatusDoc
+		if !iter.Next(&doc) {
+			break
+		}
+		entity, kind, ok := statusEntityFromGlobalKey(doc.GlobalKey)
+		if !ok || !filter.includesKind(kind) {
+			continue
+		}
+		entries = append(entries, StatusTimelineEntry{
+			Entity: entity,
+			Kind:   kind,
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+		})
+		if filter.Size > 0 && len(entries) == filter.Size {
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot get status timeline")
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
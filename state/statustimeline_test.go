@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StatusTimelineSuite struct {
+	ConnSuite
+	start time.Time
+	unit  *state.Unit
+	app   *state.Application
+	mach  *state.Machine
+}
+
+var _ = gc.Suite(&StatusTimelineSuite{})
+
+func (s *StatusTimelineSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	// The statuses are recorded in the future, after those
+	// recorded when the entities were created.
+	s.start = time.Now().Add(time.Hour)
+	s.mach = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.mach})
+	var err error
+	s.app, err = s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setStatus(c, s.mach, status.Started, "", 1)
+	s.setStatus(c, s.unit, status.Maintenance, "installing", 2)
+	s.setStatus(c, s.unit.Agent(), status.Executing, "running install hook", 3)
+	s.setStatus(c, s.app, status.Blocked, "missing relation", 4)
+	s.setStatus(c, s.unit, status.Blocked, "missing relation", 5)
+}
+
+func (s *StatusTimelineSuite) at(minutes int) *time.Time {
+	t := s.start.Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+func (s *StatusTimelineSuite) setStatus(c *gc.C, setter status.StatusSetter, value status.Status, message string, minutes int) {
+	err := setter.SetStatus(status.StatusInfo{
+		Status:  value,
+		Message: message,
+		Since:   s.at(minutes),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type timelineEntry struct {
+	entity names.Tag
+	kind   status.HistoryKind
+	status status.Status
+}
+
+func (s *StatusTimelineSuite) checkTimeline(c *gc.C, entries []state.StatusTimelineEntry, expected []timelineEntry) {
+	var obtained []timelineEntry
+	for _, entry := range entries {
+		obtained = append(obtained, timelineEntry{entry.Entity, entry.Kind, entry.Status})
+	}
+	c.Assert(obtained, jc.DeepEquals, expected)
+}
+
+func (s *StatusTimelineSuite) TestStatusTimeline(c *gc.C) {
+	entries, err := s.State.StatusTimeline(state.StatusTimelineFilter{From: s.at(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	unitTag := s.unit.UnitTag()
+	s.checkTimeline(c, entries, []timelineEntry{
+		{s.mach.MachineTag(), status.KindMachine, status.Started},
+		{unitTag, status.KindWorkload, status.Maintenance},
+		{unitTag, status.KindUnitAgent, status.Executing},
+		{s.app.ApplicationTag(), status.KindApplication, status.Blocked},
+		{unitTag, status.KindWorkload, status.Blocked},
+	})
+	c.Check(entries[2].Message, gc.Equals, "running install hook")
+	c.Check(entries[2].Since.Equal(*s.at(3)), jc.IsTrue)
+}
+
+func (s *StatusTimelineSuite) TestStatusTimelineWindow(c *gc.C) {
+	entries, err := s.State.StatusTimeline(state.StatusTimelineFilter{
+		From: s.at(2),
+		To:   s.at(4),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unitTag := s.unit.UnitTag()
+	s.checkTimeline(c, entries, []timelineEntry{
+		{unitTag, status.KindWorkload, status.Maintenance},
+		{unitTag, status.KindUnitAgent, status.Executing},
+		{s.app.ApplicationTag(), status.KindApplication, status.Blocked},
+	})
+}
+
+func (s *StatusTimelineSuite) TestStatusTimelineKinds(c *gc.C) {
+	entries, err := s.State.StatusTimeline(state.StatusTimelineFilter{
+		From:  s.at(1),
+		Kinds: []status.HistoryKind{status.KindUnit, status.KindMachine},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unitTag := s.unit.UnitTag()
+	s.checkTimeline(c, entries, []timelineEntry{
+		{s.mach.MachineTag(), status.KindMachine, status.Started},
+		{unitTag, status.KindWorkload, status.Maintenance},
+		{unitTag, status.KindUnitAgent, status.Executing},
+		{unitTag, status.KindWorkload, status.Blocked},
+	})
+}
+
+func (s *StatusTimelineSuite) TestStatusTimelineSizeAndExclude(c *gc.C) {
+	entries, err := s.State.StatusTimeline(state.StatusTimelineFilter{
+		Size:    2,
+		Exclude: set.NewStrings("missing relation"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTimeline(c, entries, []timelineEntry{
+		{s.unit.UnitTag(), status.KindWorkload, status.Maintenance},
+		{s.unit.UnitTag(), status.KindUnitAgent, status.Executing},
+	})
+}
+
+func (s *StatusTimelineSuite) TestStatusTimelineInvalidFilter(c *gc.C) {
+	_, err := s.State.StatusTimeline(state.StatusTimelineFilter{})
+	c.Assert(err, gc.ErrorMatches, "status timeline without a start time or size not valid")
+
+	_, err = s.State.StatusTimeline(state.StatusTimelineFilter{From: s.at(2), To: s.at(1)})
+	c.Assert(err, gc.ErrorMatches, "status timeline ending before it starts not valid")
+
+	_, err = s.State.StatusTimeline(state.StatusTimelineFilter{Size: 1, Kinds: []status.HistoryKind{"volume"}})
+	c.Assert(err, gc.ErrorMatches, `status kind "volume" not valid`)
+}
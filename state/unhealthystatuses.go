@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// UnhealthyStatus describes a status of a machine or unit, in one
+// of the controller's models, that needs attention.
+type UnhealthyStatus struct {
+	ModelUUID string
+
+	// Entity identifies the machine or unit.
+	Entity names.Tag
+
+	// Kind identifies which of the entity's statuses is unhealthy.
+	Kind status.HistoryKind
+
+	status.StatusInfo
+}
+
+// unhealthyStatuses holds, for each kind of status, the
+// values considered to need attention.
+var unhealthyStatuses = map[status.HistoryKind][]status.Status{
+	status.KindWorkload:          {status.Error, status.Blocked},
+	status.KindUnitAgent:         {status.Error, status.Failed},
+	status.KindMachine:           {status.Error},
+	status.KindMachineInstance:   {status.Error, status.ProvisioningError},
+	status.KindContainer:         {status.Error},
+	status.KindContainerInstance: {status.Error, status.ProvisioningError},
+}
+
+// UnhealthyStatuses returns the statuses of the machines and units in
+// the specified models that need attention: workloads that are in error
+// or blocked, unit and machine agents that are in error, and instances
+// that failed to provision. Statuses derived from agent presence, such
+// as lost unit agents, are not reported. The results are ordered by
+// model, and then by entity.
+func (st *State) UnhealthyStatuses(modelUUIDs []string) ([]UnhealthyStatus, error) {
+	if len(modelUUIDs) == 0 {
+		return nil, nil
+	}
+	values := make(map[status.Status]bool)
+	for _, statuses := range unhealthyStatuses {
+		for _, s := range statuses {
+			values[s] = true
+		}
+	}
+	var query []status.Status
+	for s := range values {
+		query = append(query, s)
+	}
+
+	// We use the raw statuses because we are reading across models.
+	rawStatuses, closer := st.database.GetRawCollection(statusesC)
+	defer closer()
+	iter := rawStatuses.Find(bson.M{
+		"model-uuid": bson.M{"$in": modelUUIDs},
+		"status":     bson.M{"$in": query},
+	}).Iter()
+	defer iter.Close()
+
+	var results []UnhealthyStatus
+	for {
+		// The doc is declared afresh for each iteration, because
+		// decoding into a reused value leaves fields, such as
+		// statusdata, from a previous document in place.
+		var doc struct {
+			DocID      string                 `bson:"_id"`
+			ModelUUID  string                 `bson:"model-uuid"`
+			Status     status.Status          `bson:"status"`
+			StatusInfo string                 `bson:"statusinfo"`
+			StatusData map[string]interface{} `bson:"statusdata"`
+			Updated    int64                  `bson:"updated"`
+		}
+		if !iter.Next(&doc) {
+			break
+		}
+		globalKey := strings.TrimPrefix(doc.DocID, doc.ModelUUID+":")
+		entity, kind, ok := statusEntityFromGlobalKey(globalKey)
+		if !ok || !isUnhealthyStatus(kind, doc.Status) {
+			continue
+		}
+		results = append(results, UnhealthyStatus{
+			ModelUUID: doc.ModelUUID,
+			Entity:    entity,
+			Kind:      kind,
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].ModelUUID != results[j].ModelUUID {
+			return results[i].ModelUUID < results[j].ModelUUID
+		}
+		if results[i].Entity.String() != results[j].Entity.String() {
+			return results[i].Entity.String() < results[j].Entity.String()
+		}
+		return results[i].Kind < results[j].Kind
+	})
+	return results, nil
+}
+
+// statusEntityFromGlobalKey returns the application, machine or unit,
+// and the kind of status, recorded under the given status global key.
+func statusEntityFromGlobalKey(key string) (names.Tag, status.HistoryKind, bool) {
+	switch {
+	case strings.HasPrefix(key, "a#"):
+		name := strings.TrimPrefix(key, "a#")
+		if !names.IsValidApplication(name) {
+			return nil, "", false
+		}
+		return names.NewApplicationTag(name), status.KindApplication, true
+	case strings.HasPrefix(key, "u#"):
+		name := strings.TrimPrefix(key, "u#")
+		kind := status.KindUnitAgent
+		if strings.HasSuffix(name, "#charm") {
+			name = strings.TrimSuffix(name, "#charm")
+			kind = status.KindWorkload
+		}
+		if !names.IsValidUnit(name) {
+			return nil, "", false
+		}
+		return names.NewUnitTag(name), kind, true
+	case strings.HasPrefix(key, "m#"):
+		id := strings.TrimPrefix(key, "m#")
+		instance := strings.HasSuffix(id, "#instance")
+		id = strings.TrimSuffix(id, "#instance")
+		if !names.IsValidMachine(id) {
+			return nil, "", false
+		}
+		container := names.IsContainerMachine(id)
+		var kind status.HistoryKind
+		switch {
+		case instance && container:
+			kind = status.KindContainerInstance
+		case instance:
+			kind = status.KindMachineInstance
+		case container:
+			kind = status.KindContainer
+		default:
+			kind = status.KindMachine
+		}
+		return names.NewMachineTag(id), kind, true
+	}
+	return nil, "", false
+}
+
+func isUnhealthyStatus(kind status.HistoryKind, value status.Status) bool {
+	for _, s := range unhealthyStatuses[kind] {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
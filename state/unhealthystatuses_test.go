@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnhealthyStatusesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UnhealthyStatusesSuite{})
+
+func (s *UnhealthyStatusesSuite) TestUnhealthyStatuses(c *gc.C) {
+	now := time.Now()
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "missing relation",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	healthy := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = healthy.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherFactory := factory.NewFactory(otherSt)
+	machine := otherFactory.MakeMachine(c, nil)
+	err = machine.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: "no capacity",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.State.UnhealthyStatuses([]string{s.State.ModelUUID(), otherSt.ModelUUID()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	byModel := make(map[string]state.UnhealthyStatus)
+	for _, result := range results {
+		byModel[result.ModelUUID] = result
+	}
+
+	unitStatus := byModel[s.State.ModelUUID()]
+	c.Check(unitStatus.Entity, gc.Equals, names.NewUnitTag(unit.Name()))
+	c.Check(unitStatus.Kind, gc.Equals, status.KindWorkload)
+	c.Check(unitStatus.Status, gc.Equals, status.Blocked)
+	c.Check(unitStatus.Message, gc.Equals, "missing relation")
+
+	machineStatus := byModel[otherSt.ModelUUID()]
+	c.Check(machineStatus.Entity, gc.Equals, machine.MachineTag())
+	c.Check(machineStatus.Kind, gc.Equals, status.KindMachineInstance)
+	c.Check(machineStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(machineStatus.Message, gc.Equals, "no capacity")
+
+	// Only the requested models are considered.
+	results, err = s.State.UnhealthyStatuses([]string{otherSt.ModelUUID()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Entity, gc.Equals, machine.MachineTag())
+}
+
+func (s *UnhealthyStatusesSuite) TestUnhealthyStatusesNoModels(c *gc.C) {
+	results, err := s.State.UnhealthyStatuses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *UnhealthyStatusesSuite) TestUnhealthyStatusesDataNotShared(c *gc.C) {
+	now := time.Now()
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "missing relation",
+		Data:    map[string]interface{}{"relation": "db"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, nil)
+	err = machine.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "broken",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.State.UnhealthyStatuses([]string{s.State.ModelUUID()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	for _, result := range results {
+		switch result.Entity {
+		case names.NewUnitTag(unit.Name()):
+			c.Check(result.Data, jc.DeepEquals, map[string]interface{}{"relation": "db"})
+		case machine.MachineTag():
+			c.Check(result.Data, gc.HasLen, 0)
+		default:
+			c.Errorf("unexpected entity %v", result.Entity)
+		}
+	}
+}
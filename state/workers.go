@@ -222,6 +222,57 @@ func (l lazyLeaseChecker) Token(leaseName, holderName string) corelease.Token {
 	return checker.Token(leaseName, holderName)
 }
 
+// lazyLeasePinner wraps workers.leadershipManager.Pinner, and calls it
+// in the method calls. This enables the manager to use restarted lease
+// managers.
+type lazyLeasePinner struct {
+	leasePinner func() (corelease.Pinner, error)
+}
+
+// Pin is part of the lease.Pinner interface.
+func (l lazyLeasePinner) Pin(leaseName, entity string) error {
+	pinner, err := l.leasePinner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return pinner.Pin(leaseName, entity)
+}
+
+// Unpin is part of the lease.Pinner interface.
+func (l lazyLeasePinner) Unpin(leaseName, entity string) error {
+	pinner, err := l.leasePinner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return pinner.Unpin(leaseName, entity)
+}
+
+// Pinned is part of the lease.Pinner interface.
+func (l lazyLeasePinner) Pinned() map[string][]string {
+	pinner, err := l.leasePinner()
+	if err != nil {
+		logger.Warningf("cannot get pinned leases: %v", err)
+		return make(map[string][]string)
+	}
+	return pinner.Pinned()
+}
+
+// lazyLeaseTransferrer wraps workers.leadershipManager.Transferrer, and
+// calls it in the method calls. This enables the manager to use
+// restarted lease managers.
+type lazyLeaseTransferrer struct {
+	leaseTransferrer func() (corelease.Transferrer, error)
+}
+
+// Transfer is part of the lease.Transferrer interface.
+func (l lazyLeaseTransferrer) Transfer(leaseName, holderName string, duration time.Duration) error {
+	transferrer, err := l.leaseTransferrer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return transferrer.Transfer(leaseName, holderName, duration)
+}
+
 // errorToken is a token whose Check method always returns the given
 // error.
 type errorToken struct {
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config backupscheduler.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = backupscheduler.ManifoldConfig{
+		AgentName:  "agent",
+		ClockName:  "clock",
+		StateName:  "state",
+		RetryDelay: time.Minute,
+		NewBackups: func(*state.State, agent.Config) backupscheduler.Backups {
+			return nil
+		},
+		NewWorker: func(backupscheduler.Config) (worker.Worker, error) {
+			return nil, errors.New("unexpected")
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestMissingAgentName(c *gc.C) {
+	s.config.AgentName = ""
+	s.checkNotValid(c, "empty AgentName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	s.config.ClockName = ""
+	s.checkNotValid(c, "empty ClockName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingStateName(c *gc.C) {
+	s.config.StateName = ""
+	s.checkNotValid(c, "empty StateName not valid")
+}
+
+func (s *ManifoldSuite) TestZeroRetryDelay(c *gc.C) {
+	s.config.RetryDelay = 0
+	s.checkNotValid(c, "non-positive RetryDelay not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewBackups(c *gc.C) {
+	s.config.NewBackups = nil
+	s.checkNotValid(c, "nil NewBackups not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// backupsDB implements backups.DB, which needs both
+// state and model methods.
+type backupsDB struct {
+	*state.State
+	*state.Model
+}
+
+// ModelTag disambiguates the ModelTag method.
+func (db backupsDB) ModelTag() names.ModelTag {
+	return db.Model.ModelTag()
+}
+
+// stateBackups implements Backups by creating backups of the
+// controller in the same way as the backups facade does.
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// NewBackups returns a Backups that creates backups of the
+// controller using the given state and agent configuration.
+func NewBackups(st *state.State, agentConfig agent.Config) Backups {
+	return &stateBackups{st: st, agentConfig: agentConfig}
+}
+
+func (b *stateBackups) open() (backupsDB, backups.Backups, func(), error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return backupsDB{}, nil, nil, errors.Trace(err)
+	}
+	db := backupsDB{b.st, model}
+	stor := backups.NewStorage(db)
+	return db, backups.NewBackups(stor), func() { stor.Close() }, nil
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create() (*backups.Metadata, error) {
+	db, backupsMethods, closer, err := b.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info found in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(db, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = backups.ScheduledNotes
+	meta.Scheduled = true
+
+	modelConfig, err := db.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backupsMethods.Create(meta, &paths, dbInfo, true, true, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	_, backupsMethods, closer, err := b.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer()
+	return backupsMethods.List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	_, backupsMethods, closer, err := b.open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+	return backupsMethods.Remove(id)
+}
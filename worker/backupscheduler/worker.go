@@ -0,0 +1,192 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend provides the controller configuration
+// that determines when backups are created.
+type Backend interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// Backups creates, lists and removes the controller's backups.
+type Backups interface {
+	// Create creates and stores a new scheduled
+	// backup of the controller.
+	Create() (*backups.Metadata, error)
+
+	// List returns the metadata for all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove deletes the backup from storage.
+	Remove(id string) error
+}
+
+// Config holds the dependencies and configuration for a backup
+// scheduler worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+
+	// RetryDelay is how long the worker waits
+	// before trying again after a failed backup.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// a backup scheduler worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which creates a backup of the controller
+// every backup-interval, and removes the scheduled backups that are
+// no longer kept by the retention policy in the controller config.
+// This worker must not be run in more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduleWorker{config: config}
+	return jworker.NewSimpleWorker(w.loop), nil
+}
+
+type scheduleWorker struct {
+	config Config
+}
+
+func (w *scheduleWorker) loop(stopCh <-chan struct{}) error {
+	controllerConfigWatcher := w.config.Backend.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	var (
+		interval                time.Duration
+		policy                  backups.RetentionPolicy
+		controllerConfigChanges = controllerConfigWatcher.Changes()
+		backupCh                <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+
+		case _, ok := <-controllerConfigChanges:
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			newPolicy := backups.RetentionPolicy{
+				Daily:  controllerConfig.BackupRetainDaily(),
+				Weekly: controllerConfig.BackupRetainWeekly(),
+			}
+			newInterval := controllerConfig.BackupInterval()
+			if newInterval == interval && newPolicy == policy && backupCh != nil {
+				continue
+			}
+			interval, policy = newInterval, newPolicy
+			if interval == 0 {
+				logger.Infof("scheduled backups disabled")
+				backupCh = nil
+				continue
+			}
+			logger.Infof("backup schedule: every %v, keeping %d daily and %d weekly backups",
+				interval, policy.Daily, policy.Weekly)
+			delay, err := w.nextBackupDelay(interval)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			backupCh = w.config.Clock.After(delay)
+
+		case <-backupCh:
+			if err := w.backup(policy); err != nil {
+				logger.Errorf("scheduled backup failed, retrying in %v: %v", w.config.RetryDelay, err)
+				backupCh = w.config.Clock.After(w.config.RetryDelay)
+				continue
+			}
+			backupCh = w.config.Clock.After(interval)
+		}
+	}
+}
+
+// nextBackupDelay returns how long to wait before the next scheduled
+// backup, given the time the last scheduled backup was started.
+func (w *scheduleWorker) nextBackupDelay(interval time.Duration) (time.Duration, error) {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot list backups")
+	}
+	var last time.Time
+	for _, meta := range all {
+		if meta.Scheduled && meta.Started.After(last) {
+			last = meta.Started
+		}
+	}
+	if last.IsZero() {
+		return 0, nil
+	}
+	delay := last.Add(interval).Sub(w.config.Clock.Now())
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, nil
+}
+
+// backup creates a scheduled backup, and then removes the scheduled
+// backups that the policy no longer keeps. Failing to remove an
+// expired backup does not fail the scheduled backup.
+func (w *scheduleWorker) backup(policy backups.RetentionPolicy) error {
+	meta, err := w.config.Backups.Create()
+	if err != nil {
+		return errors.Annotate(err, "cannot create backup")
+	}
+	logger.Infof("created scheduled backup %q", meta.ID())
+
+	all, err := w.config.Backups.List()
+	if err != nil {
+		logger.Errorf("cannot list backups to apply retention policy: %v", err)
+		return nil
+	}
+	for _, expired := range policy.Expired(all, w.config.Clock.Now()) {
+		if err := w.config.Backups.Remove(expired.ID()); err != nil {
+			logger.Errorf("cannot remove expired backup %q: %v", expired.ID(), err)
+			continue
+		}
+		logger.Infof("removed expired backup %q", expired.ID())
+	}
+	return nil
+}
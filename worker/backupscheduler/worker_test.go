@@ -0,0 +1,306 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	clock   *testclock.Clock
+	backend *mockBackend
+	backups *mockBackups
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2018, time.June, 13, 15, 0, 0, 0, time.UTC))
+	s.backend = newMockBackend(controller.Config{
+		controller.BackupInterval:     "1h",
+		controller.BackupRetainDaily:  1,
+		controller.BackupRetainWeekly: 0,
+	})
+	s.AddCleanup(func(*gc.C) { s.backend.watcher.Kill() })
+	s.backups = &mockBackups{
+		clock: s.clock,
+		calls: make(chan string, 10),
+	}
+	s.config = backupscheduler.Config{
+		Backend:    s.backend,
+		Backups:    s.backups,
+		Clock:      s.clock,
+		RetryDelay: 5 * time.Minute,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) scheduled(id string, age time.Duration) *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	meta.Started = s.clock.Now().Add(-age)
+	meta.Scheduled = true
+	return meta
+}
+
+func (s *WorkerSuite) assertCalls(c *gc.C, expect ...string) {
+	for _, name := range expect {
+		select {
+		case call := <-s.backups.calls:
+			c.Assert(call, gc.Equals, name)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) assertNoCalls(c *gc.C) {
+	select {
+	case call := <-s.backups.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	s.config.Backend = nil
+	s.checkNotValid(c, "nil Backend not valid")
+	s.SetUpTest(c)
+	s.config.Backups = nil
+	s.checkNotValid(c, "nil Backups not valid")
+	s.SetUpTest(c)
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+	s.SetUpTest(c)
+	s.config.RetryDelay = 0
+	s.checkNotValid(c, "non-positive RetryDelay not valid")
+}
+
+func (s *WorkerSuite) checkNotValid(c *gc.C, expect string) {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestFirstBackupWhenNoneScheduled(c *gc.C) {
+	s.startWorker(c)
+	s.assertCalls(c, "List", "Create", "List")
+	s.assertNoCalls(c)
+
+	s.advance(c, time.Hour)
+	s.assertCalls(c, "Create", "List", "Remove")
+	c.Assert(s.backups.removed, jc.DeepEquals, []string{"created-0"})
+}
+
+func (s *WorkerSuite) TestWaitsForIntervalSinceLastScheduledBackup(c *gc.C) {
+	manual := backupstesting.NewMetadataStarted()
+	manual.SetID("manual")
+	manual.Started = s.clock.Now()
+	s.backups.all = []*backups.Metadata{manual, s.scheduled("last", 20*time.Minute)}
+
+	s.startWorker(c)
+	s.assertCalls(c, "List")
+	s.advance(c, 39*time.Minute)
+	s.assertNoCalls(c)
+
+	s.clock.Advance(time.Minute)
+	s.assertCalls(c, "Create", "List", "Remove")
+	c.Assert(s.backups.removed, jc.DeepEquals, []string{"last"})
+}
+
+func (s *WorkerSuite) TestRemovesExpiredBackups(c *gc.C) {
+	s.backups.all = []*backups.Metadata{
+		s.scheduled("last", 2*time.Hour),
+		s.scheduled("yesterday", 24*time.Hour),
+	}
+	s.backups.created = s.scheduled("new", 0)
+
+	s.startWorker(c)
+	s.assertCalls(c, "List", "Create", "List", "Remove", "Remove")
+	c.Assert(s.backups.removed, jc.SameContents, []string{"last", "yesterday"})
+}
+
+func (s *WorkerSuite) TestRemoveFailureIsNotFatal(c *gc.C) {
+	s.backups.all = []*backups.Metadata{s.scheduled("last", 24*time.Hour)}
+	s.backups.removeErr = errors.New("boom")
+
+	w := s.startWorker(c)
+	s.assertCalls(c, "List", "Create", "List", "Remove")
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	c.Assert(c.GetTestLog(), jc.Contains, `cannot remove expired backup "last": boom`)
+}
+
+func (s *WorkerSuite) TestRetriesFailedBackup(c *gc.C) {
+	s.backups.createErrs = []error{errors.New("boom")}
+
+	s.startWorker(c)
+	s.assertCalls(c, "List", "Create")
+	s.assertNoCalls(c)
+
+	s.advance(c, 5*time.Minute)
+	s.assertCalls(c, "Create", "List")
+	c.Assert(c.GetTestLog(), jc.Contains, "scheduled backup failed, retrying in 5m0s: cannot create backup: boom")
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.backend.setInterval("0s")
+
+	w := s.startWorker(c)
+	s.assertNoCalls(c)
+
+	s.backend.setInterval("1h")
+	s.backend.watcher.changes <- struct{}{}
+	s.assertCalls(c, "List", "Create", "List")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot load controller configuration: boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	mu      sync.Mutex
+	config  controller.Config
+	watcher *mockNotifyWatcher
+}
+
+func newMockBackend(config controller.Config) *mockBackend {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		return nil
+	})
+	w.changes <- struct{}{}
+	return &mockBackend{config: config, watcher: w}
+}
+
+func (b *mockBackend) WatchControllerConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchControllerConfig")
+	return b.watcher
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	config := make(controller.Config)
+	for k, v := range b.config {
+		config[k] = v
+	}
+	return config, b.NextErr()
+}
+
+func (b *mockBackend) setInterval(interval string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config[controller.BackupInterval] = interval
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *mockNotifyWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+type mockBackups struct {
+	clock      *testclock.Clock
+	calls      chan string
+	all        []*backups.Metadata
+	created    *backups.Metadata
+	createErrs []error
+	removeErr  error
+	removed    []string
+}
+
+func (b *mockBackups) Create() (*backups.Metadata, error) {
+	defer func() { b.calls <- "Create" }()
+	if len(b.createErrs) > 0 {
+		err := b.createErrs[0]
+		b.createErrs = b.createErrs[1:]
+		return nil, err
+	}
+	meta := b.created
+	if meta == nil {
+		meta = backupstesting.NewMetadataStarted()
+		meta.SetID(fmt.Sprintf("created-%d", len(b.all)))
+		meta.Started = b.clock.Now()
+	}
+	meta.Scheduled = true
+	b.all = append(b.all, meta)
+	return meta, nil
+}
+
+func (b *mockBackups) List() ([]*backups.Metadata, error) {
+	defer func() { b.calls <- "List" }()
+	return b.all, nil
+}
+
+func (b *mockBackups) Remove(id string) error {
+	defer func() { b.calls <- "Remove" }()
+	if b.removeErr != nil {
+		return b.removeErr
+	}
+	b.removed = append(b.removed, id)
+	return nil
+}
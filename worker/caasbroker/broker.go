@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 )
@@ -20,6 +21,7 @@ var logger = loggo.GetLogger("juju.worker.caas")
 type ConfigAPI interface {
 	CloudSpec() (environs.CloudSpec, error)
 	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 }
 
 // Config describes the dependencies of a Tracker.
@@ -96,12 +98,31 @@ func (t *Tracker) Broker() caas.Broker {
 }
 
 func (t *Tracker) loop() error {
-	// TODO(caas) - watch for config and credential changes
+	// TODO(caas) - watch for credential changes
+	modelWatcher, err := t.config.ConfigAPI.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model config")
+	}
+	if err := t.catacomb.Add(modelWatcher); err != nil {
+		return errors.Trace(err)
+	}
 	for {
-		logger.Debugf("waiting for config and credential notifications")
+		logger.Debugf("waiting for config notifications")
 		select {
 		case <-t.catacomb.Dying():
 			return t.catacomb.ErrDying()
+		case _, ok := <-modelWatcher.Changes():
+			if !ok {
+				return errors.New("model config watch closed")
+			}
+		}
+		logger.Debugf("reloading model config")
+		modelConfig, err := t.config.ConfigAPI.ModelConfig()
+		if err != nil {
+			return errors.Annotate(err, "cannot read model config")
+		}
+		if err = t.broker.SetConfig(modelConfig); err != nil {
+			return errors.Annotate(err, "cannot update model config")
 		}
 	}
 }
@@ -4,6 +4,8 @@
 package caasbroker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -109,3 +111,93 @@ func (s *TrackerSuite) TestInitialise(c *gc.C) {
 		context.CheckCallNames(c, "CloudSpec", "Model")
 	})
 }
+
+func (s *TrackerSuite) TestWatchFails(c *gc.C) {
+	fix := s.validFixture()
+	fix.observerErrs = []error{
+		nil, nil, errors.New("grrk splat"),
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot watch model config: grrk splat")
+		context.CheckCallNames(c, "CloudSpec", "Model", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestWatchCloses(c *gc.C) {
+	fix := s.validFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.CloseModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "model config watch closed")
+		context.CheckCallNames(c, "CloudSpec", "Model", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestWatchedModelConfigIncompatible(c *gc.C) {
+	fix := s.validFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI: context,
+			NewContainerBrokerFunc: func(args environs.OpenParams) (caas.Broker, error) {
+				broker := &mockBroker{}
+				broker.SetErrors(errors.New("SetConfig is broken"))
+				return broker, nil
+			},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.SendModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot update model config: SetConfig is broken")
+		context.CheckCallNames(c, "CloudSpec", "Model", "WatchForModelConfigChanges", "Model")
+	})
+}
+
+func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
+	fix := s.validFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		broker := tracker.Broker().(*mockBroker)
+		c.Assert(broker.Config().AllAttrs()["preserve-namespace"], gc.IsNil)
+
+		attrs := coretesting.Attrs(fix.config).Merge(coretesting.Attrs{
+			"preserve-namespace": true,
+		})
+		context.SetConfig(attrs)
+		context.SendModelConfigNotify()
+
+		timeout := time.After(coretesting.LongWait)
+		for {
+			if broker.Config().AllAttrs()["preserve-namespace"] == true {
+				break
+			}
+			select {
+			case <-time.After(coretesting.ShortWait):
+			case <-timeout:
+				c.Fatalf("timed out waiting for broker to be updated")
+			}
+		}
+	})
+}
@@ -8,8 +8,11 @@ import (
 
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 )
@@ -22,19 +25,30 @@ type fixture struct {
 }
 
 func (fix *fixture) Run(c *gc.C, test func(*runContext)) {
+	watcher := newNotifyWatcher(fix.watcherErr)
+	defer workertest.DirtyKill(c, watcher)
 	context := &runContext{
-		cloud:  fix.cloud,
-		config: fix.config,
+		cloud:   fix.cloud,
+		config:  fix.config,
+		watcher: watcher,
 	}
 	context.stub.SetErrors(fix.observerErrs...)
 	test(context)
 }
 
 type runContext struct {
-	mu     sync.Mutex
-	stub   testing.Stub
-	cloud  environs.CloudSpec
-	config map[string]interface{}
+	mu      sync.Mutex
+	stub    testing.Stub
+	cloud   environs.CloudSpec
+	config  map[string]interface{}
+	watcher *notifyWatcher
+}
+
+// SetConfig updates the configuration returned by ModelConfig.
+func (context *runContext) SetConfig(attrs map[string]interface{}) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.config = attrs
 }
 
 func (context *runContext) CloudSpec() (environs.CloudSpec, error) {
@@ -57,20 +71,79 @@ func (context *runContext) ModelConfig() (*config.Config, error) {
 	return config.New(config.UseDefaults, context.config)
 }
 
+// SendModelConfigNotify sends a value on the channel used by
+// WatchForModelConfigChanges results.
+func (context *runContext) SendModelConfigNotify() {
+	context.watcher.changes <- struct{}{}
+}
+
+// CloseModelConfigNotify closes the channel used by
+// WatchForModelConfigChanges results.
+func (context *runContext) CloseModelConfigNotify() {
+	close(context.watcher.changes)
+}
+
+func (context *runContext) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.stub.AddCall("WatchForModelConfigChanges")
+	if err := context.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return context.watcher, nil
+}
+
 func (context *runContext) CheckCallNames(c *gc.C, names ...string) {
 	context.mu.Lock()
 	defer context.mu.Unlock()
 	context.stub.CheckCallNames(c, names...)
 }
 
+// newNotifyWatcher returns a watcher.NotifyWatcher that will fail with the
+// supplied error when Kill()ed.
+func newNotifyWatcher(err error) *notifyWatcher {
+	return &notifyWatcher{
+		Worker:  workertest.NewErrorWorker(err),
+		changes: make(chan struct{}, 1000),
+	}
+}
+
+type notifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
 type mockBroker struct {
 	caas.Broker
 	testing.Stub
 	spec      environs.CloudSpec
 	namespace string
+	cfg       *config.Config
 	mu        sync.Mutex
 }
 
 func newMockBroker(args environs.OpenParams) (caas.Broker, error) {
-	return &mockBroker{spec: args.Cloud, namespace: args.Config.Name()}, nil
+	return &mockBroker{spec: args.Cloud, namespace: args.Config.Name(), cfg: args.Config}, nil
+}
+
+func (b *mockBroker) SetConfig(cfg *config.Config) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.MethodCall(b, "SetConfig", cfg)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.cfg = cfg
+	return nil
+}
+
+func (b *mockBroker) Config() *config.Config {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg
 }
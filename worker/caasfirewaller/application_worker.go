@@ -4,11 +4,14 @@
 package caasfirewaller
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/caas"
 )
 
 type applicationWorker struct {
@@ -16,6 +19,7 @@ type applicationWorker struct {
 	application       string
 	applicationGetter ApplicationGetter
 	serviceExposer    ServiceExposer
+	policyManager     NetworkPolicyManager
 
 	lifeGetter LifeGetter
 
@@ -27,12 +31,14 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	policyManager NetworkPolicyManager,
 	lifeGetter LifeGetter,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
 		application:       application,
 		applicationGetter: applicationGetter,
 		serviceExposer:    applicationExposer,
+		policyManager:     policyManager,
 		lifeGetter:        lifeGetter,
 		initial:           true,
 	}
@@ -91,6 +97,9 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := w.ensureNetworkPolicy(exposed); err != nil {
+		return errors.Trace(err)
+	}
 	if !w.initial && exposed == w.previouslyExposed {
 		return nil
 	}
@@ -112,3 +121,20 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+// ensureNetworkPolicy updates the application's network policy so that
+// only the pods of related applications may connect to its pods. The
+// policy is refreshed on every change, since the application's relations
+// and service ports may both have changed since it was last written.
+func (w *applicationWorker) ensureNetworkPolicy(exposed bool) error {
+	related, err := w.applicationGetter.RelatedApplications(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sort.Strings(related)
+	params := caas.NetworkPolicyParams{
+		RelatedApplications: related,
+		Exposed:             exposed,
+	}
+	return errors.Trace(w.policyManager.EnsureNetworkPolicy(w.application, params))
+}
@@ -3,9 +3,19 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyManager provides an interface for restricting
+// ingress to the pods of an application.
+type NetworkPolicyManager interface {
+	EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error
+	DeleteNetworkPolicy(appName string) error
+}
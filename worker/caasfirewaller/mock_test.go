@@ -50,6 +50,7 @@ type mockApplicationGetter struct {
 	allWatcher *watchertest.MockStringsWatcher
 	appWatcher *watchertest.MockNotifyWatcher
 	exposed    bool
+	related    []string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -81,6 +82,32 @@ func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.C
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (m *mockApplicationGetter) RelatedApplications(appName string) ([]string, error) {
+	m.MethodCall(m, "RelatedApplications", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.related, nil
+}
+
+type mockNetworkPolicyManager struct {
+	testing.Stub
+	ensured chan<- caas.NetworkPolicyParams
+	deleted chan<- struct{}
+}
+
+func (m *mockNetworkPolicyManager) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error {
+	m.MethodCall(m, "EnsureNetworkPolicy", appName, params)
+	m.ensured <- params
+	return m.NextErr()
+}
+
+func (m *mockNetworkPolicyManager) DeleteNetworkPolicy(appName string) error {
+	m.MethodCall(m, "DeleteNetworkPolicy", appName)
+	m.deleted <- struct{}{}
+	return m.NextErr()
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	life life.Value
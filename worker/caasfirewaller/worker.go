@@ -19,6 +19,10 @@ type Config struct {
 	ApplicationGetter ApplicationGetter
 	LifeGetter        LifeGetter
 	ServiceExposer    ServiceExposer
+
+	// NetworkPolicyManager is used to restrict ingress to each
+	// application's pods to those of the related applications.
+	NetworkPolicyManager NetworkPolicyManager
 }
 
 // Validate validates the worker configuration.
@@ -32,6 +36,9 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.NetworkPolicyManager == nil {
+		return errors.NotValidf("missing NetworkPolicyManager")
+	}
 	return nil
 }
 
@@ -91,6 +98,9 @@ func (p *firewaller) loop() error {
 						}
 						delete(appWorkers, appId)
 					}
+					if err := p.config.NetworkPolicyManager.DeleteNetworkPolicy(appId); err != nil {
+						return errors.Annotatef(err, "deleting network policy for %q", appId)
+					}
 					continue
 				}
 				if err != nil {
@@ -105,6 +115,7 @@ func (p *firewaller) loop() error {
 					appId,
 					p.config.ApplicationGetter,
 					p.config.ServiceExposer,
+					p.config.NetworkPolicyManager,
 					p.config.LifeGetter,
 				)
 				if err != nil {
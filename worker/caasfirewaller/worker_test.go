@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
@@ -26,11 +27,14 @@ type WorkerSuite struct {
 	applicationGetter mockApplicationGetter
 	serviceExposer    mockServiceExposer
 	lifeGetter        mockLifeGetter
+	policyManager     mockNetworkPolicyManager
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
+	policyEnsured      chan caas.NetworkPolicyParams
+	policyDeleted      chan struct{}
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -42,6 +46,8 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.policyEnsured = make(chan caas.NetworkPolicyParams, 10)
+	s.policyDeleted = make(chan struct{}, 10)
 
 	s.applicationGetter = mockApplicationGetter{
 		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
@@ -56,11 +62,16 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		exposed:   s.serviceExposed,
 		unexposed: s.serviceUnexposed,
 	}
+	s.policyManager = mockNetworkPolicyManager{
+		ensured: s.policyEnsured,
+		deleted: s.policyDeleted,
+	}
 
 	s.config = caasfirewaller.Config{
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
+		ApplicationGetter:    &s.applicationGetter,
+		ServiceExposer:       &s.serviceExposer,
+		LifeGetter:           &s.lifeGetter,
+		NetworkPolicyManager: &s.policyManager,
 	}
 }
 
@@ -84,6 +95,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyManager = nil
+	}, `missing NetworkPolicyManager not valid`)
 }
 
 func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasfirewaller.Config), expect string) {
@@ -175,6 +190,71 @@ func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) waitPolicyEnsured(c *gc.C) caas.NetworkPolicyParams {
+	select {
+	case params := <-s.policyEnsured:
+		return params
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be ensured")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestNetworkPolicyFollowsRelations(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.related = []string{"mysql"}
+	s.sendApplicationExposedChange(c)
+	c.Assert(s.waitPolicyEnsured(c), jc.DeepEquals, caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mysql"},
+	})
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+
+	s.applicationGetter.related = []string{"mysql", "haproxy"}
+	s.sendApplicationExposedChange(c)
+	c.Assert(s.waitPolicyEnsured(c), jc.DeepEquals, caas.NetworkPolicyParams{
+		RelatedApplications: []string{"haproxy", "mysql"},
+	})
+	s.policyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", caas.NetworkPolicyParams{
+		RelatedApplications: []string{"haproxy", "mysql"},
+	})
+}
+
+func (s *WorkerSuite) TestNetworkPolicyExposed(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	c.Assert(s.waitPolicyEnsured(c), jc.DeepEquals, caas.NetworkPolicyParams{
+		Exposed: true,
+	})
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+}
+
 func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -216,6 +296,14 @@ func (s *WorkerSuite) TestRemoveApplicationStopsWatchingApplication(c *gc.C) {
 
 	err = workertest.CheckKilled(c, s.applicationGetter.appWatcher)
 	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-s.policyDeleted:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be deleted")
+	}
+	s.policyManager.CheckCallNames(c, "DeleteNetworkPolicy")
+	s.policyManager.CheckCall(c, 0, "DeleteNetworkPolicy", "gitlab")
 }
 
 func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
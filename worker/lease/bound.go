@@ -11,15 +11,9 @@ import (
 	"github.com/juju/juju/core/lease"
 )
 
-// checkerClaimer combines the lease.Checker and lease.Claimer
-// interfaces.
-type checkerClaimer interface {
-	lease.Checker
-	lease.Claimer
-}
-
-// boundManager implements lease.Claimer and lease.Checker - it
-// represents a lease manager for a specific namespace and model.
+// boundManager implements lease.Claimer, lease.Checker, lease.Pinner
+// and lease.Transferrer - it represents a lease manager for a specific
+// namespace and model.
 type boundManager struct {
 	manager   *Manager
 	secretary Secretary
@@ -85,3 +79,73 @@ func (b *boundManager) Token(leaseName, holderName string) lease.Token {
 		stop:       b.manager.catacomb.Dying(),
 	}
 }
+
+// Pin is part of the lease.Pinner interface.
+func (b *boundManager) Pin(leaseName, entity string) error {
+	key := b.leaseKey(leaseName)
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot pin lease %q", leaseName)
+	}
+	return pin{
+		leaseKey: key,
+		entity:   entity,
+		response: make(chan error),
+		stop:     b.manager.catacomb.Dying(),
+	}.invoke(b.manager.pins)
+}
+
+// Unpin is part of the lease.Pinner interface.
+func (b *boundManager) Unpin(leaseName, entity string) error {
+	key := b.leaseKey(leaseName)
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot unpin lease %q", leaseName)
+	}
+	return pin{
+		leaseKey: key,
+		entity:   entity,
+		unpin:    true,
+		response: make(chan error),
+		stop:     b.manager.catacomb.Dying(),
+	}.invoke(b.manager.pins)
+}
+
+// Pinned is part of the lease.Pinner interface.
+func (b *boundManager) Pinned() map[string][]string {
+	result := make(map[string][]string)
+	for key, entities := range b.manager.config.Store.Pinned() {
+		if key.Namespace != b.namespace || key.ModelUUID != b.modelUUID {
+			continue
+		}
+		result[key.Lease] = entities
+	}
+	return result
+}
+
+// Transfer is part of the lease.Transferrer interface.
+func (b *boundManager) Transfer(leaseName, holderName string, duration time.Duration) error {
+	key := b.leaseKey(leaseName)
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease %q", leaseName)
+	}
+	if err := b.secretary.CheckHolder(holderName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease to holder %q", holderName)
+	}
+	if err := b.secretary.CheckDuration(duration); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease for %s", duration)
+	}
+	return transfer{
+		leaseKey:   key,
+		holderName: holderName,
+		duration:   duration,
+		response:   make(chan error),
+		stop:       b.manager.catacomb.Dying(),
+	}.invoke(b.manager.transfers)
+}
+
+func (b *boundManager) leaseKey(leaseName string) lease.Key {
+	return lease.Key{
+		Namespace: b.namespace,
+		ModelUUID: b.modelUUID,
+		Lease:     leaseName,
+	}
+}
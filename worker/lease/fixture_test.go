@@ -70,6 +70,10 @@ type Fixture struct {
 	// test starts up.
 	leases map[corelease.Key]corelease.Info
 
+	// pinned contains the pinned leases the corelease.Store should
+	// report, and the entities that pinned them.
+	pinned map[corelease.Key][]string
+
 	// expectCalls contains the calls that should be made to the corelease.Store
 	// in the course of a test. By specifying a callback you can cause the
 	// reported leases to change.
@@ -86,6 +90,9 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testclock.Clock)) {
 	clock := testclock.NewClock(defaultClockStart)
 	store := NewStore(fix.leases, fix.expectCalls)
+	for key, entities := range fix.pinned {
+		store.pinned[key] = entities
+	}
 	manager, err := lease.NewManager(lease.ManagerConfig{
 		Clock: clock,
 		Store: store,
@@ -67,12 +67,15 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		logContext = logContext[:6]
 	}
 	manager := &Manager{
-		config:     config,
-		claims:     make(chan claim),
-		checks:     make(chan check),
-		blocks:     make(chan block),
-		errors:     make(chan error),
-		logContext: logContext,
+		config:      config,
+		claims:      make(chan claim),
+		checks:      make(chan check),
+		blocks:      make(chan block),
+		pins:        make(chan pin),
+		transfers:   make(chan transfer),
+		transferred: make(chan lease.Key),
+		errors:      make(chan error),
+		logContext:  logContext,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -110,6 +113,16 @@ type Manager struct {
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
 
+	// pins is used to deliver lease pin and unpin requests to the loop.
+	pins chan pin
+
+	// transfers is used to deliver lease transfer requests to the loop.
+	transfers chan transfer
+
+	// transferred is used to tell the loop which leases have changed
+	// hands, so that anyone waiting for them can be woken up.
+	transferred chan lease.Key
+
 	// errors is used to send errors from background claim or tick
 	// goroutines back to the main loop.
 	errors chan error
@@ -133,11 +146,10 @@ func (manager *Manager) loop() error {
 			return errors.Trace(err)
 		}
 
-		leases := manager.config.Store.Leases()
-		for leaseName := range blocks {
-			if _, found := leases[leaseName]; !found {
-				manager.config.Logger.Tracef("[%s] unblocking: %s", manager.logContext, leaseName)
-				blocks.unblock(leaseName)
+		for leaseKey := range blocks {
+			if _, found := manager.config.Store.Lease(leaseKey); !found {
+				manager.config.Logger.Tracef("[%s] unblocking: %s", manager.logContext, leaseKey)
+				blocks.unblock(leaseKey)
 			}
 		}
 	}
@@ -160,11 +172,18 @@ func (manager *Manager) choose(blocks blocks) error {
 		// TODO(raftlease): Include the other key items.
 		manager.config.Logger.Tracef("[%s] adding block for: %s", manager.logContext, block.leaseKey.Lease)
 		blocks.add(block)
+	case pin := <-manager.pins:
+		go manager.retryingPin(pin)
+	case transfer := <-manager.transfers:
+		go manager.retryingTransfer(transfer)
+	case leaseKey := <-manager.transferred:
+		manager.config.Logger.Tracef("[%s] unblocking transferred lease: %s", manager.logContext, leaseKey.Lease)
+		blocks.unblock(leaseKey)
 	}
 	return nil
 }
 
-func (manager *Manager) bind(namespace, modelUUID string) (checkerClaimer, error) {
+func (manager *Manager) bind(namespace, modelUUID string) (*boundManager, error) {
 	secretary, err := manager.config.Secretary(namespace)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -187,6 +206,17 @@ func (manager *Manager) Claimer(namespace, modelUUID string) (lease.Claimer, err
 	return manager.bind(namespace, modelUUID)
 }
 
+// Pinner returns a lease.Pinner for the specified namespace and model.
+func (manager *Manager) Pinner(namespace, modelUUID string) (lease.Pinner, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
+// Transferrer returns a lease.Transferrer for the specified namespace
+// and model.
+func (manager *Manager) Transferrer(namespace, modelUUID string) (lease.Transferrer, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
 // retryingClaim handles timeouts when claiming, and responds to the
 // claiming party when it eventually succeeds or fails, or if it times
 // out after a number of retries.
@@ -245,10 +275,7 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 		case <-manager.catacomb.Dying():
 			return false, manager.catacomb.ErrDying()
 		default:
-			// TODO(jam) 2017-10-31: We are asking for all leases just to look
-			// up one of them. Shouldn't the store.Leases() interface allow us
-			// to just query for a single entry?
-			info, found := store.Leases()[claim.leaseKey]
+			info, found := store.Lease(claim.leaseKey)
 			switch {
 			case !found:
 				manager.config.Logger.Tracef("[%s] %s asked for lease %s, no lease found, claiming for %s", manager.logContext, claim.holderName, claim.leaseKey.Lease, claim.duration)
@@ -256,6 +283,10 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 			case info.Holder == claim.holderName:
 				manager.config.Logger.Tracef("[%s] %s extending lease %s for %s", manager.logContext, claim.holderName, claim.leaseKey.Lease, claim.duration)
 				err = store.ExtendLease(claim.leaseKey, request)
+				if errors.Cause(err) == lease.ErrClaimDenied {
+					manager.config.Logger.Tracef("[%s] lease %s is being transferred from %s, rejecting", manager.logContext, claim.leaseKey.Lease, claim.holderName)
+					return false, nil
+				}
 			default:
 				// Note: (jam) 2017-10-31) We don't check here if the lease has
 				// expired for the current holder. Should we?
@@ -272,19 +303,86 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 	return true, nil
 }
 
+// retryingPin handles timeouts when pinning or unpinning, and responds
+// to the requesting party when it eventually succeeds or fails. Pins
+// don't affect when the loop needs to wake up, so errors are only
+// reported to the requester.
+func (manager *Manager) retryingPin(pin pin) {
+	err := manager.retry("pin", func() error {
+		if pin.unpin {
+			manager.config.Logger.Tracef("[%s] %s unpinning lease %s", manager.logContext, pin.entity, pin.leaseKey.Lease)
+			return manager.config.Store.UnpinLease(pin.leaseKey, pin.entity)
+		}
+		manager.config.Logger.Tracef("[%s] %s pinning lease %s", manager.logContext, pin.entity, pin.leaseKey.Lease)
+		return manager.config.Store.PinLease(pin.leaseKey, pin.entity)
+	})
+	if lease.IsTimeout(err) {
+		manager.config.Logger.Warningf("[%s] retrying timed out while handling pin", manager.logContext)
+		pin.respond(lease.ErrTimeout)
+		return
+	}
+	pin.respond(errors.Trace(err))
+}
+
+// retryingTransfer handles timeouts when transferring, and responds to
+// the requesting party when it eventually succeeds or fails.
+func (manager *Manager) retryingTransfer(transfer transfer) {
+	err := manager.retry("transfer", func() error {
+		return manager.handleTransfer(transfer)
+	})
+	if lease.IsTimeout(err) {
+		manager.config.Logger.Warningf("[%s] retrying timed out while handling transfer", manager.logContext)
+		transfer.respond(lease.ErrTimeout)
+		return
+	}
+	transfer.respond(errors.Trace(err))
+}
+
+// handleTransfer processes the supplied transfer. A lease held by
+// someone else isn't taken from them, as they were promised it until
+// it expires: instead the store records that it passes to the new
+// holder then, and the holder can no longer extend it. The store
+// refuses to transfer pinned leases in the same operation, so a pin
+// can't slip in between the check and the transfer.
+func (manager *Manager) handleTransfer(transfer transfer) error {
+	store := manager.config.Store
+	request := lease.Request{transfer.holderName, transfer.duration}
+	err := lease.ErrInvalid
+	for lease.IsInvalid(err) {
+		select {
+		case <-manager.catacomb.Dying():
+			return manager.catacomb.ErrDying()
+		default:
+		}
+		info, found := store.Lease(transfer.leaseKey)
+		switch {
+		case !found:
+			manager.config.Logger.Tracef("[%s] transferring unheld lease %s to %s", manager.logContext, transfer.leaseKey.Lease, transfer.holderName)
+			err = store.ClaimLease(transfer.leaseKey, request)
+		case info.Holder == transfer.holderName:
+			manager.config.Logger.Tracef("[%s] %s already holds lease %s, extending", manager.logContext, transfer.holderName, transfer.leaseKey.Lease)
+			err = store.ExtendLease(transfer.leaseKey, request)
+		default:
+			manager.config.Logger.Tracef("[%s] transferring lease %s from %s to %s when it expires", manager.logContext, transfer.leaseKey.Lease, info.Holder, transfer.holderName)
+			err = store.TransferLease(transfer.leaseKey, info.Holder, request)
+		}
+	}
+	return errors.Trace(err)
+}
+
 // handleCheck processes and responds to the supplied check. It will only return
 // unrecoverable errors; mere untruth of the assertion just indicates a bad
 // request, and is communicated back to the check's originator.
 func (manager *Manager) handleCheck(check check) error {
 	store := manager.config.Store
 	manager.config.Logger.Tracef("[%s] handling Check for lease %s on behalf of %s", manager.logContext, check.leaseKey.Lease, check.holderName)
-	info, found := store.Leases()[check.leaseKey]
+	info, found := store.Lease(check.leaseKey)
 	if !found || info.Holder != check.holderName {
 		manager.config.Logger.Tracef("[%s] handling Check for lease %s on behalf of %s, not found, refreshing", manager.logContext, check.leaseKey.Lease, check.holderName)
 		if err := store.Refresh(); err != nil {
 			return errors.Trace(err)
 		}
-		info, found = store.Leases()[check.leaseKey]
+		info, found = store.Lease(check.leaseKey)
 	}
 
 	var response error
@@ -365,6 +463,7 @@ func (manager *Manager) tick(now time.Time) error {
 		return errors.Trace(err)
 	}
 	leases := store.Leases()
+	pinned := store.Pinned()
 
 	// Sort lease keys so we expire in a predictable order for the tests.
 	keys := make([]lease.Key, 0, len(leases))
@@ -377,15 +476,30 @@ func (manager *Manager) tick(now time.Time) error {
 
 	manager.config.Logger.Tracef("[%s] checking expiry on %d leases", manager.logContext, len(leases))
 	expired := make([]lease.Key, 0)
+	var transferred []lease.Key
 	for _, key := range keys {
 		if leases[key].Expiry.After(now) {
 			continue
 		}
+		if _, found := pinned[key]; found {
+			// Pinned leases are kept by their holders until
+			// they're unpinned.
+			continue
+		}
 		err := store.ExpireLease(key)
-		if err != nil && !lease.IsInvalid(err) {
+		if lease.IsInvalid(err) {
+			// The lease was extended or pinned since the
+			// refresh, so it hasn't expired.
+			continue
+		} else if err != nil {
 			return errors.Trace(err)
 		}
 		expired = append(expired, key)
+		if info, found := store.Lease(key); found && info.Holder != leases[key].Holder {
+			// The lease was handed to the holder it was
+			// transferred to.
+			transferred = append(transferred, key)
+		}
 	}
 	if len(expired) == 0 {
 		manager.config.Logger.Debugf("[%s] no leases to expire", manager.logContext)
@@ -396,9 +510,33 @@ func (manager *Manager) tick(now time.Time) error {
 		}
 		manager.config.Logger.Debugf("[%s] expired %d leases: %s", manager.logContext, len(expired), strings.Join(names, ", "))
 	}
+	// Wake anyone waiting for the transferred leases: the new holder's
+	// next claim will succeed, while everyone else's is denied.
+	for _, key := range transferred {
+		select {
+		case <-manager.catacomb.Dying():
+			return nil
+		case manager.transferred <- key:
+		}
+	}
 	return nil
 }
 
+// retry runs the supplied operation, retrying it if it times out.
+func (manager *Manager) retry(verb string, operation func() error) error {
+	var err error
+	for a := manager.startRetry(); a.Next(); {
+		err = operation()
+		if !lease.IsTimeout(err) {
+			break
+		}
+		if a.More() {
+			manager.config.Logger.Tracef("[%s] timed out handling %s, retrying...", manager.logContext, verb)
+		}
+	}
+	return err
+}
+
 func (manager *Manager) startRetry() *retry.Attempt {
 	return retry.StartWithCancel(
 		retry.LimitCount(maxRetries, retry.Exponential{
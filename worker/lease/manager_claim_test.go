@@ -229,6 +229,29 @@ func (s *ClaimSuite) TestExtendLease_Failure_OtherHolder(c *gc.C) {
 	})
 }
 
+func (s *ClaimSuite) TestExtendLease_Failure_Transferring(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/0", time.Minute},
+			},
+			err: corelease.ErrClaimDenied,
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getClaimer(c, manager).Claim("redis", "redis/0", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+	})
+}
+
 func (s *ClaimSuite) TestExtendLease_Failure_Error(c *gc.C) {
 	fix := &Fixture{
 		leases: map[corelease.Key]corelease.Info{
@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type PinSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PinSuite{})
+
+func (s *PinSuite) TestPin_Success(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "user-admin"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).Pin("redis", "user-admin")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPin_Error(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "user-admin"},
+			err:    errors.New("lease pinning not implemented"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).Pin("redis", "user-admin")
+		c.Check(err, gc.ErrorMatches, "lease pinning not implemented")
+	})
+}
+
+func (s *PinSuite) TestPin_Timeout(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "user-admin"},
+			err:    corelease.ErrTimeout,
+		}, {
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "user-admin"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testclock.Clock) {
+		result := make(chan error)
+		go func() {
+			result <- getPinner(c, manager).Pin("redis", "user-admin")
+		}()
+		// We want two waiters - one for the main loop, and one for
+		// the retry delay.
+		waitAdvance(c, clock, 50*time.Millisecond, 2)
+		select {
+		case err := <-result:
+			c.Check(err, jc.ErrorIsNil)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for pin")
+		}
+	})
+}
+
+func (s *PinSuite) TestPin_InvalidLease(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).Pin("INVALID", "user-admin")
+		c.Check(err, gc.ErrorMatches, `cannot pin lease "INVALID": name not valid`)
+	})
+}
+
+func (s *PinSuite) TestUnpin_Success(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "UnpinLease",
+			args:   []interface{}{key("redis"), "user-admin"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).Unpin("redis", "user-admin")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinned(c *gc.C) {
+	fix := &Fixture{
+		pinned: map[corelease.Key][]string{
+			key("redis"): {"user-admin", "machine-0"},
+			key("namespace", "otherModelUUID", "mysql"): {"user-admin"},
+			key("otherNamespace", "modelUUID", "mysql"): {"user-admin"},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		c.Check(getPinner(c, manager).Pinned(), jc.DeepEquals, map[string][]string{
+			"redis": {"user-admin", "machine-0"},
+		})
+	})
+}
+
+func (s *PinSuite) TestPinnedLeaseNotExpired(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(-time.Second),
+			},
+		},
+		pinned: map[corelease.Key][]string{
+			key("redis"): {"user-admin"},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, _ *testclock.Clock) {})
+}
+
+func getPinner(c *gc.C, manager *lease.Manager) corelease.Pinner {
+	pinner, err := manager.Pinner("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return pinner
+}
@@ -0,0 +1,216 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type TransferSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TransferSuite{})
+
+func (s *TransferSuite) TestTransfer_Unheld(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/1", time.Minute},
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_AlreadyHeld(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/1",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/1", time.Minute},
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_FromOtherHolder(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute},
+			},
+		}, {
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{key("redis")},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(2 * time.Minute),
+				}
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testclock.Clock) {
+		blockTest := newBlockTest(manager, key("redis"))
+		blockTest.assertBlocked(c)
+
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+
+		// The current holder keeps the lease until it expires.
+		waitAdvance(c, clock, almostSeconds(60), 1)
+		blockTest.assertBlocked(c)
+
+		// Then it's handed over, and waiters are woken so the new
+		// holder can pick it up.
+		waitAdvance(c, clock, time.Second, 1)
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_HolderChangedInBetween(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute},
+			},
+			err: corelease.ErrInvalid,
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/2",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}, {
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/2",
+				corelease.Request{"redis/1", time.Minute},
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_Pinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			// The store checks the pin as it records the transfer.
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute},
+			},
+			err: corelease.ErrPinned,
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrPinned)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_Error(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute},
+			},
+			err: errors.New("snarf"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, gc.ErrorMatches, "snarf")
+	})
+}
+
+func (s *TransferSuite) TestTransfer_InvalidHolder(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "INVALID", time.Minute)
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease to holder "INVALID": name not valid`)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_InvalidDuration(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Second)
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease for 1s: time not valid`)
+	})
+}
+
+func getTransferrer(c *gc.C, manager *lease.Manager) corelease.Transferrer {
+	transferrer, err := manager.Transferrer("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return transferrer
+}
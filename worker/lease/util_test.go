@@ -47,6 +47,7 @@ func (Secretary) CheckDuration(duration time.Duration) error {
 type Store struct {
 	mu           sync.Mutex
 	leases       map[lease.Key]lease.Info
+	pinned       map[lease.Key][]string
 	expect       []call
 	failed       chan error
 	runningCalls int
@@ -65,6 +66,7 @@ func NewStore(leases map[lease.Key]lease.Info, expect []call) *Store {
 	}
 	return &Store{
 		leases: leases,
+		pinned: make(map[lease.Key][]string),
 		expect: expect,
 		done:   done,
 		failed: make(chan error, 1000),
@@ -98,6 +100,38 @@ func (store *Store) Leases() map[lease.Key]lease.Info {
 	return result
 }
 
+// LeaseGroup is part of the lease.Store interface.
+func (store *Store) LeaseGroup(namespace, modelUUID string) map[lease.Key]lease.Info {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key]lease.Info)
+	for k, v := range store.leases {
+		if k.Namespace == namespace && k.ModelUUID == modelUUID {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Lease is part of the lease.Store interface.
+func (store *Store) Lease(key lease.Key) (lease.Info, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	info, found := store.leases[key]
+	return info, found
+}
+
+// Pinned is part of the lease.Store interface.
+func (store *Store) Pinned() map[lease.Key][]string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key][]string)
+	for k, v := range store.pinned {
+		result[k] = v
+	}
+	return result
+}
+
 func (store *Store) closeIfEmpty() {
 	// This must be called with the lock held.
 	if store.runningCalls > 1 {
@@ -166,6 +200,21 @@ func (store *Store) ExpireLease(key lease.Key) error {
 	return store.call("ExpireLease", []interface{}{key})
 }
 
+// TransferLease is part of the corelease.Store interface.
+func (store *Store) TransferLease(key lease.Key, holder string, request lease.Request) error {
+	return store.call("TransferLease", []interface{}{key, holder, request})
+}
+
+// PinLease is part of the corelease.Store interface.
+func (store *Store) PinLease(key lease.Key, entity string) error {
+	return store.call("PinLease", []interface{}{key, entity})
+}
+
+// UnpinLease is part of the corelease.Store interface.
+func (store *Store) UnpinLease(key lease.Key, entity string) error {
+	return store.call("UnpinLease", []interface{}{key, entity})
+}
+
 // Refresh is part of the lease.Store interface.
 func (store *Store) Refresh() error {
 	return store.call("Refresh", nil)
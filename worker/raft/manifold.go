@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/pubsub"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
@@ -18,9 +19,10 @@ import (
 // ManifoldConfig holds the information necessary to run a raft
 // worker in a dependency.Engine.
 type ManifoldConfig struct {
-	ClockName     string
-	AgentName     string
-	TransportName string
+	ClockName      string
+	AgentName      string
+	TransportName  string
+	CentralHubName string
 
 	FSM       raft.FSM
 	Logger    Logger
@@ -38,6 +40,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.TransportName == "" {
 		return errors.NotValidf("empty TransportName")
 	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
 	if config.FSM == nil {
 		return errors.NotValidf("nil FSM")
 	}
@@ -57,6 +62,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.ClockName,
 			config.AgentName,
 			config.TransportName,
+			config.CentralHubName,
 		},
 		Start:  config.start,
 		Output: raftOutput,
@@ -84,6 +90,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// TODO(axw) make the directory path configurable, so we can
 	// potentially have multiple Rafts. The dqlite raft should go
 	// in <data-dir>/dqlite.
@@ -97,6 +108,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		LocalID:    raft.ServerID(agentConfig.Tag().Id()),
 		Transport:  transport,
 		Clock:      clk,
+		Hub:        hub,
 	})
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -30,6 +31,7 @@ type ManifoldSuite struct {
 	agent     *mockAgent
 	transport *coreraft.InmemTransport
 	clock     *testclock.Clock
+	hub       *pubsub.StructuredHub
 	fsm       *raft.SimpleFSM
 	logger    loggo.Logger
 	worker    *mockRaftWorker
@@ -64,23 +66,26 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	})
 
 	s.clock = testclock.NewClock(time.Time{})
+	s.hub = pubsub.NewStructuredHub(nil)
 
 	s.context = s.newContext(nil)
 	s.manifold = raft.Manifold(raft.ManifoldConfig{
-		ClockName:     "clock",
-		AgentName:     "agent",
-		TransportName: "transport",
-		FSM:           s.fsm,
-		Logger:        s.logger,
-		NewWorker:     s.newWorker,
+		ClockName:      "clock",
+		AgentName:      "agent",
+		TransportName:  "transport",
+		CentralHubName: "central-hub",
+		FSM:            s.fsm,
+		Logger:         s.logger,
+		NewWorker:      s.newWorker,
 	})
 }
 
 func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
 	resources := map[string]interface{}{
-		"agent":     s.agent,
-		"transport": s.transport,
-		"clock":     s.clock,
+		"agent":       s.agent,
+		"transport":   s.transport,
+		"clock":       s.clock,
+		"central-hub": s.hub,
 	}
 	for k, v := range overlay {
 		resources[k] = v
@@ -97,7 +102,7 @@ func (s *ManifoldSuite) newWorker(config raft.Config) (worker.Worker, error) {
 }
 
 var expectedInputs = []string{
-	"clock", "agent", "transport",
+	"clock", "agent", "transport", "central-hub",
 }
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
@@ -130,6 +135,7 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		LocalID:    "99",
 		Transport:  s.transport,
 		Clock:      s.clock,
+		Hub:        s.hub,
 	})
 }
 
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/pubsub/controller"
+)
+
+// subscribe subscribes to raft status and step down requests on the
+// hub. A step down request for the local node, while it is the
+// leader, is passed on to the worker loop on the stepDown channel.
+func (w *Worker) subscribe(r *raft.Raft, stepDown chan<- struct{}) (func(), error) {
+	unsubscribeStatus, err := w.config.Hub.Subscribe(
+		controller.RaftStatusRequestTopic,
+		func(_ string, _ controller.RaftStatusRequest, err error) {
+			if err != nil {
+				w.config.Logger.Errorf("raft status request: %v", err)
+				return
+			}
+			if _, err := w.config.Hub.Publish(controller.RaftStatusTopic, w.status(r)); err != nil {
+				w.config.Logger.Errorf("publishing raft status: %v", err)
+			}
+		},
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "subscribing to %q", controller.RaftStatusRequestTopic)
+	}
+	unsubscribeStepDown, err := w.config.Hub.Subscribe(
+		controller.RaftStepDownTopic,
+		func(_ string, req controller.RaftStepDown, err error) {
+			if err != nil {
+				w.config.Logger.Errorf("raft step down request: %v", err)
+				return
+			}
+			if req.Leader != string(w.config.LocalID) || r.State() != raft.Leader {
+				return
+			}
+			select {
+			case stepDown <- struct{}{}:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		unsubscribeStatus()
+		return nil, errors.Annotatef(err, "subscribing to %q", controller.RaftStepDownTopic)
+	}
+	return func() {
+		unsubscribeStatus()
+		unsubscribeStepDown()
+	}, nil
+}
+
+// status returns the local node's view of the raft cluster. Raft
+// only tells us the leader's address, so the leader's ID is looked up
+// in the cluster configuration.
+func (w *Worker) status(r *raft.Raft) controller.RaftStatus {
+	state := r.State()
+	status := controller.RaftStatus{
+		ID:    string(w.config.LocalID),
+		State: state.String(),
+	}
+	if term, err := strconv.ParseUint(r.Stats()["term"], 10, 64); err == nil {
+		status.Term = term
+	}
+	future := r.GetConfiguration()
+	if err := future.Error(); err != nil {
+		w.config.Logger.Warningf("getting raft configuration: %v", err)
+		return status
+	}
+	leader := r.Leader()
+	for _, server := range future.Configuration().Servers {
+		if leader != "" && server.Address == leader {
+			status.Leader = string(server.ID)
+		}
+		if state == raft.Leader && server.Suffrage == raft.Voter {
+			status.Voters++
+		}
+	}
+	return status
+}
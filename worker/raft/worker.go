@@ -67,6 +67,12 @@ var (
 	// chance to see any configuration changes the backstop worker
 	// might have force-appended to the raft log.
 	ErrNoLeaderTimeout = errors.New("timed out waiting for leader contact")
+
+	// ErrStepDown is returned by the worker loop when the local node
+	// is the leader and has been asked to step down. Restarting the
+	// worker shuts down the local raft node, so the other servers
+	// elect a new leader.
+	ErrStepDown = errors.New("stepping down as raft leader")
 )
 
 // Logger represents the logging methods called.
@@ -128,6 +134,17 @@ type Config struct {
 	// SnapshotRetention is the non-negative number of snapshots
 	// to retain on disk. If zero, defaults to 2.
 	SnapshotRetention int
+
+	// Hub, if non-nil, is the central hub on which the worker
+	// answers raft status requests and requests for the leader to
+	// step down, made to rehearse controller failover.
+	Hub Hub
+}
+
+// Hub represents the methods of the central hub the worker uses.
+type Hub interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
 }
 
 // Validate validates the raft worker configuration.
@@ -329,6 +346,15 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 	r.RegisterObserver(observer)
 	defer r.DeregisterObserver(observer)
 
+	stepDown := make(chan struct{}, 1)
+	if w.config.Hub != nil {
+		unsubscribe, err := w.subscribe(r, stepDown)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer unsubscribe()
+	}
+
 	// Every 10 seconds we check whether the no-leader timeout should
 	// trip.
 	noLeaderCheck := w.config.Clock.After(noLeaderFrequency)
@@ -344,6 +370,9 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 			// the local node was removed from the cluster
 			// configuration, causing it to shutdown.
 			return errors.New("raft shutdown")
+		case <-stepDown:
+			w.config.Logger.Warningf("stepping down as raft leader to rehearse failover")
+			return ErrStepDown
 		case now := <-noLeaderCheck:
 			noLeaderCheck = w.config.Clock.After(noLeaderFrequency)
 			if r.State() == raft.Leader {
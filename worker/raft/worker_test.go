@@ -11,11 +11,13 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/pubsub/controller"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/rafttest"
@@ -116,6 +118,7 @@ type WorkerSuite struct {
 	workerFixture
 	worker *raft.Worker
 	clock  *testclock.Clock
+	hub    *pubsub.StructuredHub
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -142,6 +145,8 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.clock = testclock.NewClock(time.Now())
 	s.config.Clock = s.clock
 	s.config.NoLeaderTimeout = 4 * time.Second
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.config.Hub = s.hub
 
 	s.config.Transport = transport
 	s.config.FSM = fsm
@@ -333,6 +338,58 @@ func (s *WorkerSuite) TestNoLeaderTimeout(c *gc.C) {
 	c.Assert(workertest.CheckKilled(c, s.worker), gc.Equals, raft.ErrNoLeaderTimeout)
 }
 
+func (s *WorkerSuite) TestRaftStatus(c *gc.C) {
+	s.waitLeader(c)
+
+	statuses := make(chan controller.RaftStatus, 1)
+	unsubscribe, err := s.hub.Subscribe(controller.RaftStatusTopic,
+		func(_ string, status controller.RaftStatus, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			statuses <- status
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	_, err = s.hub.Publish(controller.RaftStatusRequestTopic, controller.RaftStatusRequest{Requester: "test"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case status := <-statuses:
+		c.Assert(status, jc.DeepEquals, controller.RaftStatus{
+			ID:     "123",
+			State:  "Leader",
+			Leader: "123",
+			Term:   status.Term,
+			Voters: 1,
+		})
+		c.Assert(status.Term > 0, jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for raft status")
+	}
+}
+
+func (s *WorkerSuite) TestStepDown(c *gc.C) {
+	s.waitLeader(c)
+
+	_, err := s.hub.Publish(controller.RaftStepDownTopic, controller.RaftStepDown{Leader: "123"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(workertest.CheckKilled(c, s.worker), gc.Equals, raft.ErrStepDown)
+}
+
+func (s *WorkerSuite) TestStepDownIgnoredForOtherServer(c *gc.C) {
+	s.waitLeader(c)
+
+	done, err := s.hub.Publish(controller.RaftStepDownTopic, controller.RaftStepDown{Leader: "456"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for step down request to be handled")
+	}
+	workertest.CheckAlive(c, s.worker)
+}
+
 // Connect the provided transport bidirectionally.
 func connectTransports(transports ...coreraft.LoopbackTransport) {
 	for _, t1 := range transports {